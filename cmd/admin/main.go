@@ -14,6 +14,7 @@ import (
 	"ride-hail/internal/shared/config"
 	"ride-hail/internal/shared/logger"
 	"ride-hail/internal/shared/postgres"
+	"ride-hail/internal/shared/storage"
 )
 
 func main() {
@@ -55,7 +56,13 @@ func main() {
 		Port: port,
 	}
 
-	app := admin.NewApp(db, serverConfig, log)
+	documents, err := storage.NewLocalDisk(getEnv("DOCUMENTS_DIR", "./data/documents"))
+	if err != nil {
+		log.Error(ctx, "storage_error", "Failed to open document storage", err)
+		os.Exit(1)
+	}
+
+	app := admin.NewApp(db, serverConfig, documents, log)
 
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
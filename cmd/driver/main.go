@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
@@ -14,8 +15,13 @@ import (
 	"ride-hail/internal/shared/broker/messages"
 	"ride-hail/internal/shared/broker/rabbitmq"
 	"ride-hail/internal/shared/config"
+	"ride-hail/internal/shared/destination"
+	"ride-hail/internal/shared/fatigue"
 	"ride-hail/internal/shared/logger"
 	"ride-hail/internal/shared/postgres"
+	"ride-hail/internal/shared/pricing"
+	"ride-hail/internal/shared/reliability"
+	"ride-hail/internal/shared/storage"
 )
 
 func main() {
@@ -67,6 +73,10 @@ func main() {
 	slog.Info("connected to the message broker successfully")
 
 	// Declare exchanges (как в ride service)
+	if err := rabbit.DeclareExchanges(messages.ExchangeRideTopic, "topic", true, false, false, false, nil); err != nil {
+		slog.Error("failed to declare ride exchange", "err", err.Error())
+		os.Exit(1)
+	}
 	if err := rabbit.DeclareExchanges(messages.ExchangeDriverTopic, "topic", true, false, false, false, nil); err != nil {
 		slog.Error("failed to declare driver exchange", "err", err.Error())
 		os.Exit(1)
@@ -79,6 +89,42 @@ func main() {
 
 	// Declare queues
 	queues := []broker.QueueConfig{
+		{
+			Name:       messages.QueueRideRequests,
+			Durable:    true,
+			AutoDelete: false,
+			Exclusive:  false,
+			NoWait:     false,
+			Exchange:   messages.ExchangeRideTopic,
+			RoutingKey: "ride.request.*",
+		},
+		{
+			Name:       messages.QueuePassengerUpdates,
+			Durable:    true,
+			AutoDelete: false,
+			Exclusive:  false,
+			NoWait:     false,
+			Exchange:   messages.ExchangeRideTopic,
+			RoutingKey: "ride.passenger.*",
+		},
+		{
+			Name:       messages.QueueDriverChat,
+			Durable:    true,
+			AutoDelete: false,
+			Exclusive:  false,
+			NoWait:     false,
+			Exchange:   messages.ExchangeRideTopic,
+			RoutingKey: "ride.chat.*",
+		},
+		{
+			Name:       messages.QueuePassengerChat,
+			Durable:    true,
+			AutoDelete: false,
+			Exclusive:  false,
+			NoWait:     false,
+			Exchange:   messages.ExchangeDriverTopic,
+			RoutingKey: "driver.chat.*",
+		},
 		{
 			Name:       messages.QueueDriverMatching,
 			Durable:    true,
@@ -105,7 +151,80 @@ func main() {
 	}
 	slog.Info("queues declared successfully")
 
-	app := driver.NewApp(db, rabbit)
+	// Waiting at pickup: free grace period, then per-minute charges; no-show allowed after the timeout
+	waiting := pricing.WaitingPolicy{
+		Grace:       pricing.DefaultWaitingGrace,
+		NoShowAfter: pricing.DefaultNoShowAfter,
+	}
+	if d, err := time.ParseDuration(getEnv("WAITING_GRACE_PERIOD", "")); err == nil {
+		waiting.Grace = d
+	}
+	if d, err := time.ParseDuration(getEnv("NO_SHOW_TIMEOUT", "")); err == nil {
+		waiting.NoShowAfter = d
+	}
+
+	// Fatigue limits: online hours in a sliding window and continuous driving before a mandatory break
+	fatiguePolicy := fatigue.DefaultPolicy
+	if d, err := time.ParseDuration(getEnv("FATIGUE_MAX_ONLINE", "")); err == nil {
+		fatiguePolicy.MaxOnline = d
+	}
+	if d, err := time.ParseDuration(getEnv("FATIGUE_WINDOW", "")); err == nil {
+		fatiguePolicy.Window = d
+	}
+	if d, err := time.ParseDuration(getEnv("FATIGUE_MAX_CONTINUOUS", "")); err == nil {
+		fatiguePolicy.MaxContinuous = d
+	}
+	if d, err := time.ParseDuration(getEnv("FATIGUE_REQUIRED_BREAK", "")); err == nil {
+		fatiguePolicy.RequiredBreak = d
+	}
+	if d, err := time.ParseDuration(getEnv("FATIGUE_WARN_BEFORE", "")); err == nil {
+		fatiguePolicy.WarnBefore = d
+	}
+
+	// Destination mode: uses per day, progress towards the destination a ride must make, and when it ends
+	destinationPolicy := destination.DefaultPolicy
+	if n, err := strconv.Atoi(getEnv("DESTINATION_MAX_PER_DAY", "")); err == nil && n >= 0 {
+		destinationPolicy.MaxPerDay = n
+	}
+	if f, err := strconv.ParseFloat(getEnv("DESTINATION_MIN_PROGRESS", ""), 64); err == nil && f >= 0 && f <= 1 {
+		destinationPolicy.MinProgress = f
+	}
+	if d, err := time.ParseDuration(getEnv("DESTINATION_TIMEOUT", "")); err == nil {
+		destinationPolicy.Timeout = d
+	}
+	if f, err := strconv.ParseFloat(getEnv("DESTINATION_ARRIVAL_RADIUS_KM", ""), 64); err == nil && f > 0 {
+		destinationPolicy.ArrivalRadiusKm = f
+	}
+
+	// Offer rates: how many recent offers count, the thresholds for warnings and cooldowns, and how long a cooldown lasts
+	reliabilityPolicy := reliability.DefaultPolicy
+	if n, err := strconv.Atoi(getEnv("RELIABILITY_WINDOW", "")); err == nil && n > 0 {
+		reliabilityPolicy.Window = n
+	}
+	if n, err := strconv.Atoi(getEnv("RELIABILITY_MIN_OFFERS", "")); err == nil && n >= 0 {
+		reliabilityPolicy.MinOffers = n
+	}
+	for key, rate := range map[string]*float64{
+		"RELIABILITY_WARN_ACCEPTANCE":       &reliabilityPolicy.WarnAcceptance,
+		"RELIABILITY_COOLDOWN_ACCEPTANCE":   &reliabilityPolicy.CooldownAcceptance,
+		"RELIABILITY_WARN_CANCELLATION":     &reliabilityPolicy.WarnCancellation,
+		"RELIABILITY_COOLDOWN_CANCELLATION": &reliabilityPolicy.CooldownCancellation,
+	} {
+		if f, err := strconv.ParseFloat(getEnv(key, ""), 64); err == nil && f >= 0 && f <= 1 {
+			*rate = f
+		}
+	}
+	if d, err := time.ParseDuration(getEnv("RELIABILITY_COOLDOWN", "")); err == nil {
+		reliabilityPolicy.Cooldown = d
+	}
+
+	documents, err := storage.NewLocalDisk(getEnv("DOCUMENTS_DIR", "./data/documents"))
+	if err != nil {
+		slog.Error("failed to open document storage", "err", err.Error())
+		os.Exit(1)
+	}
+
+	app := driver.NewApp(db, rabbit, waiting, fatiguePolicy, documents, destinationPolicy, reliabilityPolicy)
 	go func() {
 		defer wg.Done()
 		if err := app.Start(ctx); err != nil {
@@ -110,6 +110,33 @@ func main() {
 			Exchange:   messages.ExchangeRideTopic,
 			RoutingKey: "ride.status.*",
 		},
+		{
+			Name:       messages.QueuePassengerUpdates,
+			Durable:    true,
+			AutoDelete: false,
+			Exclusive:  false,
+			NoWait:     false,
+			Exchange:   messages.ExchangeRideTopic,
+			RoutingKey: "ride.passenger.*",
+		},
+		{
+			Name:       messages.QueueDriverChat,
+			Durable:    true,
+			AutoDelete: false,
+			Exclusive:  false,
+			NoWait:     false,
+			Exchange:   messages.ExchangeRideTopic,
+			RoutingKey: "ride.chat.*",
+		},
+		{
+			Name:       messages.QueuePassengerChat,
+			Durable:    true,
+			AutoDelete: false,
+			Exclusive:  false,
+			NoWait:     false,
+			Exchange:   messages.ExchangeDriverTopic,
+			RoutingKey: "driver.chat.*",
+		},
 		{
 			Name:       messages.QueueDriverResponses,
 			Durable:    true,
@@ -8,6 +8,7 @@ import (
 	"ride-hail/internal/admin/service"
 	"ride-hail/internal/shared/logger"
 	"ride-hail/internal/shared/postgres"
+	"ride-hail/internal/shared/storage"
 )
 
 type App struct {
@@ -15,14 +16,17 @@ type App struct {
 	serverConfig *handlers.ServerConfig
 
 	db *postgres.Database
+	// documents keeps the files drivers upload during onboarding
+	documents storage.Storage
 
 	logger *logger.Logger
 }
 
-func NewApp(db *postgres.Database, serverConfig *handlers.ServerConfig, log *logger.Logger) *App {
+func NewApp(db *postgres.Database, serverConfig *handlers.ServerConfig, documents storage.Storage, log *logger.Logger) *App {
 	return &App{
 		db:           db,
 		serverConfig: serverConfig,
+		documents:    documents,
 		logger:       log,
 	}
 }
@@ -30,8 +34,14 @@ func NewApp(db *postgres.Database, serverConfig *handlers.ServerConfig, log *log
 func (a *App) Start(ctx context.Context) error {
 	metricsRepo := repository.NewMetricsRepository(a.db)
 	ridesRepo := repository.NewRidesRepository(a.db)
+	zonesRepo := repository.NewZonesRepository(a.db)
+	vehicleClassesRepo := repository.NewVehicleClassesRepository(a.db)
+	citiesRepo := repository.NewCitiesRepository(a.db)
+	promoRepo := repository.NewPromoRepository(a.db)
+	documentsRepo := repository.NewDocumentsRepository(a.db)
+	reliabilityRepo := repository.NewReliabilityRepository(a.db)
 
-	svc := service.NewService(metricsRepo, ridesRepo, a.logger)
+	svc := service.NewService(metricsRepo, ridesRepo, zonesRepo, vehicleClassesRepo, citiesRepo, promoRepo, documentsRepo, a.documents, reliabilityRepo, a.logger)
 
 	handler := handlers.NewHandler(*svc)
 
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"errors"
+
+	"ride-hail/internal/shared/cities"
+	"ride-hail/internal/shared/geo"
+)
+
+var (
+	ErrCityNotFound = errors.New("city not found")
+	ErrInvalidCity  = errors.New("invalid city")
+)
+
+type CityRequest struct {
+	Code        string      `json:"code"`
+	Name        string      `json:"name"`
+	Currency    string      `json:"currency"`
+	Timezone    string      `json:"timezone"`
+	ServiceArea geo.Polygon `json:"service_area,omitempty"`
+	IsDefault   bool        `json:"is_default"`
+	IsActive    *bool       `json:"is_active,omitempty"`
+}
+
+type CitiesList struct {
+	Cities []cities.City `json:"cities"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"errors"
+
+	"ride-hail/internal/shared/onboarding"
+)
+
+var (
+	ErrDocumentNotFound = errors.New("driver document not found")
+	ErrInvalidReview    = errors.New("invalid document review")
+)
+
+// DocumentReviewRequest approves or rejects a driver document. A note is
+// required when rejecting so the driver knows what to fix.
+type DocumentReviewRequest struct {
+	Decision string `json:"decision"`
+	Note     string `json:"note,omitempty"`
+}
+
+type DriverDocumentsList struct {
+	Documents []onboarding.Document `json:"documents"`
+}
+
+// DocumentReview is the reviewed document with where its driver stands afterwards.
+type DocumentReview struct {
+	Document         onboarding.Document `json:"document"`
+	DriverVerified   bool                `json:"driver_verified"`
+	MissingDocuments []string            `json:"missing_documents"`
+}
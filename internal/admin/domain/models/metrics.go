@@ -1,11 +1,19 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
 
 type Overview struct {
-	Time               time.Time
+	Time time.Time
+	// "Сегодня" считается в часовом поясе города, суммы - в его валюте
+	City               string
+	Currency           string
+	Timezone           string
 	Metrics            *Metrics
-	DriverDistribution *DriverDistribution
+	DriverDistribution DriverDistribution
 	Hotspots           []Hotspots
 }
 
@@ -14,17 +22,14 @@ type Metrics struct {
 	AvailableDrivers           int
 	BusyDrivers                int
 	TotalRidesToday            int
-	TotalRevenueToday          float64
+	TotalRevenueToday          money.Money
 	AverageWaitTimeMinutes     float32
 	AverageRideDurationMinutes float32
 	CancellationRate           float32
 }
 
-type DriverDistribution struct {
-	Economy int
-	Premium int
-	XL      int
-}
+// DriverDistribution counts online drivers by vehicle class code
+type DriverDistribution map[string]int
 
 type Hotspots struct {
 	Location     string
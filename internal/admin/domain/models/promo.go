@@ -0,0 +1,34 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/promo"
+)
+
+var ErrPromoCampaignNotFound = errors.New("promo campaign not found")
+
+// PromoCampaignRequest creates or replaces a promo campaign. Cities are given by ID
+// or code; empty lists apply everywhere and omitted limits are unlimited.
+type PromoCampaignRequest struct {
+	Code           string      `json:"code"`
+	Name           string      `json:"name"`
+	DiscountType   string      `json:"discount_type"`
+	PercentOff     int         `json:"percent_off,omitempty"`
+	AmountOff      money.Money `json:"amount_off"`
+	MaxDiscount    money.Money `json:"max_discount"`
+	Currency       string      `json:"currency,omitempty"`
+	ValidFrom      *time.Time  `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time  `json:"valid_until,omitempty"`
+	MaxRedemptions int         `json:"max_redemptions,omitempty"`
+	MaxPerUser     int         `json:"max_per_user,omitempty"`
+	VehicleTypes   []string    `json:"vehicle_types,omitempty"`
+	Cities         []string    `json:"cities,omitempty"`
+	IsActive       *bool       `json:"is_active,omitempty"`
+}
+
+type PromoCampaignsList struct {
+	Campaigns []promo.Campaign `json:"campaigns"`
+}
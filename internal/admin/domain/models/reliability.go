@@ -0,0 +1,28 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"ride-hail/internal/shared/reliability"
+)
+
+var ErrInvalidReliabilityLevel = errors.New("invalid reliability level")
+
+// DriverReliability is how a driver deals with ride offers. The rates are the
+// rolling ones matching uses; Offers counts the last 30 days for context.
+type DriverReliability struct {
+	DriverID         string             `json:"driver_id"`
+	Email            string             `json:"email"`
+	Status           string             `json:"status"`
+	AcceptanceRate   *float64           `json:"acceptance_rate"`
+	CancellationRate *float64           `json:"cancellation_rate"`
+	Level            string             `json:"level"`
+	Reason           string             `json:"reason,omitempty"`
+	CooldownUntil    *time.Time         `json:"cooldown_until,omitempty"`
+	Offers           reliability.Counts `json:"offers_30d"`
+}
+
+type DriverReliabilityList struct {
+	Drivers []DriverReliability `json:"drivers"`
+}
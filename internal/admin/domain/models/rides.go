@@ -22,6 +22,7 @@ type Ride struct {
 	CurrentDriverLocation Location  `json:"current_driver_location"`
 	DistanceCompletedKM   float64   `json:"distance_completed_km"`
 	DistanceRemainingKM   float64   `json:"distance_remaining_km"`
+	Currency              string    `json:"currency"`
 }
 
 type Location struct {
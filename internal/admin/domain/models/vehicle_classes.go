@@ -0,0 +1,55 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
+)
+
+var (
+	ErrVehicleClassNotFound = errors.New("vehicle class not found")
+	ErrVehicleClassExists   = errors.New("vehicle class already exists")
+	ErrInvalidVehicleClass  = errors.New("invalid vehicle class")
+	ErrInvalidTariff        = errors.New("invalid tariff")
+)
+
+// VehicleClassRequest creates or replaces a vehicle class. Tier defaults to 1;
+// drivers of higher tiers may opt in to rides of lower ones.
+type VehicleClassRequest struct {
+	Code        string `json:"code"`
+	DisplayName string `json:"display_name"`
+	Capacity    int    `json:"capacity"`
+	Tier        int    `json:"tier,omitempty"`
+	IsActive    *bool  `json:"is_active,omitempty"`
+}
+
+// TariffRequest creates a new tariff version. EffectiveFrom defaults to now,
+// an empty CityID makes the tariff apply in every city without its own.
+// The waiting rate and the no-show fee are optional.
+type TariffRequest struct {
+	CityID            string      `json:"city_id,omitempty"`
+	BaseFare          money.Money `json:"base_fare"`
+	RatePerKm         money.Money `json:"rate_per_km"`
+	RatePerMin        money.Money `json:"rate_per_min"`
+	MinimumFare       money.Money `json:"minimum_fare"`
+	WaitingRatePerMin money.Money `json:"waiting_rate_per_min"`
+	NoShowFee         money.Money `json:"no_show_fee"`
+	EffectiveFrom     *time.Time  `json:"effective_from,omitempty"`
+}
+
+// VehicleClassInfo is a vehicle class with the tariff currently in effect.
+type VehicleClassInfo struct {
+	pricing.VehicleClass
+	CurrentTariff *pricing.Tariff `json:"current_tariff"`
+}
+
+type VehicleClassesList struct {
+	VehicleClasses []VehicleClassInfo `json:"vehicle_classes"`
+}
+
+type TariffsList struct {
+	VehicleType string           `json:"vehicle_type"`
+	Tariffs     []pricing.Tariff `json:"tariffs"`
+}
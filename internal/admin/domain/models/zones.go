@@ -0,0 +1,26 @@
+package models
+
+import (
+	"errors"
+
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/money"
+)
+
+var (
+	ErrZoneNotFound = errors.New("zone not found")
+	ErrInvalidZone  = errors.New("invalid zone")
+)
+
+type ZoneRequest struct {
+	Name      string       `json:"name"`
+	Type      geo.ZoneType `json:"zone_type"`
+	Polygon   geo.Polygon  `json:"polygon"`
+	Surcharge money.Money  `json:"surcharge_amount"`
+	ParentID  string       `json:"parent_zone_id,omitempty"`
+	IsActive  *bool        `json:"is_active,omitempty"`
+}
+
+type ZonesList struct {
+	Zones []geo.Zone `json:"zones"`
+}
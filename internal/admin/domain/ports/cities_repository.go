@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"ride-hail/internal/shared/cities"
+)
+
+type CitiesRepository interface {
+	ListCities(ctx context.Context) ([]cities.City, error)
+	// GetCity finds a city by ID or code.
+	GetCity(ctx context.Context, idOrCode string) (*cities.City, error)
+	GetDefaultCity(ctx context.Context) (*cities.City, error)
+	CreateCity(ctx context.Context, city *cities.City) error
+	UpdateCity(ctx context.Context, city *cities.City) error
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"ride-hail/internal/shared/onboarding"
+)
+
+type DocumentsRepository interface {
+	// ListDocuments returns the documents in the review status, oldest first.
+	ListDocuments(ctx context.Context, status string) ([]onboarding.Document, error)
+	GetDocument(ctx context.Context, id string) (*onboarding.Document, error)
+	// ListDriverDocuments returns all documents of the driver.
+	ListDriverDocuments(ctx context.Context, driverID string) ([]onboarding.Document, error)
+	// ReviewDocument stores the decision on the document and returns it. An approved
+	// document can be rejected later, e.g. when it turns out to be forged.
+	ReviewDocument(ctx context.Context, id, status, note string) (*onboarding.Document, error)
+	SetDriverVerified(ctx context.Context, driverID string, verified bool) error
+}
@@ -7,5 +7,5 @@ import (
 )
 
 type MetricsRepository interface {
-	FetchOverview(ctx context.Context) (*models.Overview, error)
+	FetchOverview(ctx context.Context, cityID, timezone string) (*models.Overview, error)
 }
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"ride-hail/internal/shared/promo"
+)
+
+type PromoRepository interface {
+	ListCampaigns(ctx context.Context) ([]promo.Campaign, error)
+	CreateCampaign(ctx context.Context, c *promo.Campaign) error
+	UpdateCampaign(ctx context.Context, c *promo.Campaign) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"ride-hail/internal/admin/domain/models"
+)
+
+type ReliabilityRepository interface {
+	// ListDriverReliability returns drivers who have had offers, at the level if one
+	// is given, least reliable first.
+	ListDriverReliability(ctx context.Context, level string) ([]models.DriverReliability, error)
+}
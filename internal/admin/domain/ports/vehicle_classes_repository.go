@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"ride-hail/internal/shared/pricing"
+)
+
+type VehicleClassesRepository interface {
+	ListVehicleClasses(ctx context.Context) ([]pricing.VehicleClass, error)
+	GetVehicleClass(ctx context.Context, code string) (*pricing.VehicleClass, error)
+	CreateVehicleClass(ctx context.Context, vc *pricing.VehicleClass) error
+	UpdateVehicleClass(ctx context.Context, vc *pricing.VehicleClass) error
+	ListTariffs(ctx context.Context, vehicleType string) ([]pricing.Tariff, error)
+	CreateTariff(ctx context.Context, t *pricing.Tariff) error
+}
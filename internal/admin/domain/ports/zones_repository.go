@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"ride-hail/internal/shared/geo"
+)
+
+type ZonesRepository interface {
+	ListZones(ctx context.Context) ([]geo.Zone, error)
+	CreateZone(ctx context.Context, zone *geo.Zone) error
+	UpdateZone(ctx context.Context, zone *geo.Zone) error
+	DeactivateZone(ctx context.Context, id string) error
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"ride-hail/internal/admin/domain/models"
+)
+
+func (s *Handler) GetCities(w http.ResponseWriter, r *http.Request) {
+	result, err := s.service.ListCities(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list cities", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Handler) CreateCity(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var req models.CityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	city, err := s.service.CreateCity(r.Context(), req)
+	if err != nil {
+		writeCityError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, city)
+}
+
+func (s *Handler) UpdateCity(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	cityID := r.PathValue("city_id")
+	if cityID == "" {
+		http.Error(w, "city_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	city, err := s.service.UpdateCity(r.Context(), cityID, req)
+	if err != nil {
+		writeCityError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, city)
+}
+
+func writeCityError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidCity):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, models.ErrCityNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "Failed to save city", http.StatusInternalServerError)
+	}
+}
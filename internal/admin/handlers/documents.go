@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"ride-hail/internal/admin/domain/models"
+)
+
+func (s *Handler) GetDriverDocuments(w http.ResponseWriter, r *http.Request) {
+	result, err := s.service.ListDriverDocuments(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		writeDocumentError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Handler) GetDriverDocumentFile(w http.ResponseWriter, r *http.Request) {
+	documentID := r.PathValue("document_id")
+	if documentID == "" {
+		http.Error(w, "document_id is required", http.StatusBadRequest)
+		return
+	}
+
+	doc, file, err := s.service.OpenDriverDocument(r.Context(), documentID)
+	if err != nil {
+		writeDocumentError(w, err)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(doc.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": doc.FileName}))
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, file)
+}
+
+func (s *Handler) ReviewDriverDocument(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	documentID := r.PathValue("document_id")
+	if documentID == "" {
+		http.Error(w, "document_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.DocumentReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := s.service.ReviewDriverDocument(r.Context(), documentID, req)
+	if err != nil {
+		writeDocumentError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func writeDocumentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidReview):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, models.ErrDocumentNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "Failed to process driver document", http.StatusInternalServerError)
+	}
+}
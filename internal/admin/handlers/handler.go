@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"ride-hail/internal/admin/domain/models"
 	"ride-hail/internal/admin/service"
 )
 
@@ -19,7 +21,11 @@ func NewHandler(s service.Service) *Handler {
 }
 
 func (s *Handler) GetOverview(w http.ResponseWriter, r *http.Request) {
-	result, err := s.service.CollectRuntimeMetrics(r.Context())
+	result, err := s.service.CollectRuntimeMetrics(r.Context(), r.URL.Query().Get("city"))
+	if errors.Is(err, models.ErrCityNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Failed to collect runtime metrics", http.StatusInternalServerError)
 		return
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/shared/promo"
+)
+
+func (s *Handler) GetPromoCampaigns(w http.ResponseWriter, r *http.Request) {
+	result, err := s.service.ListPromoCampaigns(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list promo campaigns", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Handler) CreatePromoCampaign(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var req models.PromoCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	campaign, err := s.service.CreatePromoCampaign(r.Context(), req)
+	if err != nil {
+		writePromoError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, campaign)
+}
+
+func (s *Handler) UpdatePromoCampaign(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	campaignID := r.PathValue("campaign_id")
+	if campaignID == "" {
+		http.Error(w, "campaign_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.PromoCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	campaign, err := s.service.UpdatePromoCampaign(r.Context(), campaignID, req)
+	if err != nil {
+		writePromoError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, campaign)
+}
+
+func writePromoError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, promo.ErrInvalidCampaign):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, models.ErrPromoCampaignNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "Failed to save promo campaign", http.StatusInternalServerError)
+	}
+}
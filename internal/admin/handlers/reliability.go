@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"ride-hail/internal/admin/domain/models"
+)
+
+func (s *Handler) GetDriverReliability(w http.ResponseWriter, r *http.Request) {
+	result, err := s.service.ListDriverReliability(r.Context(), r.URL.Query().Get("level"))
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidReliabilityLevel) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to get driver reliability", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
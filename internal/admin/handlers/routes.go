@@ -12,5 +12,36 @@ func RegisterRoutes(handler *Handler) http.Handler {
 	mux.HandleFunc("GET /admin/overview", middleware.AuthMiddleware(handler.GetOverview))
 	mux.HandleFunc("GET /admin/rides/active", middleware.AuthMiddleware(handler.GetRidesList))
 
+	// Geofenced zones
+	mux.HandleFunc("GET /admin/zones", middleware.AuthMiddleware(handler.GetZones))
+	mux.Handle("POST /admin/zones", middleware.JsonMiddleware(middleware.AuthMiddleware(handler.CreateZone)))
+	mux.Handle("PUT /admin/zones/{zone_id}", middleware.JsonMiddleware(middleware.AuthMiddleware(handler.UpdateZone)))
+	mux.HandleFunc("DELETE /admin/zones/{zone_id}", middleware.AuthMiddleware(handler.DeleteZone))
+
+	// Cities (markets)
+	mux.HandleFunc("GET /admin/cities", middleware.AuthMiddleware(handler.GetCities))
+	mux.Handle("POST /admin/cities", middleware.JsonMiddleware(middleware.AuthMiddleware(handler.CreateCity)))
+	mux.Handle("PUT /admin/cities/{city_id}", middleware.JsonMiddleware(middleware.AuthMiddleware(handler.UpdateCity)))
+
+	// Vehicle classes and versioned tariffs
+	mux.HandleFunc("GET /admin/vehicle-classes", middleware.AuthMiddleware(handler.GetVehicleClasses))
+	mux.Handle("POST /admin/vehicle-classes", middleware.JsonMiddleware(middleware.AuthMiddleware(handler.CreateVehicleClass)))
+	mux.Handle("PUT /admin/vehicle-classes/{code}", middleware.JsonMiddleware(middleware.AuthMiddleware(handler.UpdateVehicleClass)))
+	mux.HandleFunc("GET /admin/vehicle-classes/{code}/tariffs", middleware.AuthMiddleware(handler.GetTariffs))
+	mux.Handle("POST /admin/vehicle-classes/{code}/tariffs", middleware.JsonMiddleware(middleware.AuthMiddleware(handler.CreateTariff)))
+
+	// Promo code campaigns
+	mux.HandleFunc("GET /admin/promo-campaigns", middleware.AuthMiddleware(handler.GetPromoCampaigns))
+	mux.Handle("POST /admin/promo-campaigns", middleware.JsonMiddleware(middleware.AuthMiddleware(handler.CreatePromoCampaign)))
+	mux.Handle("PUT /admin/promo-campaigns/{campaign_id}", middleware.JsonMiddleware(middleware.AuthMiddleware(handler.UpdatePromoCampaign)))
+
+	// Driver onboarding documents
+	mux.HandleFunc("GET /admin/driver-documents", middleware.AuthMiddleware(handler.GetDriverDocuments))
+	mux.HandleFunc("GET /admin/driver-documents/{document_id}/file", middleware.AuthMiddleware(handler.GetDriverDocumentFile))
+	mux.Handle("POST /admin/driver-documents/{document_id}/review", middleware.JsonMiddleware(middleware.AuthMiddleware(handler.ReviewDriverDocument)))
+
+	// Driver offer acceptance and cancellation rates
+	mux.HandleFunc("GET /admin/drivers/reliability", middleware.AuthMiddleware(handler.GetDriverReliability))
+
 	return mux
 }
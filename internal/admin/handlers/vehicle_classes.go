@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"ride-hail/internal/admin/domain/models"
+)
+
+func (s *Handler) GetVehicleClasses(w http.ResponseWriter, r *http.Request) {
+	result, err := s.service.ListVehicleClasses(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list vehicle classes", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Handler) CreateVehicleClass(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var req models.VehicleClassRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	vc, err := s.service.CreateVehicleClass(r.Context(), req)
+	if err != nil {
+		writeVehicleClassError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, vc)
+}
+
+func (s *Handler) UpdateVehicleClass(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	code := r.PathValue("code")
+	if code == "" {
+		http.Error(w, "code is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.VehicleClassRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	vc, err := s.service.UpdateVehicleClass(r.Context(), code, req)
+	if err != nil {
+		writeVehicleClassError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, vc)
+}
+
+func (s *Handler) GetTariffs(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+	if code == "" {
+		http.Error(w, "code is required", http.StatusBadRequest)
+		return
+	}
+
+	result, err := s.service.ListTariffs(r.Context(), code)
+	if err != nil {
+		writeVehicleClassError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Handler) CreateTariff(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	code := r.PathValue("code")
+	if code == "" {
+		http.Error(w, "code is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.TariffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	tariff, err := s.service.CreateTariff(r.Context(), code, req)
+	if err != nil {
+		writeVehicleClassError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tariff)
+}
+
+func writeVehicleClassError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidVehicleClass), errors.Is(err, models.ErrInvalidTariff):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, models.ErrVehicleClassNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, models.ErrVehicleClassExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "Failed to save vehicle class", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"ride-hail/internal/admin/domain/models"
+)
+
+func (s *Handler) GetZones(w http.ResponseWriter, r *http.Request) {
+	result, err := s.service.ListZones(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list zones", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Handler) CreateZone(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var req models.ZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	zone, err := s.service.CreateZone(r.Context(), req)
+	if err != nil {
+		writeZoneError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, zone)
+}
+
+func (s *Handler) UpdateZone(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	zoneID := r.PathValue("zone_id")
+	if zoneID == "" {
+		http.Error(w, "zone_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	zone, err := s.service.UpdateZone(r.Context(), zoneID, req)
+	if err != nil {
+		writeZoneError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, zone)
+}
+
+func (s *Handler) DeleteZone(w http.ResponseWriter, r *http.Request) {
+	zoneID := r.PathValue("zone_id")
+	if zoneID == "" {
+		http.Error(w, "zone_id is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.service.DeleteZone(r.Context(), zoneID); err != nil {
+		writeZoneError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeZoneError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidZone):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, models.ErrZoneNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "Failed to save zone", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
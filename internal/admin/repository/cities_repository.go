@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/admin/domain/ports"
+	"ride-hail/internal/shared/cities"
+	"ride-hail/internal/shared/postgres"
+)
+
+const citySelect = `
+        SELECT id, code, name, currency, timezone, service_area, is_default, is_active, created_at, updated_at
+        FROM cities
+    `
+
+// errCityTaken is returned when the code or the default flag is already used by another city.
+var errCityTaken = fmt.Errorf("%w: code is taken or another city is already default", models.ErrInvalidCity)
+
+type CitiesRepository struct {
+	db *postgres.Database
+}
+
+func NewCitiesRepository(db *postgres.Database) ports.CitiesRepository {
+	return &CitiesRepository{
+		db: db,
+	}
+}
+
+// ListCities implements [ports.CitiesRepository].
+func (c *CitiesRepository) ListCities(ctx context.Context) ([]cities.City, error) {
+	rows, err := c.db.Query(ctx, citySelect+` ORDER BY code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []cities.City{}
+	for rows.Next() {
+		city, err := scanCity(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *city)
+	}
+
+	return result, rows.Err()
+}
+
+// GetCity implements [ports.CitiesRepository].
+func (c *CitiesRepository) GetCity(ctx context.Context, idOrCode string) (*cities.City, error) {
+	return c.getOne(ctx, citySelect+` WHERE id::text = $1 OR code = $1`, idOrCode)
+}
+
+// GetDefaultCity implements [ports.CitiesRepository].
+func (c *CitiesRepository) GetDefaultCity(ctx context.Context) (*cities.City, error) {
+	return c.getOne(ctx, citySelect+` WHERE is_default`)
+}
+
+func (c *CitiesRepository) getOne(ctx context.Context, q string, args ...any) (*cities.City, error) {
+	city, err := scanCity(c.db.QueryRow(ctx, q, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrCityNotFound
+	}
+	return city, err
+}
+
+// CreateCity implements [ports.CitiesRepository].
+func (c *CitiesRepository) CreateCity(ctx context.Context, city *cities.City) error {
+	areaJSON, err := marshalServiceArea(city)
+	if err != nil {
+		return err
+	}
+
+	q := `
+        INSERT INTO cities (code, name, currency, timezone, service_area, is_default, is_active)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at, updated_at
+    `
+
+	err = c.db.QueryRow(ctx, q,
+		city.Code,
+		city.Name,
+		city.Currency,
+		city.Timezone,
+		areaJSON,
+		city.IsDefault,
+		city.IsActive,
+	).Scan(&city.ID, &city.CreatedAt, &city.UpdatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return errCityTaken
+	}
+
+	return err
+}
+
+// UpdateCity implements [ports.CitiesRepository].
+func (c *CitiesRepository) UpdateCity(ctx context.Context, city *cities.City) error {
+	areaJSON, err := marshalServiceArea(city)
+	if err != nil {
+		return err
+	}
+
+	q := `
+        UPDATE cities
+        SET code = $1, name = $2, currency = $3, timezone = $4, service_area = $5,
+            is_default = $6, is_active = $7, updated_at = NOW()
+        WHERE id = $8
+        RETURNING created_at, updated_at
+    `
+
+	err = c.db.QueryRow(ctx, q,
+		city.Code,
+		city.Name,
+		city.Currency,
+		city.Timezone,
+		areaJSON,
+		city.IsDefault,
+		city.IsActive,
+		city.ID,
+	).Scan(&city.CreatedAt, &city.UpdatedAt)
+
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return models.ErrCityNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == uniqueViolation:
+		return errCityTaken
+	}
+
+	return err
+}
+
+func scanCity(row pgx.Row) (*cities.City, error) {
+	var city cities.City
+	var areaJSON []byte
+	if err := row.Scan(
+		&city.ID,
+		&city.Code,
+		&city.Name,
+		&city.Currency,
+		&city.Timezone,
+		&areaJSON,
+		&city.IsDefault,
+		&city.IsActive,
+		&city.CreatedAt,
+		&city.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(areaJSON) > 0 {
+		if err := json.Unmarshal(areaJSON, &city.ServiceArea); err != nil {
+			return nil, err
+		}
+	}
+
+	return &city, nil
+}
+
+// marshalServiceArea stores an empty service area as NULL.
+func marshalServiceArea(city *cities.City) ([]byte, error) {
+	if len(city.ServiceArea) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(city.ServiceArea)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/admin/domain/ports"
+	"ride-hail/internal/shared/onboarding"
+	"ride-hail/internal/shared/postgres"
+)
+
+const documentColumns = `
+        id, driver_id, doc_type, file_name, content_type, size_bytes, storage_key,
+        expires_at, status, COALESCE(review_note, ''), reviewed_at, created_at
+    `
+
+type DocumentsRepository struct {
+	db *postgres.Database
+}
+
+func NewDocumentsRepository(db *postgres.Database) ports.DocumentsRepository {
+	return &DocumentsRepository{
+		db: db,
+	}
+}
+
+// ListDocuments implements [ports.DocumentsRepository].
+func (d *DocumentsRepository) ListDocuments(ctx context.Context, status string) ([]onboarding.Document, error) {
+	return d.list(ctx, `SELECT `+documentColumns+` FROM driver_documents WHERE status = $1 ORDER BY created_at`, status)
+}
+
+// GetDocument implements [ports.DocumentsRepository].
+func (d *DocumentsRepository) GetDocument(ctx context.Context, id string) (*onboarding.Document, error) {
+	doc, err := scanDocument(d.db.QueryRow(ctx, `SELECT `+documentColumns+` FROM driver_documents WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrDocumentNotFound
+	}
+	return doc, err
+}
+
+// ListDriverDocuments implements [ports.DocumentsRepository].
+func (d *DocumentsRepository) ListDriverDocuments(ctx context.Context, driverID string) ([]onboarding.Document, error) {
+	return d.list(ctx, `SELECT `+documentColumns+` FROM driver_documents WHERE driver_id = $1 ORDER BY created_at DESC`, driverID)
+}
+
+// ReviewDocument implements [ports.DocumentsRepository].
+func (d *DocumentsRepository) ReviewDocument(ctx context.Context, id, status, note string) (*onboarding.Document, error) {
+	q := `
+        UPDATE driver_documents
+        SET status = $2, review_note = NULLIF($3, ''), reviewed_at = now()
+        WHERE id = $1
+        RETURNING ` + documentColumns
+	doc, err := scanDocument(d.db.QueryRow(ctx, q, id, status, note))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrDocumentNotFound
+	}
+	return doc, err
+}
+
+// SetDriverVerified implements [ports.DocumentsRepository].
+func (d *DocumentsRepository) SetDriverVerified(ctx context.Context, driverID string, verified bool) error {
+	_, err := d.db.Exec(ctx, `UPDATE drivers SET is_verified = $2, updated_at = now() WHERE id = $1`, driverID, verified)
+	return err
+}
+
+func (d *DocumentsRepository) list(ctx context.Context, q string, args ...any) ([]onboarding.Document, error) {
+	rows, err := d.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []onboarding.Document{}
+	for rows.Next() {
+		doc, err := scanDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *doc)
+	}
+
+	return result, rows.Err()
+}
+
+func scanDocument(row pgx.Row) (*onboarding.Document, error) {
+	var doc onboarding.Document
+	err := row.Scan(
+		&doc.ID,
+		&doc.DriverID,
+		&doc.Type,
+		&doc.FileName,
+		&doc.ContentType,
+		&doc.Size,
+		&doc.StorageKey,
+		&doc.ExpiresAt,
+		&doc.Status,
+		&doc.ReviewNote,
+		&doc.ReviewedAt,
+		&doc.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
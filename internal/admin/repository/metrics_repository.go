@@ -20,32 +20,37 @@ func NewMetricsRepository(db *postgres.Database) ports.MetricsRepository {
 }
 
 // FetchOverview implements [ports.MetricsRepository].
-func (m *MetricsRepository) FetchOverview(ctx context.Context) (*models.Overview, error) {
+// Rides are limited to the city unless cityID is empty; "today" is the current date in timezone.
+func (m *MetricsRepository) FetchOverview(ctx context.Context, cityID, timezone string) (*models.Overview, error) {
 	overview := &models.Overview{
-		Time:    time.Now(),
-		Metrics: &models.Metrics{},
+		Time:     time.Now(),
+		Timezone: timezone,
+		Metrics:  &models.Metrics{},
 	}
 
 	// Fetch main metrics
 	metricsQuery := `
+        WITH city_rides AS (
+            SELECT * FROM rides WHERE $1 = '' OR city_id::text = $1
+        )
         SELECT 
             COUNT(CASE WHEN r.status IN ('IN_PROGRESS', 'EN_ROUTE', 'ARRIVED') THEN 1 END) as active_rides,
             COUNT(CASE WHEN d.status = 'AVAILABLE' THEN 1 END) as available_drivers,
             COUNT(CASE WHEN d.status = 'BUSY' THEN 1 END) as busy_drivers,
-            COUNT(CASE WHEN r.created_at::date = CURRENT_DATE THEN 1 END) as total_rides_today,
-            COALESCE(SUM(CASE WHEN r.created_at::date = CURRENT_DATE THEN r.final_fare END), 0) as total_revenue_today,
+            COUNT(CASE WHEN (r.created_at AT TIME ZONE $2)::date = (NOW() AT TIME ZONE $2)::date THEN 1 END) as total_rides_today,
+            COALESCE(SUM(CASE WHEN (r.created_at AT TIME ZONE $2)::date = (NOW() AT TIME ZONE $2)::date THEN r.final_fare END), 0) as total_revenue_today,
             COALESCE(AVG(CASE WHEN r.matched_at IS NOT NULL THEN EXTRACT(EPOCH FROM (r.matched_at - r.requested_at))/60 END), 0) as avg_wait_time,
             COALESCE(AVG(CASE WHEN r.completed_at IS NOT NULL THEN EXTRACT(EPOCH FROM (r.completed_at - r.started_at))/60 END), 0) as avg_ride_duration,
             CASE 
                 WHEN COUNT(r.id) > 0 THEN 
-                    (COUNT(CASE WHEN r.status = 'CANCELLED' AND r.created_at::date = CURRENT_DATE THEN 1 END)::float / COUNT(CASE WHEN r.created_at::date = CURRENT_DATE THEN 1 END)) * 100
+                    (COUNT(CASE WHEN r.status = 'CANCELLED' AND (r.created_at AT TIME ZONE $2)::date = (NOW() AT TIME ZONE $2)::date THEN 1 END)::float / COUNT(CASE WHEN (r.created_at AT TIME ZONE $2)::date = (NOW() AT TIME ZONE $2)::date THEN 1 END)) * 100
                 ELSE 0 
             END as cancellation_rate
-        FROM rides r
+        FROM city_rides r
         FULL OUTER JOIN drivers d ON d.user_id = r.driver_id
     `
 
-	err := m.db.QueryRow(ctx, metricsQuery).Scan(
+	err := m.db.QueryRow(ctx, metricsQuery, cityID, timezone).Scan(
 		&overview.Metrics.ActiveRides,
 		&overview.Metrics.AvailableDrivers,
 		&overview.Metrics.BusyDrivers,
@@ -75,23 +80,17 @@ func (m *MetricsRepository) FetchOverview(ctx context.Context) (*models.Overview
 	}
 	defer rows.Close()
 
-	overview.DriverDistribution = &models.DriverDistribution{}
+	// Keyed by whatever classes the drivers have, so catalog changes need no code here
+	distribution := models.DriverDistribution{}
 	for rows.Next() {
 		var vehicleType string
 		var count int
 		if err := rows.Scan(&vehicleType, &count); err != nil {
 			return nil, err
 		}
-
-		switch vehicleType {
-		case "ECONOMY":
-			overview.DriverDistribution.Economy = count
-		case "PREMIUM":
-			overview.DriverDistribution.Premium = count
-		case "XL":
-			overview.DriverDistribution.XL = count
-		}
+		distribution[vehicleType] = count
 	}
+	overview.DriverDistribution = distribution
 
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -106,13 +105,14 @@ func (m *MetricsRepository) FetchOverview(ctx context.Context) (*models.Overview
         FROM rides r
         JOIN coordinates c ON r.pickup_coordinate_id = c.id
         WHERE r.created_at >= NOW() - INTERVAL '1 hour'
+          AND ($1 = '' OR r.city_id::text = $1)
         GROUP BY c.address
         HAVING COUNT(r.id) > 0
         ORDER BY active_rides DESC, waiting_rides DESC
         LIMIT 10
     `
 
-	hotspotRows, err := m.db.Query(ctx, hotspotsQuery)
+	hotspotRows, err := m.db.Query(ctx, hotspotsQuery, cityID)
 	if err != nil {
 		return nil, err
 	}
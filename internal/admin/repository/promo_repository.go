@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/admin/domain/ports"
+	"ride-hail/internal/shared/postgres"
+	"ride-hail/internal/shared/promo"
+)
+
+// Zero discounts, limits and currency are stored as NULL
+const campaignColumns = `
+        id, code, name, discount_type, COALESCE(percent_off, 0), amount_off, max_discount,
+        COALESCE(currency, ''), valid_from, valid_until, COALESCE(max_redemptions, 0), COALESCE(max_per_user, 0),
+        vehicle_types, city_ids::text[], is_active,
+        (SELECT count(*) FROM promo_redemptions pr WHERE pr.campaign_id = promo_campaigns.id AND pr.status <> 'RELEASED'),
+        created_at, updated_at
+    `
+
+var errCodeTaken = fmt.Errorf("%w: code is already used by another campaign", promo.ErrInvalidCampaign)
+
+type PromoRepository struct {
+	db *postgres.Database
+}
+
+func NewPromoRepository(db *postgres.Database) ports.PromoRepository {
+	return &PromoRepository{
+		db: db,
+	}
+}
+
+// ListCampaigns implements [ports.PromoRepository].
+func (p *PromoRepository) ListCampaigns(ctx context.Context) ([]promo.Campaign, error) {
+	rows, err := p.db.Query(ctx, `SELECT `+campaignColumns+` FROM promo_campaigns ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []promo.Campaign{}
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *c)
+	}
+
+	return result, rows.Err()
+}
+
+// CreateCampaign implements [ports.PromoRepository].
+func (p *PromoRepository) CreateCampaign(ctx context.Context, c *promo.Campaign) error {
+	q := `
+        INSERT INTO promo_campaigns (code, name, discount_type, percent_off, amount_off, max_discount, currency,
+            valid_from, valid_until, max_redemptions, max_per_user, vehicle_types, city_ids, is_active)
+        VALUES ($1, $2, $3, NULLIF($4, 0), NULLIF($5::decimal, 0), NULLIF($6::decimal, 0), NULLIF($7, ''),
+            $8, $9, NULLIF($10, 0), NULLIF($11, 0), $12, $13::uuid[], $14)
+        RETURNING ` + campaignColumns
+
+	return saveCampaign(c, p.db.QueryRow(ctx, q, campaignArgs(c)...))
+}
+
+// UpdateCampaign implements [ports.PromoRepository].
+func (p *PromoRepository) UpdateCampaign(ctx context.Context, c *promo.Campaign) error {
+	q := `
+        UPDATE promo_campaigns
+        SET code = $1, name = $2, discount_type = $3, percent_off = NULLIF($4, 0),
+            amount_off = NULLIF($5::decimal, 0), max_discount = NULLIF($6::decimal, 0), currency = NULLIF($7, ''),
+            valid_from = $8, valid_until = $9, max_redemptions = NULLIF($10, 0), max_per_user = NULLIF($11, 0),
+            vehicle_types = $12, city_ids = $13::uuid[], is_active = $14, updated_at = NOW()
+        WHERE id = $15
+        RETURNING ` + campaignColumns
+
+	return saveCampaign(c, p.db.QueryRow(ctx, q, append(campaignArgs(c), c.ID)...))
+}
+
+// saveCampaign reads the stored campaign back into c.
+func saveCampaign(c *promo.Campaign, row pgx.Row) error {
+	saved, err := scanCampaign(row)
+
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return models.ErrPromoCampaignNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == uniqueViolation:
+		return errCodeTaken
+	case err != nil:
+		return err
+	}
+
+	*c = *saved
+	return nil
+}
+
+func campaignArgs(c *promo.Campaign) []any {
+	vehicleTypes, cityIDs := c.VehicleTypes, c.CityIDs
+	if vehicleTypes == nil {
+		vehicleTypes = []string{}
+	}
+	if cityIDs == nil {
+		cityIDs = []string{}
+	}
+
+	return []any{
+		c.Code,
+		c.Name,
+		c.DiscountType,
+		c.PercentOff,
+		c.AmountOff,
+		c.MaxDiscount,
+		c.Currency,
+		c.ValidFrom,
+		c.ValidUntil,
+		c.MaxRedemptions,
+		c.MaxPerUser,
+		vehicleTypes,
+		cityIDs,
+		c.IsActive,
+	}
+}
+
+func scanCampaign(row pgx.Row) (*promo.Campaign, error) {
+	var c promo.Campaign
+	if err := row.Scan(
+		&c.ID,
+		&c.Code,
+		&c.Name,
+		&c.DiscountType,
+		&c.PercentOff,
+		&c.AmountOff,
+		&c.MaxDiscount,
+		&c.Currency,
+		&c.ValidFrom,
+		&c.ValidUntil,
+		&c.MaxRedemptions,
+		&c.MaxPerUser,
+		&c.VehicleTypes,
+		&c.CityIDs,
+		&c.IsActive,
+		&c.Redeemed,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	c.AmountOff.Currency = c.Currency
+	c.MaxDiscount.Currency = c.Currency
+	return &c, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/admin/domain/ports"
+	"ride-hail/internal/shared/postgres"
+)
+
+type ReliabilityRepository struct {
+	db *postgres.Database
+}
+
+func NewReliabilityRepository(db *postgres.Database) ports.ReliabilityRepository {
+	return &ReliabilityRepository{
+		db: db,
+	}
+}
+
+// ListDriverReliability implements [ports.ReliabilityRepository].
+func (r *ReliabilityRepository) ListDriverReliability(ctx context.Context, level string) ([]models.DriverReliability, error) {
+	q := `
+        SELECT d.id, u.email, COALESCE(d.status, 'OFFLINE'),
+               d.acceptance_rate, d.cancellation_rate, d.reliability_level, COALESCE(d.reliability_reason, ''),
+               CASE WHEN d.cooldown_until > now() THEN d.cooldown_until END,
+               o.accepted, o.declined, o.expired, o.cancelled
+        FROM drivers d
+        JOIN users u ON u.id = d.id
+        CROSS JOIN LATERAL (
+            SELECT COUNT(*) FILTER (WHERE outcome = 'ACCEPTED') AS accepted,
+                   COUNT(*) FILTER (WHERE outcome = 'DECLINED') AS declined,
+                   COUNT(*) FILTER (WHERE outcome = 'EXPIRED') AS expired,
+                   COUNT(*) FILTER (WHERE outcome = 'CANCELLED') AS cancelled
+            FROM driver_offers
+            WHERE driver_id = d.id AND outcome <> 'PENDING' AND offered_at > now() - interval '30 days'
+        ) o
+        WHERE d.acceptance_rate IS NOT NULL
+          AND ($1 = '' OR d.reliability_level = $1)
+        ORDER BY CASE d.reliability_level WHEN 'COOLDOWN' THEN 0 WHEN 'WARNING' THEN 1 ELSE 2 END,
+                 d.acceptance_rate, d.cancellation_rate DESC NULLS LAST`
+
+	rows, err := r.db.Query(ctx, q, level)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []models.DriverReliability{}
+	for rows.Next() {
+		var dr models.DriverReliability
+		if err := rows.Scan(
+			&dr.DriverID,
+			&dr.Email,
+			&dr.Status,
+			&dr.AcceptanceRate,
+			&dr.CancellationRate,
+			&dr.Level,
+			&dr.Reason,
+			&dr.CooldownUntil,
+			&dr.Offers.Accepted,
+			&dr.Offers.Declined,
+			&dr.Offers.Expired,
+			&dr.Offers.Cancelled,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, dr)
+	}
+	return result, rows.Err()
+}
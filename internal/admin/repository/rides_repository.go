@@ -65,8 +65,10 @@ func (r *RidesRepository) FetchRidesList(ctx context.Context, page int, pageSize
                 FROM location_history lh2
                 WHERE lh2.ride_id = r.id
                 ), 0
-            ) as distance_remaining
+            ) as distance_remaining,
+            COALESCE(ci.currency, '') as currency
         FROM rides r
+        LEFT JOIN cities ci ON ci.id = r.city_id
         JOIN coordinates pc ON r.pickup_coordinate_id = pc.id
         JOIN coordinates dc ON r.dropoff_coordinate_id = dc.id
         LEFT JOIN LATERAL (
@@ -103,6 +105,7 @@ func (r *RidesRepository) FetchRidesList(ctx context.Context, page int, pageSize
 			&ride.CurrentDriverLocation.Longitude,
 			&ride.DistanceCompletedKM,
 			&ride.DistanceRemainingKM,
+			&ride.Currency,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/admin/domain/ports"
+	"ride-hail/internal/shared/postgres"
+	"ride-hail/internal/shared/pricing"
+)
+
+const uniqueViolation = "23505"
+
+type VehicleClassesRepository struct {
+	db *postgres.Database
+}
+
+func NewVehicleClassesRepository(db *postgres.Database) ports.VehicleClassesRepository {
+	return &VehicleClassesRepository{
+		db: db,
+	}
+}
+
+// ListVehicleClasses implements [ports.VehicleClassesRepository].
+func (v *VehicleClassesRepository) ListVehicleClasses(ctx context.Context) ([]pricing.VehicleClass, error) {
+	q := `
+        SELECT value, COALESCE(display_name, value), capacity, tier, is_active
+        FROM vehicle_type
+        ORDER BY value
+    `
+
+	rows, err := v.db.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	classes := []pricing.VehicleClass{}
+	for rows.Next() {
+		var vc pricing.VehicleClass
+		if err := rows.Scan(&vc.Code, &vc.DisplayName, &vc.Capacity, &vc.Tier, &vc.IsActive); err != nil {
+			return nil, err
+		}
+		classes = append(classes, vc)
+	}
+
+	return classes, rows.Err()
+}
+
+// GetVehicleClass implements [ports.VehicleClassesRepository].
+func (v *VehicleClassesRepository) GetVehicleClass(ctx context.Context, code string) (*pricing.VehicleClass, error) {
+	q := `
+        SELECT value, COALESCE(display_name, value), capacity, tier, is_active
+        FROM vehicle_type
+        WHERE value = $1
+    `
+
+	var vc pricing.VehicleClass
+	err := v.db.QueryRow(ctx, q, code).Scan(&vc.Code, &vc.DisplayName, &vc.Capacity, &vc.Tier, &vc.IsActive)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrVehicleClassNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &vc, nil
+}
+
+// CreateVehicleClass implements [ports.VehicleClassesRepository].
+func (v *VehicleClassesRepository) CreateVehicleClass(ctx context.Context, vc *pricing.VehicleClass) error {
+	q := `
+        INSERT INTO vehicle_type (value, display_name, capacity, tier, is_active)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+
+	_, err := v.db.Exec(ctx, q, vc.Code, vc.DisplayName, vc.Capacity, vc.Tier, vc.IsActive)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return models.ErrVehicleClassExists
+	}
+
+	return err
+}
+
+// UpdateVehicleClass implements [ports.VehicleClassesRepository].
+func (v *VehicleClassesRepository) UpdateVehicleClass(ctx context.Context, vc *pricing.VehicleClass) error {
+	q := `
+        UPDATE vehicle_type
+        SET display_name = $1, capacity = $2, tier = $3, is_active = $4
+        WHERE value = $5
+    `
+
+	result, err := v.db.Exec(ctx, q, vc.DisplayName, vc.Capacity, vc.Tier, vc.IsActive, vc.Code)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrVehicleClassNotFound
+	}
+
+	return nil
+}
+
+// ListTariffs implements [ports.VehicleClassesRepository].
+// An empty vehicleType returns the tariffs of every class.
+func (v *VehicleClassesRepository) ListTariffs(ctx context.Context, vehicleType string) ([]pricing.Tariff, error) {
+	q := `
+        SELECT id, vehicle_type, COALESCE(city_id::text, ''), base_fare, rate_per_km, rate_per_min, minimum_fare, waiting_rate_per_min, no_show_fee, effective_from, created_at
+        FROM tariffs
+        WHERE $1 = '' OR vehicle_type = $1
+        ORDER BY vehicle_type, city_id NULLS FIRST, effective_from DESC
+    `
+
+	rows, err := v.db.Query(ctx, q, vehicleType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tariffs := []pricing.Tariff{}
+	for rows.Next() {
+		var t pricing.Tariff
+		if err := rows.Scan(
+			&t.ID,
+			&t.VehicleType,
+			&t.CityID,
+			&t.BaseFare,
+			&t.RatePerKm,
+			&t.RatePerMin,
+			&t.MinimumFare,
+			&t.WaitingRatePerMin,
+			&t.NoShowFee,
+			&t.EffectiveFrom,
+			&t.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tariffs = append(tariffs, t)
+	}
+
+	return tariffs, rows.Err()
+}
+
+// CreateTariff implements [ports.VehicleClassesRepository].
+func (v *VehicleClassesRepository) CreateTariff(ctx context.Context, t *pricing.Tariff) error {
+	q := `
+        INSERT INTO tariffs (vehicle_type, city_id, base_fare, rate_per_km, rate_per_min, minimum_fare, waiting_rate_per_min, no_show_fee, effective_from)
+        VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id, created_at
+    `
+
+	err := v.db.QueryRow(ctx, q,
+		t.VehicleType,
+		t.CityID,
+		t.BaseFare,
+		t.RatePerKm,
+		t.RatePerMin,
+		t.MinimumFare,
+		t.WaitingRatePerMin,
+		t.NoShowFee,
+		t.EffectiveFrom,
+	).Scan(&t.ID, &t.CreatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return models.ErrInvalidTariff
+	}
+
+	return err
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/admin/domain/ports"
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/postgres"
+)
+
+type ZonesRepository struct {
+	db *postgres.Database
+}
+
+func NewZonesRepository(db *postgres.Database) ports.ZonesRepository {
+	return &ZonesRepository{
+		db: db,
+	}
+}
+
+// ListZones implements [ports.ZonesRepository].
+func (z *ZonesRepository) ListZones(ctx context.Context) ([]geo.Zone, error) {
+	q := `
+        SELECT id, name, zone_type, polygon, surcharge_amount, COALESCE(parent_zone_id::text, ''), is_active, created_at, updated_at
+        FROM zones
+        ORDER BY created_at DESC
+    `
+
+	rows, err := z.db.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := []geo.Zone{}
+	for rows.Next() {
+		var zone geo.Zone
+		var polygonJSON []byte
+		if err := rows.Scan(
+			&zone.ID,
+			&zone.Name,
+			&zone.Type,
+			&polygonJSON,
+			&zone.Surcharge,
+			&zone.ParentID,
+			&zone.IsActive,
+			&zone.CreatedAt,
+			&zone.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(polygonJSON, &zone.Polygon); err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// CreateZone implements [ports.ZonesRepository].
+func (z *ZonesRepository) CreateZone(ctx context.Context, zone *geo.Zone) error {
+	polygonJSON, err := json.Marshal(zone.Polygon)
+	if err != nil {
+		return err
+	}
+
+	q := `
+        INSERT INTO zones (name, zone_type, polygon, surcharge_amount, parent_zone_id, is_active)
+        VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, true)
+        RETURNING id, is_active, created_at, updated_at
+    `
+
+	return z.db.QueryRow(ctx, q,
+		zone.Name,
+		zone.Type,
+		polygonJSON,
+		zone.Surcharge,
+		zone.ParentID,
+	).Scan(&zone.ID, &zone.IsActive, &zone.CreatedAt, &zone.UpdatedAt)
+}
+
+// UpdateZone implements [ports.ZonesRepository].
+func (z *ZonesRepository) UpdateZone(ctx context.Context, zone *geo.Zone) error {
+	polygonJSON, err := json.Marshal(zone.Polygon)
+	if err != nil {
+		return err
+	}
+
+	q := `
+        UPDATE zones
+        SET name = $1, zone_type = $2, polygon = $3, surcharge_amount = $4,
+            parent_zone_id = NULLIF($5, '')::uuid, is_active = $6, updated_at = NOW()
+        WHERE id = $7
+    `
+
+	result, err := z.db.Exec(ctx, q,
+		zone.Name,
+		zone.Type,
+		polygonJSON,
+		zone.Surcharge,
+		zone.ParentID,
+		zone.IsActive,
+		zone.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrZoneNotFound
+	}
+
+	return nil
+}
+
+// DeactivateZone implements [ports.ZonesRepository].
+func (z *ZonesRepository) DeactivateZone(ctx context.Context, id string) error {
+	q := `UPDATE zones SET is_active = false, updated_at = NOW() WHERE id = $1`
+
+	result, err := z.db.Exec(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrZoneNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/shared/cities"
+)
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+func (s *Service) ListCities(ctx context.Context) (*models.CitiesList, error) {
+	result, err := s.citiesRepo.ListCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CitiesList{Cities: result}, nil
+}
+
+func (s *Service) CreateCity(ctx context.Context, req models.CityRequest) (*cities.City, error) {
+	if err := validateCity(req); err != nil {
+		return nil, err
+	}
+
+	city := cityFromRequest(req)
+	if err := s.citiesRepo.CreateCity(ctx, city); err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.InfoWithFields(ctx, "city_created", "city created", map[string]any{
+			"city_id":  city.ID,
+			"code":     city.Code,
+			"currency": city.Currency,
+		})
+	}
+
+	return city, nil
+}
+
+func (s *Service) UpdateCity(ctx context.Context, id string, req models.CityRequest) (*cities.City, error) {
+	if err := validateCity(req); err != nil {
+		return nil, err
+	}
+
+	city := cityFromRequest(req)
+	city.ID = id
+	if err := s.citiesRepo.UpdateCity(ctx, city); err != nil {
+		return nil, err
+	}
+
+	return city, nil
+}
+
+// overviewCity picks the city for the dashboard: the requested one or the default city.
+func (s *Service) overviewCity(ctx context.Context, idOrCode string) (*cities.City, error) {
+	if idOrCode != "" {
+		return s.citiesRepo.GetCity(ctx, idOrCode)
+	}
+	return s.citiesRepo.GetDefaultCity(ctx)
+}
+
+func cityFromRequest(req models.CityRequest) *cities.City {
+	city := &cities.City{
+		Code:        req.Code,
+		Name:        req.Name,
+		Currency:    req.Currency,
+		Timezone:    req.Timezone,
+		ServiceArea: req.ServiceArea,
+		IsDefault:   req.IsDefault,
+		IsActive:    true,
+	}
+	if req.IsActive != nil {
+		city.IsActive = *req.IsActive
+	}
+	return city
+}
+
+func validateCity(req models.CityRequest) error {
+	if req.Code == "" || req.Name == "" {
+		return fmt.Errorf("%w: code and name are required", models.ErrInvalidCity)
+	}
+	if !currencyCode.MatchString(req.Currency) {
+		return fmt.Errorf("%w: currency must be an ISO 4217 code", models.ErrInvalidCity)
+	}
+	if _, err := time.LoadLocation(req.Timezone); err != nil || req.Timezone == "" {
+		return fmt.Errorf("%w: unknown timezone %q", models.ErrInvalidCity, req.Timezone)
+	}
+	if len(req.ServiceArea) > 0 && !req.ServiceArea.IsValid() {
+		return fmt.Errorf("%w: service_area needs at least 3 valid points", models.ErrInvalidCity)
+	}
+	if len(req.ServiceArea) == 0 && !req.IsDefault {
+		return fmt.Errorf("%w: only the default city may omit service_area", models.ErrInvalidCity)
+	}
+	return nil
+}
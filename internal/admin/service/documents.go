@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/shared/onboarding"
+)
+
+// ListDriverDocuments returns the documents in the review status, pending ones by default.
+func (s *Service) ListDriverDocuments(ctx context.Context, status string) (*models.DriverDocumentsList, error) {
+	status = strings.ToUpper(status)
+	switch status {
+	case "":
+		status = onboarding.StatusPending
+	case onboarding.StatusPending, onboarding.StatusApproved, onboarding.StatusRejected:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", models.ErrInvalidReview, status)
+	}
+
+	result, err := s.documentsRepo.ListDocuments(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	return &models.DriverDocumentsList{Documents: result}, nil
+}
+
+// OpenDriverDocument returns the document with its file for the reviewer to look at.
+func (s *Service) OpenDriverDocument(ctx context.Context, id string) (*onboarding.Document, io.ReadCloser, error) {
+	doc, err := s.documentsRepo.GetDocument(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := s.documents.Open(ctx, doc.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open document file: %w", err)
+	}
+	return doc, file, nil
+}
+
+// ReviewDriverDocument approves or rejects the document, then verifies the driver
+// when all required documents are approved and unexpired, or unverifies them.
+func (s *Service) ReviewDriverDocument(ctx context.Context, id string, req models.DocumentReviewRequest) (*models.DocumentReview, error) {
+	decision := strings.ToUpper(strings.TrimSpace(req.Decision))
+	note := strings.TrimSpace(req.Note)
+	switch decision {
+	case onboarding.StatusApproved:
+	case onboarding.StatusRejected:
+		if note == "" {
+			return nil, fmt.Errorf("%w: note is required when rejecting", models.ErrInvalidReview)
+		}
+	default:
+		return nil, fmt.Errorf("%w: decision must be APPROVED or REJECTED", models.ErrInvalidReview)
+	}
+
+	doc, err := s.documentsRepo.ReviewDocument(ctx, id, decision, note)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := s.documentsRepo.ListDriverDocuments(ctx, doc.DriverID)
+	if err != nil {
+		return nil, err
+	}
+	missing := onboarding.Missing(docs, time.Now())
+	verified := len(missing) == 0
+	if err := s.documentsRepo.SetDriverVerified(ctx, doc.DriverID, verified); err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.InfoWithFields(ctx, "driver_document_reviewed", "driver document reviewed", map[string]any{
+			"document_id":     doc.ID,
+			"driver_id":       doc.DriverID,
+			"decision":        decision,
+			"driver_verified": verified,
+		})
+	}
+
+	if missing == nil {
+		missing = []string{}
+	}
+	return &models.DocumentReview{Document: *doc, DriverVerified: verified, MissingDocuments: missing}, nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/shared/promo"
+)
+
+func (s *Service) ListPromoCampaigns(ctx context.Context) (*models.PromoCampaignsList, error) {
+	result, err := s.promoRepo.ListCampaigns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PromoCampaignsList{Campaigns: result}, nil
+}
+
+func (s *Service) CreatePromoCampaign(ctx context.Context, req models.PromoCampaignRequest) (*promo.Campaign, error) {
+	campaign, err := s.campaignFromRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.promoRepo.CreateCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.InfoWithFields(ctx, "promo_campaign_created", "promo campaign created", map[string]any{
+			"campaign_id":   campaign.ID,
+			"code":          campaign.Code,
+			"discount_type": campaign.DiscountType,
+		})
+	}
+
+	return campaign, nil
+}
+
+func (s *Service) UpdatePromoCampaign(ctx context.Context, id string, req models.PromoCampaignRequest) (*promo.Campaign, error) {
+	campaign, err := s.campaignFromRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign.ID = id
+	if err := s.promoRepo.UpdateCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// campaignFromRequest validates the request and resolves its cities to IDs.
+func (s *Service) campaignFromRequest(ctx context.Context, req models.PromoCampaignRequest) (*promo.Campaign, error) {
+	campaign := &promo.Campaign{
+		Code:           promo.NormalizeCode(req.Code),
+		Name:           req.Name,
+		DiscountType:   req.DiscountType,
+		PercentOff:     req.PercentOff,
+		AmountOff:      req.AmountOff,
+		MaxDiscount:    req.MaxDiscount,
+		Currency:       req.Currency,
+		ValidFrom:      req.ValidFrom,
+		ValidUntil:     req.ValidUntil,
+		MaxRedemptions: req.MaxRedemptions,
+		MaxPerUser:     req.MaxPerUser,
+		VehicleTypes:   req.VehicleTypes,
+		IsActive:       true,
+	}
+	if req.IsActive != nil {
+		campaign.IsActive = *req.IsActive
+	}
+
+	if err := campaign.Validate(); err != nil {
+		return nil, err
+	}
+	if campaign.Currency != "" && !currencyCode.MatchString(campaign.Currency) {
+		return nil, fmt.Errorf("%w: currency must be an ISO 4217 code", promo.ErrInvalidCampaign)
+	}
+	if campaign.Currency == "" && campaign.DiscountType == promo.DiscountFixed {
+		return nil, fmt.Errorf("%w: a fixed discount needs a currency", promo.ErrInvalidCampaign)
+	}
+
+	for _, code := range req.VehicleTypes {
+		if _, err := s.vehicleClassesRepo.GetVehicleClass(ctx, code); err != nil {
+			if errors.Is(err, models.ErrVehicleClassNotFound) {
+				return nil, fmt.Errorf("%w: unknown vehicle type %q", promo.ErrInvalidCampaign, code)
+			}
+			return nil, err
+		}
+	}
+
+	for _, idOrCode := range req.Cities {
+		city, err := s.citiesRepo.GetCity(ctx, idOrCode)
+		if err != nil {
+			if errors.Is(err, models.ErrCityNotFound) {
+				return nil, fmt.Errorf("%w: unknown city %q", promo.ErrInvalidCampaign, idOrCode)
+			}
+			return nil, err
+		}
+		campaign.CityIDs = append(campaign.CityIDs, city.ID)
+	}
+
+	return campaign, nil
+}
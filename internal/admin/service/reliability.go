@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/shared/reliability"
+)
+
+// ListDriverReliability returns drivers' offer rates, all levels unless one is given.
+func (s *Service) ListDriverReliability(ctx context.Context, level string) (*models.DriverReliabilityList, error) {
+	level = strings.ToUpper(level)
+	switch level {
+	case "", reliability.LevelOK, reliability.LevelWarning, reliability.LevelCooldown:
+	default:
+		return nil, fmt.Errorf("%w: unknown level %q", models.ErrInvalidReliabilityLevel, level)
+	}
+
+	result, err := s.reliabilityRepo.ListDriverReliability(ctx, level)
+	if err != nil {
+		return nil, err
+	}
+	return &models.DriverReliabilityList{Drivers: result}, nil
+}
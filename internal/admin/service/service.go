@@ -2,30 +2,166 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"ride-hail/internal/admin/domain/models"
 	"ride-hail/internal/admin/domain/ports"
+	"ride-hail/internal/shared/geo"
 	"ride-hail/internal/shared/logger"
+	"ride-hail/internal/shared/storage"
 )
 
 type Service struct {
 	metricsRepo ports.MetricsRepository
 	ridesRepo   ports.RidesRepository
-	logger      *logger.Logger
+	zonesRepo   ports.ZonesRepository
+	citiesRepo  ports.CitiesRepository
+	promoRepo   ports.PromoRepository
+
+	documentsRepo ports.DocumentsRepository
+	// documents keeps the files drivers upload during onboarding
+	documents storage.Storage
+
+	reliabilityRepo ports.ReliabilityRepository
+
+	vehicleClassesRepo ports.VehicleClassesRepository
+
+	logger *logger.Logger
 }
 
-func NewService(metrics ports.MetricsRepository, rides ports.RidesRepository, log *logger.Logger) *Service {
+func NewService(
+	metrics ports.MetricsRepository,
+	rides ports.RidesRepository,
+	zones ports.ZonesRepository,
+	vehicleClasses ports.VehicleClassesRepository,
+	cityRepo ports.CitiesRepository,
+	promoRepo ports.PromoRepository,
+	documentsRepo ports.DocumentsRepository,
+	documents storage.Storage,
+	reliabilityRepo ports.ReliabilityRepository,
+	log *logger.Logger,
+) *Service {
 	return &Service{
-		metricsRepo: metrics,
-		ridesRepo:   rides,
-		logger:      log,
+		metricsRepo:        metrics,
+		ridesRepo:          rides,
+		zonesRepo:          zones,
+		vehicleClassesRepo: vehicleClasses,
+		citiesRepo:         cityRepo,
+		promoRepo:          promoRepo,
+		documentsRepo:      documentsRepo,
+		documents:          documents,
+		reliabilityRepo:    reliabilityRepo,
+		logger:             log,
 	}
 }
 
-func (s *Service) CollectRuntimeMetrics(ctx context.Context) (*models.Overview, error) {
-	return s.metricsRepo.FetchOverview(ctx)
+// CollectRuntimeMetrics builds the dashboard for the city given by ID or code,
+// or for the default city when none is given.
+func (s *Service) CollectRuntimeMetrics(ctx context.Context, city string) (*models.Overview, error) {
+	c, err := s.overviewCity(ctx, city)
+	switch {
+	case errors.Is(err, models.ErrCityNotFound) && city == "":
+		// Без городов считаем по всем поездкам в UTC
+		return s.metricsRepo.FetchOverview(ctx, "", "UTC")
+	case err != nil:
+		return nil, err
+	}
+
+	overview, err := s.metricsRepo.FetchOverview(ctx, c.ID, c.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	overview.City = c.Code
+	overview.Currency = c.Currency
+	overview.Metrics.TotalRevenueToday.Currency = c.Currency
+	return overview, nil
 }
 
 func (s *Service) CollectRidesInfo(ctx context.Context, page, pageSize int) (*models.RidesList, error) {
 	return s.ridesRepo.FetchRidesList(ctx, page, pageSize)
 }
+
+func (s *Service) ListZones(ctx context.Context) (*models.ZonesList, error) {
+	zones, err := s.zonesRepo.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ZonesList{Zones: zones}, nil
+}
+
+func (s *Service) CreateZone(ctx context.Context, req models.ZoneRequest) (*geo.Zone, error) {
+	if err := validateZone(req); err != nil {
+		return nil, err
+	}
+
+	zone := &geo.Zone{
+		Name:      req.Name,
+		Type:      req.Type,
+		Polygon:   req.Polygon,
+		Surcharge: req.Surcharge,
+		ParentID:  req.ParentID,
+	}
+
+	if err := s.zonesRepo.CreateZone(ctx, zone); err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.InfoWithFields(ctx, "zone_created", "zone created", map[string]any{
+			"zone_id":   zone.ID,
+			"zone_type": zone.Type,
+		})
+	}
+
+	return zone, nil
+}
+
+func (s *Service) UpdateZone(ctx context.Context, id string, req models.ZoneRequest) (*geo.Zone, error) {
+	if err := validateZone(req); err != nil {
+		return nil, err
+	}
+
+	zone := &geo.Zone{
+		ID:        id,
+		Name:      req.Name,
+		Type:      req.Type,
+		Polygon:   req.Polygon,
+		Surcharge: req.Surcharge,
+		ParentID:  req.ParentID,
+		IsActive:  true,
+	}
+	if req.IsActive != nil {
+		zone.IsActive = *req.IsActive
+	}
+
+	if err := s.zonesRepo.UpdateZone(ctx, zone); err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+func (s *Service) DeleteZone(ctx context.Context, id string) error {
+	return s.zonesRepo.DeactivateZone(ctx, id)
+}
+
+func validateZone(req models.ZoneRequest) error {
+	if req.Name == "" {
+		return fmt.Errorf("%w: name is required", models.ErrInvalidZone)
+	}
+	if !req.Type.IsValid() {
+		return fmt.Errorf("%w: unknown zone_type %q", models.ErrInvalidZone, req.Type)
+	}
+	if !req.Polygon.IsValid() {
+		return fmt.Errorf("%w: polygon needs at least 3 valid points", models.ErrInvalidZone)
+	}
+	if req.Type == geo.ZoneTypeAirportStaging && req.ParentID == "" {
+		return fmt.Errorf("%w: airport staging zone needs parent_zone_id", models.ErrInvalidZone)
+	}
+	if req.Surcharge.IsNegative() {
+		return fmt.Errorf("%w: surcharge_amount must not be negative", models.ErrInvalidZone)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"ride-hail/internal/admin/domain/models"
+	"ride-hail/internal/shared/pricing"
+)
+
+// vehicleClassCode - коды классов в верхнем регистре, как ECONOMY или ELECTRIC
+var vehicleClassCode = regexp.MustCompile(`^[A-Z][A-Z0-9_]{1,31}$`)
+
+// defaultTier is given to classes created without a tier
+const defaultTier = 1
+
+func (s *Service) ListVehicleClasses(ctx context.Context) (*models.VehicleClassesList, error) {
+	classes, err := s.vehicleClassesRepo.ListVehicleClasses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tariffs, err := s.vehicleClassesRepo.ListTariffs(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := pricing.NewCatalog(nil)
+	catalog.Set(classes, tariffs)
+
+	now := time.Now()
+	result := &models.VehicleClassesList{VehicleClasses: make([]models.VehicleClassInfo, 0, len(classes))}
+	for _, vc := range classes {
+		info := models.VehicleClassInfo{VehicleClass: vc}
+		if t, ok := catalog.TariffAt(vc.Code, "", now); ok {
+			info.CurrentTariff = &t
+		}
+		result.VehicleClasses = append(result.VehicleClasses, info)
+	}
+
+	return result, nil
+}
+
+func (s *Service) CreateVehicleClass(ctx context.Context, req models.VehicleClassRequest) (*pricing.VehicleClass, error) {
+	if !vehicleClassCode.MatchString(req.Code) {
+		return nil, fmt.Errorf("%w: code must be upper case letters, digits or underscores", models.ErrInvalidVehicleClass)
+	}
+	if err := validateVehicleClass(req); err != nil {
+		return nil, err
+	}
+
+	vc := &pricing.VehicleClass{
+		Code:        req.Code,
+		DisplayName: req.DisplayName,
+		Capacity:    req.Capacity,
+		Tier:        req.Tier,
+		IsActive:    true,
+	}
+	if vc.Tier == 0 {
+		vc.Tier = defaultTier
+	}
+	if req.IsActive != nil {
+		vc.IsActive = *req.IsActive
+	}
+
+	if err := s.vehicleClassesRepo.CreateVehicleClass(ctx, vc); err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.InfoWithFields(ctx, "vehicle_class_created", "vehicle class created", map[string]any{
+			"vehicle_type": vc.Code,
+		})
+	}
+
+	return vc, nil
+}
+
+func (s *Service) UpdateVehicleClass(ctx context.Context, code string, req models.VehicleClassRequest) (*pricing.VehicleClass, error) {
+	if err := validateVehicleClass(req); err != nil {
+		return nil, err
+	}
+
+	vc := &pricing.VehicleClass{
+		Code:        code,
+		DisplayName: req.DisplayName,
+		Capacity:    req.Capacity,
+		Tier:        req.Tier,
+		IsActive:    true,
+	}
+	if vc.Tier == 0 {
+		vc.Tier = defaultTier
+	}
+	if req.IsActive != nil {
+		vc.IsActive = *req.IsActive
+	}
+
+	if err := s.vehicleClassesRepo.UpdateVehicleClass(ctx, vc); err != nil {
+		return nil, err
+	}
+
+	return vc, nil
+}
+
+func (s *Service) ListTariffs(ctx context.Context, code string) (*models.TariffsList, error) {
+	if _, err := s.vehicleClassesRepo.GetVehicleClass(ctx, code); err != nil {
+		return nil, err
+	}
+
+	tariffs, err := s.vehicleClassesRepo.ListTariffs(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TariffsList{VehicleType: code, Tariffs: tariffs}, nil
+}
+
+// CreateTariff adds a new tariff version. Versions are never edited, so rides
+// already priced with an older version can still be explained.
+func (s *Service) CreateTariff(ctx context.Context, code string, req models.TariffRequest) (*pricing.Tariff, error) {
+	if _, err := s.vehicleClassesRepo.GetVehicleClass(ctx, code); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	effectiveFrom := now
+	if req.EffectiveFrom != nil {
+		effectiveFrom = *req.EffectiveFrom
+	}
+
+	if err := validateTariff(req, effectiveFrom, now); err != nil {
+		return nil, err
+	}
+
+	// city_id может быть и кодом города
+	if req.CityID != "" {
+		city, err := s.citiesRepo.GetCity(ctx, req.CityID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown city %q", models.ErrInvalidTariff, req.CityID)
+		}
+		req.CityID = city.ID
+	}
+
+	t := &pricing.Tariff{
+		VehicleType:       code,
+		CityID:            req.CityID,
+		BaseFare:          req.BaseFare,
+		RatePerKm:         req.RatePerKm,
+		RatePerMin:        req.RatePerMin,
+		MinimumFare:       req.MinimumFare,
+		WaitingRatePerMin: req.WaitingRatePerMin,
+		NoShowFee:         req.NoShowFee,
+		EffectiveFrom:     effectiveFrom,
+	}
+
+	if err := s.vehicleClassesRepo.CreateTariff(ctx, t); err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.InfoWithFields(ctx, "tariff_created", "tariff created", map[string]any{
+			"tariff_id":      t.ID,
+			"vehicle_type":   code,
+			"city_id":        t.CityID,
+			"effective_from": t.EffectiveFrom,
+		})
+	}
+
+	return t, nil
+}
+
+func validateVehicleClass(req models.VehicleClassRequest) error {
+	if req.DisplayName == "" {
+		return fmt.Errorf("%w: display_name is required", models.ErrInvalidVehicleClass)
+	}
+	if req.Capacity <= 0 {
+		return fmt.Errorf("%w: capacity must be positive", models.ErrInvalidVehicleClass)
+	}
+	if req.Tier < 0 {
+		return fmt.Errorf("%w: tier must not be negative", models.ErrInvalidVehicleClass)
+	}
+	return nil
+}
+
+func validateTariff(req models.TariffRequest, effectiveFrom, now time.Time) error {
+	if req.BaseFare.IsNegative() || req.RatePerKm.IsNegative() || req.RatePerMin.IsNegative() || req.MinimumFare.IsNegative() ||
+		req.WaitingRatePerMin.IsNegative() || req.NoShowFee.IsNegative() {
+		return fmt.Errorf("%w: prices must not be negative", models.ErrInvalidTariff)
+	}
+	// Прошлое менять нельзя: цены уже завершённых поездок должны остаться объяснимыми
+	if effectiveFrom.Before(now.Add(-time.Minute)) {
+		return fmt.Errorf("%w: effective_from must not be in the past", models.ErrInvalidTariff)
+	}
+	return nil
+}
@@ -3,27 +3,78 @@ package driver
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"ride-hail/internal/driver/handlers"
 	"ride-hail/internal/driver/handlers/ws"
 	"ride-hail/internal/driver/repositories"
 	"ride-hail/internal/driver/services"
+	"ride-hail/internal/shared/broker/messages"
 	"ride-hail/internal/shared/broker/rabbitmq"
+	"ride-hail/internal/shared/chat"
+	"ride-hail/internal/shared/destination"
+	"ride-hail/internal/shared/fatigue"
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/gps"
+	"ride-hail/internal/shared/onboarding"
+	"ride-hail/internal/shared/pool"
 	"ride-hail/internal/shared/postgres"
+	"ride-hail/internal/shared/pricing"
+	"ride-hail/internal/shared/reliability"
+	"ride-hail/internal/shared/storage"
 )
 
+// zoneRefreshInterval controls how often admin zone and tariff changes are picked up.
+const zoneRefreshInterval = 30 * time.Second
+
+// Locations streamed over the WebSocket: at most one per locationMinInterval per
+// driver, written to the database in batches of locationBatchSize or every
+// locationFlushInterval.
+const (
+	locationMinInterval   = 2 * time.Second
+	locationBatchSize     = 50
+	locationFlushInterval = 2 * time.Second
+	rateLimiterCleanup    = 10 * time.Minute
+)
+
+// staleSweepInterval controls how often drivers who stopped reporting are looked for.
+const staleSweepInterval = 30 * time.Second
+
+// fatigueCheckInterval controls how often online drivers are checked against the fatigue limits.
+const fatigueCheckInterval = time.Minute
+
+// documentExpiryInterval controls how often drivers are told about documents about to expire.
+const documentExpiryInterval = time.Hour
+
+// destinationExpiryInterval controls how often timed out destinations are ended.
+const destinationExpiryInterval = time.Minute
+
+// offerExpiryInterval controls how often unanswered offers are counted as expired.
+const offerExpiryInterval = 10 * time.Second
+
 type App struct {
-	server *handlers.Server
-	db     *postgres.Database
-	rmq    *rabbitmq.RMQ
-	hub    *ws.Hub
+	server  *handlers.Server
+	db      *postgres.Database
+	rmq     *rabbitmq.RMQ
+	hub     *ws.Hub
+	waiting pricing.WaitingPolicy
+	fatigue fatigue.Policy
+	// documents keeps the files drivers upload during onboarding
+	documents   storage.Storage
+	destination destination.Policy
+	reliability reliability.Policy
 }
 
-func NewApp(db *postgres.Database, rmq *rabbitmq.RMQ) *App {
+func NewApp(db *postgres.Database, rmq *rabbitmq.RMQ, waiting pricing.WaitingPolicy, fatiguePolicy fatigue.Policy, documents storage.Storage, destinationPolicy destination.Policy, reliabilityPolicy reliability.Policy) *App {
 	return &App{
-		db:  db,
-		rmq: rmq,
-		hub: ws.NewHub(),
+		db:          db,
+		rmq:         rmq,
+		hub:         ws.NewHub(),
+		waiting:     waiting,
+		fatigue:     fatiguePolicy,
+		documents:   documents,
+		destination: destinationPolicy,
+		reliability: reliabilityPolicy,
 	}
 }
 
@@ -33,8 +84,35 @@ func (a *App) Start(ctx context.Context) error {
 	sessionRepo := repositories.NewDriverSessionsRepository(a.db)
 	locationRepo := repositories.NewHistoryLocationRepository(a.db)
 	coordinateRepo := repositories.NewCoordinateRepository(a.db)
+	poolRepo := repositories.NewPoolRepository(a.db)
+	documentRepo := repositories.NewDocumentRepository(a.db)
 	txManager := postgres.NewTxManager(a.db)
 
+	// Geofenced zones, refreshed in the background
+	zones := geo.NewZoneCache(postgres.NewZoneLoader(a.db))
+	go zones.Run(ctx, zoneRefreshInterval)
+
+	// Vehicle classes and tariffs, used for the final fare
+	catalog := pricing.NewCatalog(postgres.NewCatalogLoader(a.db))
+	go catalog.Run(ctx, zoneRefreshInterval)
+
+	notifier := ws.NewWSNotifier(a.hub)
+	airportQueue := services.NewAirportQueue()
+
+	rateLimiter := services.NewRateLimiter(locationMinInterval)
+	go rateLimiter.Run(ctx, rateLimiterCleanup)
+
+	locationWriter := services.NewLocationWriter(coordinateRepo, locationRepo, txManager, locationBatchSize, locationFlushInterval)
+	go locationWriter.Run(ctx)
+
+	// Destination mode for drivers heading home, ended on arrival or when it times out
+	destinationService := services.NewDestinationService(repositories.NewDestinationRepository(a.db), txManager, notifier, a.destination)
+	go destinationService.Run(ctx, destinationExpiryInterval)
+
+	// Offer outcomes and the acceptance and cancellation rates they add up to
+	reliabilityService := services.NewReliabilityService(repositories.NewOfferRepository(a.db), notifier, a.reliability)
+	go reliabilityService.Run(ctx, offerExpiryInterval)
+
 	// Initialize service
 	driverService := services.NewDriverService(
 		driverRepo,
@@ -44,11 +122,70 @@ func (a *App) Start(ctx context.Context) error {
 		a.rmq, // consume
 		a.rmq, // publish
 		txManager,
+		notifier,
+		services.NewZoneTracker(zones),
+		airportQueue,
+		catalog,
+		a.waiting,
+		poolRepo,
+		pool.DefaultPolicy,
+		rateLimiter,
+		locationWriter,
+		gps.NewFilter(gps.DefaultPolicy),
+		a.fatigue,
+		documentRepo,
+		destinationService,
+		reliabilityService,
 	)
 
+	// Ride requests are matched to drivers: shared trips for pooled rides, airport queue, then nearest driver
+	matchingService := services.NewMatchingService(notifier, a.rmq, driverRepo, zones, airportQueue, poolRepo, pool.DefaultPolicy, catalog, destinationService, reliabilityService)
+	if err := matchingService.Start(ctx); err != nil {
+		slog.Error("failed to start matching service", "error", err.Error())
+		return err
+	}
+
+	// Passenger location, pickup changes and cancellations go to the assigned driver
+	passengerRelay := services.NewPassengerRelay(notifier, a.rmq, driverService)
+	if err := passengerRelay.Start(ctx); err != nil {
+		slog.Error("failed to start passenger relay", "error", err.Error())
+		return err
+	}
+
+	// Chat with the passenger: driver messages go to the ride service over driver_topic
+	chatService := chat.NewService(
+		postgres.NewChatStore(a.db),
+		chat.NewBrokerRelay(a.rmq, messages.ExchangeDriverTopic, messages.DriverChatRoutingKey),
+		notifier,
+	)
+	chatCh, err := a.rmq.Consume(ctx, messages.QueueDriverChat, "")
+	if err != nil {
+		slog.Error("failed to consume chat", "error", err.Error())
+		return err
+	}
+	go chatService.Consume(ctx, chatCh)
+
+	// Drivers whose app stopped reporting leave matching, then go offline
+	staleSweeper := services.NewStaleSweeper(driverRepo, driverService, notifier, services.DefaultStalePolicy)
+	go staleSweeper.Run(ctx, staleSweepInterval)
+
+	// Drivers are warned before the fatigue limits and made to rest once they reach them
+	fatigueMonitor := services.NewFatigueMonitor(driverRepo, driverService, notifier)
+	go fatigueMonitor.Run(ctx, fatigueCheckInterval)
+
+	// Document uploads for onboarding, with notices before they expire
+	onboardingService := services.NewOnboardingService(documentRepo, a.documents, notifier, notifier, onboarding.DefaultExpiryNotice)
+	go onboardingService.Run(ctx, documentExpiryInterval)
+
+	dispatcher := ws.NewDispatcher()
+	dispatcher.OnDisconnect(staleSweeper.Disconnected)
+	ws.RegisterOfferCommands(dispatcher, driverService)
+	ws.RegisterLocationCommands(dispatcher, driverService)
+	ws.RegisterChatCommands(dispatcher, chatService)
+
 	// Initialize handlers
-	handler := handlers.NewDriverHandler(driverService)
-	wsHandler := ws.NewWSHandler(a.hub)
+	handler := handlers.NewDriverHandler(driverService, onboardingService, destinationService)
+	wsHandler := ws.NewWSHandler(a.hub, dispatcher)
 
 	// Start WebSocket hub
 	a.hub.Start()
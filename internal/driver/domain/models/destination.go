@@ -0,0 +1,40 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"ride-hail/internal/shared/geo"
+)
+
+var ErrDestinationNotFound = errors.New("no destination set")
+
+// Destination is where a driver in destination mode is heading.
+type Destination struct {
+	ID        string     `json:"id"`
+	DriverID  string     `json:"driver_id"`
+	Latitude  float64    `json:"latitude"`
+	Longitude float64    `json:"longitude"`
+	Address   string     `json:"address,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	EndReason string     `json:"end_reason,omitempty"`
+}
+
+func (d Destination) Point() geo.Point {
+	return geo.Point{Lat: d.Latitude, Lng: d.Longitude}
+}
+
+type DestinationRequest struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Address   string  `json:"address"`
+}
+
+// DestinationStatus is the driver's destination, if any, and the uses left today.
+type DestinationStatus struct {
+	Destination *Destination `json:"destination"`
+	UsedToday   int          `json:"used_today"`
+	LeftToday   int          `json:"left_today"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
 
 type Driver struct {
 	ID     string
@@ -11,10 +15,22 @@ type Driver struct {
 	VehicleAttrs  VehicleAttributes
 	Rating        float64
 	TotalRides    int
-	TotalEarnings float64
+	TotalEarnings money.Money
 
 	Status    DriverStatus
 	IsVerifed bool
+	// IsStale is set when the driver stopped reporting their location
+	IsStale bool
+	// CooldownUntil is set while the driver gets no offers for ignoring or cancelling too many
+	CooldownUntil *time.Time
+}
+
+// DriverActivity is when an online driver was last heard from.
+type DriverActivity struct {
+	DriverID       string
+	Status         DriverStatus
+	IsStale        bool
+	LastLocationAt time.Time
 }
 
 type VehicleAttributes struct {
@@ -33,7 +49,7 @@ type DriverSession struct {
 	EndedAt   time.Time
 
 	TotalRides    int
-	TotalEarnings float64
+	TotalEarnings money.Money
 }
 
 type LocationHistory struct {
@@ -47,6 +63,11 @@ type LocationHistory struct {
 	HeadingDegrees *float64
 	RecordedAt     time.Time
 	RideID         *string
+	// What the device reported and what the GPS filter made of it
+	RawLatitude  float64
+	RawLongitude float64
+	GPSVerdict   string
+	GPSReason    string
 }
 
 type Coordinate struct {
@@ -70,6 +91,7 @@ type LocationUpdate struct {
 type SessionSummary struct {
 	SessionID      string
 	DurationHours  float64
+	BreakHours     float64
 	RidesCompleted int
-	Earnings       float64
+	Earnings       money.Money
 }
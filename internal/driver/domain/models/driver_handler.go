@@ -13,10 +13,20 @@ type OfflineResponse struct {
 }
 
 type StartDriveRequest struct {
+	RideID    string   `json:"ride_id"`
+	PickupPIN string   `json:"pickup_pin"`
+	Location  Location `json:"driver_location"`
+}
+
+type ArrivedRequest struct {
 	RideID   string   `json:"ride_id"`
 	Location Location `json:"driver_location"`
 }
 
+type NoShowRequest struct {
+	RideID string `json:"ride_id"`
+}
+
 type CompleteRideRequest struct {
 	RideID                string   `json:"ride_id"`
 	Location              Location `json:"final_location"`
@@ -1,5 +1,7 @@
 package models
 
+import "errors"
+
 type DriverStatus string
 
 const (
@@ -7,21 +9,60 @@ const (
 	Available DriverStatus = "AVAILABLE"
 	Busy      DriverStatus = "BUSY"
 	EnRoute   DriverStatus = "EN_ROUTE" // в пути
+	OnBreak   DriverStatus = "ON_BREAK" // на перерыве, смена не закрыта
 )
 
+var ErrStatusTransition = errors.New("driver status transition not allowed")
+
+// transitions lists the statuses a driver may move to from each status.
+var transitions = map[DriverStatus][]DriverStatus{
+	Offline:   {Available},
+	Available: {Offline, OnBreak, EnRoute, Busy},
+	OnBreak:   {Available, Offline},
+	EnRoute:   {Busy, Available},
+	Busy:      {Available},
+}
+
 func (s DriverStatus) String() string {
 	return string(s)
 }
 
 func (s DriverStatus) IsValid() bool {
 	switch s {
-	case Offline, Available, Busy, EnRoute:
+	case Offline, Available, Busy, EnRoute, OnBreak:
 		return true
 	default:
 		return false
 	}
 }
 
+// CanTransitionTo reports whether a driver may move from s to next. Staying in
+// the same status is always allowed.
+func (s DriverStatus) CanTransitionTo(next DriverStatus) bool {
+	if s == next {
+		return true
+	}
+	for _, to := range transitions[s] {
+		if to == next {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedFrom returns the statuses a driver may move to s from, s included.
+func (s DriverStatus) AllowedFrom() []DriverStatus {
+	from := []DriverStatus{s}
+	for status, next := range transitions {
+		for _, to := range next {
+			if to == s {
+				from = append(from, status)
+			}
+		}
+	}
+	return from
+}
+
 func ParseDriverStatus(s string) (DriverStatus, bool) {
 	ds := DriverStatus(s)
 	return ds, ds.IsValid()
@@ -42,3 +83,18 @@ const (
 func (s RideStatus) String() string {
 	return string(s)
 }
+
+// rideFrom lists the statuses a ride may reach each status from when the driver
+// moves it on. A cancelled or finished ride moves no further.
+var rideFrom = map[RideStatus][]RideStatus{
+	RideStatusMatched:    {RideStatusRequested},
+	RideStatusEnRoute:    {RideStatusMatched},
+	RideStatusArrived:    {RideStatusMatched, RideStatusEnRoute},
+	RideStatusInProgress: {RideStatusMatched, RideStatusEnRoute, RideStatusArrived},
+	RideStatusCompleted:  {RideStatusInProgress},
+}
+
+// AllowedFrom returns the statuses a ride may move to s from.
+func (s RideStatus) AllowedFrom() []RideStatus {
+	return rideFrom[s]
+}
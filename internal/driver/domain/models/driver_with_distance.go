@@ -7,4 +7,9 @@ type DriverWithDistance struct {
 	Latitude   float64 `json:"latitude"`
 	Longitude  float64 `json:"longitude"`
 	DistanceKm float64 `json:"distance_km"`
+
+	VehicleType string `json:"vehicle_type"`
+	// Rolling offer rates, nil until the driver has had offers
+	AcceptanceRate   *float64 `json:"acceptance_rate"`
+	CancellationRate *float64 `json:"cancellation_rate"`
 }
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+// RideEventDriverDeclined is recorded when a driver declines a ride offer.
+const RideEventDriverDeclined = "DRIVER_DECLINED"
+
+// SessionRecord is a past or open session with what the driver did in it.
+type SessionRecord struct {
+	SessionID       string      `json:"session_id"`
+	StartedAt       time.Time   `json:"started_at"`
+	EndedAt         *time.Time  `json:"ended_at,omitempty"`
+	OnlineHours     float64     `json:"online_hours"`
+	BreakHours      float64     `json:"break_hours"`
+	RidesCompleted  int         `json:"rides_completed"`
+	RidesCancelled  int         `json:"rides_cancelled"`
+	Earnings        money.Money `json:"earnings"`
+	EarningsPerHour money.Money `json:"earnings_per_hour"`
+}
+
+type SessionHistory struct {
+	Sessions []SessionRecord `json:"sessions"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+	Total    int             `json:"total"`
+}
+
+// EarningsReport sums up the sessions started in a period and the offers made
+// to the driver in it. Rates are left out when there is nothing to rate.
+type EarningsReport struct {
+	Period          string      `json:"period"`
+	From            time.Time   `json:"from"`
+	To              time.Time   `json:"to"`
+	Sessions        int         `json:"sessions"`
+	OnlineHours     float64     `json:"online_hours"`
+	BreakHours      float64     `json:"break_hours"`
+	RidesCompleted  int         `json:"rides_completed"`
+	Earnings        money.Money `json:"earnings"`
+	EarningsPerHour money.Money `json:"earnings_per_hour"`
+	// RidesAccepted counts offers the driver accepted, RidesCancelled those of
+	// them the driver cancelled afterwards, OffersDeclined the offers turned down
+	// and OffersExpired the ones left unanswered
+	RidesAccepted    int      `json:"rides_accepted"`
+	RidesCancelled   int      `json:"rides_cancelled"`
+	OffersDeclined   int      `json:"offers_declined"`
+	OffersExpired    int      `json:"offers_expired"`
+	AcceptanceRate   *float64 `json:"acceptance_rate,omitempty"`
+	CancellationRate *float64 `json:"cancellation_rate,omitempty"`
+}
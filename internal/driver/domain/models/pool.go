@@ -0,0 +1,19 @@
+package models
+
+import (
+	"errors"
+
+	"ride-hail/internal/shared/pool"
+)
+
+// ErrPoolNotFound is returned when the driver has no active pool.
+var ErrPoolNotFound = errors.New("pool not found")
+
+// Pool is the trip of one driver shared by several POOL rides.
+type Pool struct {
+	ID       string
+	DriverID string
+	Plan     pool.Plan
+	// DriverLocation is the current position of the driver; set by FindNearby
+	DriverLocation Location
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Limits of the preferences a driver may set
+const (
+	MaxPickupKmLimit = 50
+	MinTripKmLimit   = 100
+)
+
+var ErrInvalidPreferences = errors.New("invalid preferences")
+
+// PaymentCash rides are paid to the driver in cash, everything else by card.
+const PaymentCash = "CASH"
+
+// Preferences are what work a driver wants to be offered.
+type Preferences struct {
+	// MaxPickupKm caps the distance to the pickup, zero leaves it to matching
+	MaxPickupKm float64 `json:"max_pickup_km"`
+	// AcceptLowerClasses lets the driver take rides of a lower class their car can serve
+	AcceptLowerClasses bool    `json:"accept_lower_classes"`
+	MinTripKm          float64 `json:"min_trip_km"`
+	AcceptCash         bool    `json:"accept_cash"`
+	AcceptCard         bool    `json:"accept_card"`
+}
+
+// DefaultPreferences apply to drivers who have not set any.
+var DefaultPreferences = Preferences{AcceptCash: true, AcceptCard: true}
+
+// Offer is a ride as the driver's preferences see it.
+type Offer struct {
+	VehicleType string
+	// TripKm is the estimated trip length, zero when unknown
+	TripKm        float64
+	PaymentMethod string
+}
+
+// Validate checks the preferences are within limits and leave some way to pay.
+func (p Preferences) Validate() error {
+	if p.MaxPickupKm < 0 || p.MaxPickupKm > MaxPickupKmLimit {
+		return fmt.Errorf("%w: max_pickup_km must be between 0 and %d", ErrInvalidPreferences, MaxPickupKmLimit)
+	}
+	if p.MinTripKm < 0 || p.MinTripKm > MinTripKmLimit {
+		return fmt.Errorf("%w: min_trip_km must be between 0 and %d", ErrInvalidPreferences, MinTripKmLimit)
+	}
+	if !p.AcceptCash && !p.AcceptCard {
+		return fmt.Errorf("%w: accept cash, card or both", ErrInvalidPreferences)
+	}
+	return nil
+}
+
+// Accepts reports whether the driver wants the offer with the pickup pickupKm
+// away. lowerClass is set when the ride is of a lower class than the driver's car.
+// FindAvailableDriversNearby applies the same rules in SQL.
+func (p Preferences) Accepts(o Offer, pickupKm float64, lowerClass bool) bool {
+	if lowerClass && !p.AcceptLowerClasses {
+		return false
+	}
+	if p.MaxPickupKm > 0 && pickupKm > p.MaxPickupKm {
+		return false
+	}
+	if o.TripKm > 0 && o.TripKm < p.MinTripKm {
+		return false
+	}
+	if o.PaymentMethod == PaymentCash {
+		return p.AcceptCash
+	}
+	return p.AcceptCard
+}
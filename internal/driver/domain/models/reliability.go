@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/reliability"
+)
+
+// RideOffer is a ride offered to a driver and what became of it.
+type RideOffer struct {
+	ID        string
+	DriverID  string
+	RideID    string
+	OfferedAt time.Time
+	ExpiresAt time.Time
+	Outcome   string
+}
+
+// Reliability is how a driver deals with offers: rolling rates and any cooldown.
+type Reliability struct {
+	reliability.Stats
+	// CooldownUntil is set while the driver gets no offers
+	CooldownUntil *time.Time `json:"cooldown_until,omitempty"`
+}
+
+// InCooldown reports whether the driver gets no offers at now.
+func (r Reliability) InCooldown(now time.Time) bool {
+	return r.CooldownUntil != nil && now.Before(*r.CooldownUntil)
+}
+
+type DriverProfile struct {
+	DriverID      string       `json:"driver_id"`
+	Status        DriverStatus `json:"status"`
+	VehicleType   string       `json:"vehicle_type"`
+	Rating        float64      `json:"rating"`
+	TotalRides    int          `json:"total_rides"`
+	TotalEarnings money.Money  `json:"total_earnings"`
+	IsVerified    bool         `json:"is_verified"`
+	Reliability   Reliability  `json:"reliability"`
+}
+
+type CancelRideRequest struct {
+	RideID string `json:"ride_id"`
+	Reason string `json:"reason"`
+}
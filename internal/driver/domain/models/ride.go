@@ -1,6 +1,13 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
+	"ride-hail/internal/shared/promo"
+)
 
 type Ride struct {
 	ID            string
@@ -9,7 +16,33 @@ type Ride struct {
 	DriverID      string
 	VehicleType   string
 	Status        RideStatus
-	EstimatedFare float64
-	FinalFare     float64
+	EstimatedFare money.Money
+	// FareBreakdown itemizes the estimated fare; nil for rides created before it was stored
+	FareBreakdown *pricing.Breakdown
+	FinalFare     money.Money
+	ZoneSurcharge money.Money
+	CityID        string
+	Currency      string
+	PickupPIN     string
+	WaitingCharge money.Money
+	ArrivedAt     *time.Time
+	StartedAt     *time.Time
 	CreatedAt     time.Time
+	Pickup        Location
+	Destination   Location
+	// Pooled rides may share the car; PoolID is set once the ride is matched into a pool
+	Pooled bool
+	PoolID string
+	// Promo is the campaign of the promo code reserved for the ride, if any
+	Promo *promo.Campaign
 }
+
+// ErrRideNotAvailable is returned when a ride was already taken by another driver or cancelled.
+var ErrRideNotAvailable = errors.New("ride is no longer available")
+
+// Ride event types recorded by the driver service
+const (
+	RideEventPINFailed       = "PIN_FAILED"
+	RideEventPassengerNoShow = "PASSENGER_NO_SHOW"
+	RideEventRideCancelled   = "RIDE_CANCELLED"
+)
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+)
+
+type DestinationRepository interface {
+	// Create stores the destination and fills in its ID and CreatedAt.
+	Create(ctx context.Context, d *models.Destination) error
+	// Active returns the destinations in use by the given drivers, keyed by driver ID.
+	// Destinations past their expiry are left out even before they are ended.
+	Active(ctx context.Context, driverIDs []string) (map[string]models.Destination, error)
+	// CountSince counts the destinations the driver set since the given time.
+	CountSince(ctx context.Context, driverID string, since time.Time) (int, error)
+	// End ends the destination for the given reason. It reports false if it had ended already.
+	End(ctx context.Context, id, reason string) (bool, error)
+	// ListExpired returns destinations still in use that expired before the given time.
+	ListExpired(ctx context.Context, before time.Time) ([]models.Destination, error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"ride-hail/internal/shared/onboarding"
+)
+
+type DocumentRepository interface {
+	// Create stores a new pending document and fills in its ID and CreatedAt.
+	Create(ctx context.Context, doc *onboarding.Document) error
+	// ListByDriver returns the driver's documents, newest first.
+	ListByDriver(ctx context.Context, driverID string) ([]onboarding.Document, error)
+	// ListExpiring returns approved documents expiring before the given time that
+	// the driver has not been told about and has not renewed yet.
+	ListExpiring(ctx context.Context, before time.Time) ([]onboarding.Document, error)
+	MarkExpiryNotified(ctx context.Context, id string) error
+}
@@ -2,20 +2,49 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
 )
 
 type DriverRepository interface {
 	GetById(ctx context.Context, id string) (*models.Driver, error)
 	Update(ctx context.Context, driver *models.Driver) error
 	UpdateStatus(ctx context.Context, id string, status models.DriverStatus) error
+	// SetStale marks the driver as stale, which keeps them out of matching, or clears the mark.
+	SetStale(ctx context.Context, id string, stale bool) error
+	// ListActivity returns the drivers who are not offline with the time of their current location.
+	ListActivity(ctx context.Context) ([]models.DriverActivity, error)
 	UpdateRideStatus(ctx context.Context, rideID string, status models.RideStatus) error
 	GetRideByID(ctx context.Context, rideID string) (*models.Ride, error)
+	// SetRideFinalFare stores the final fare with its line items.
+	SetRideFinalFare(ctx context.Context, rideID string, fare pricing.Breakdown) error
+	// ConsumePromoRedemption marks the promo code reserved for the ride as used with the discount given.
+	ConsumePromoRedemption(ctx context.Context, rideID string, discount money.Money) error
+	// AssignRide matches a requested ride to the driver and stores its pickup PIN.
+	// It returns models.ErrRideNotAvailable if the ride is no longer requested.
+	AssignRide(ctx context.Context, rideID, driverID, pickupPIN string) error
+	SetRideWaitingCharge(ctx context.Context, rideID string, charge money.Money) error
+	// CancelRide cancels a ride that has not finished yet, charging the fee as its final fare.
+	// A promo code reserved for the ride is given back to its campaign.
+	CancelRide(ctx context.Context, rideID, reason string, fee pricing.Breakdown) error
+	AddRideEvent(ctx context.Context, rideID, eventType string, data map[string]any) error
+	CountRideEvents(ctx context.Context, rideID, eventType string, since time.Time) (int, error)
+	// LockRide locks the ride row until the transaction in ctx ends.
+	LockRide(ctx context.Context, rideID string) error
+	// FindAvailableDriversNearby returns up to 10 available drivers within the radius
+	// who want the offer, drivers of its class before drivers of the upgrades, who
+	// opted in to lower classes, and the nearest first.
 	FindAvailableDriversNearby(
 		ctx context.Context,
 		lat, lon float64,
-		vehicleType string,
+		offer models.Offer,
+		upgrades []string,
 		radiusMeters int,
 	) ([]models.DriverWithDistance, error)
+	// GetPreferences returns the driver's preferences, models.DefaultPreferences if none were set.
+	GetPreferences(ctx context.Context, driverID string) (models.Preferences, error)
+	SavePreferences(ctx context.Context, driverID string, prefs models.Preferences) error
 }
@@ -2,8 +2,10 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/fatigue"
 )
 
 type DriverSessionsRepository interface {
@@ -12,4 +14,19 @@ type DriverSessionsRepository interface {
 	Update(ctx context.Context, driverSession *models.DriverSession) error
 	Close(ctx context.Context, driverSessionId string) error
 	GetActiveByDriverID(ctx context.Context, driverID string) (*models.DriverSession, error)
+	// StartBreak opens a break in the session.
+	StartBreak(ctx context.Context, sessionID, driverID string) error
+	// EndBreak closes the open break of the session, if there is one.
+	EndBreak(ctx context.Context, sessionID string) error
+	// BreakTime returns the time spent on breaks during the session, an open break up to now.
+	BreakTime(ctx context.Context, sessionID string) (time.Duration, error)
+	// SessionsSince returns the driver's sessions that were still open at since.
+	SessionsSince(ctx context.Context, driverID string, since time.Time) ([]fatigue.Interval, error)
+	// BreaksSince returns the driver's breaks that were still going on at since.
+	BreaksSince(ctx context.Context, driverID string, since time.Time) ([]fatigue.Interval, error)
+	// History returns a page of the driver's sessions, newest first, with the total number of sessions.
+	History(ctx context.Context, driverID string, limit, offset int) ([]models.SessionRecord, int, error)
+	// Report sums up the sessions the driver started in [from, to).
+	// Offers, rates and earnings per hour are left for the caller.
+	Report(ctx context.Context, driverID string, from, to time.Time) (*models.EarningsReport, error)
 }
@@ -8,4 +8,5 @@ import (
 
 type HistoryLocationRepository interface {
 	AddLocation(ctx context.Context, locationHistory *models.LocationHistory) error
+	AddLocations(ctx context.Context, locations []*models.LocationHistory) error
 }
@@ -2,4 +2,5 @@ package ports
 
 type Notifier interface {
 	Notify(event interface{}) error
+	NotifyDriver(driverID string, event interface{}) error
 }
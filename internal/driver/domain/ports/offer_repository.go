@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/reliability"
+)
+
+type OfferRepository interface {
+	// Create stores a pending offer and fills in its ID and OfferedAt.
+	Create(ctx context.Context, offer *models.RideOffer) error
+	// Decide records the outcome of the driver's offer of the ride: a pending offer
+	// is accepted, declined or expires, an accepted one is cancelled. A late accept
+	// of an expired offer still counts as accepted. It reports false when there
+	// was no offer to decide.
+	Decide(ctx context.Context, driverID, rideID, outcome string) (bool, error)
+	// IsOpen reports whether the ride is offered to the driver and the offer is still
+	// unanswered and unexpired at now. The offer stays locked until the transaction ends.
+	IsOpen(ctx context.Context, driverID, rideID string, now time.Time) (bool, error)
+	// ListExpired returns pending offers that expired before the given time.
+	ListExpired(ctx context.Context, before time.Time) ([]models.RideOffer, error)
+	// Recent counts the outcomes of the driver's last decided offers, at most window of them.
+	Recent(ctx context.Context, driverID string, window int) (reliability.Counts, error)
+	// Between counts the outcomes of the driver's decided offers made in [from, to).
+	Between(ctx context.Context, driverID string, from, to time.Time) (reliability.Counts, error)
+	// GetStanding returns the rates, level and cooldown last saved for the driver.
+	GetStanding(ctx context.Context, driverID string) (models.Reliability, error)
+	// SaveStanding stores the driver's rates and level, and the cooldown if one is given.
+	SaveStanding(ctx context.Context, driverID string, stats reliability.Stats, cooldownUntil *time.Time) error
+}
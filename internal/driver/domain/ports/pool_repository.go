@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/pool"
+)
+
+type PoolRepository interface {
+	// GetActive returns the driver's active pool or models.ErrPoolNotFound.
+	GetActive(ctx context.Context, driverID string) (*models.Pool, error)
+	// Create starts a pool for the driver with its first ride.
+	Create(ctx context.Context, driverID, rideID string, plan pool.Plan) (string, error)
+	// AddRide puts the ride into the pool and stores the new plan.
+	AddRide(ctx context.Context, poolID, rideID string, plan pool.Plan) error
+	// UpdatePlan stores the plan; a pool with an empty plan is completed.
+	UpdatePlan(ctx context.Context, poolID string, plan pool.Plan) error
+	// FindNearby returns the active pools of online drivers of the vehicle type
+	// within the radius, nearest first, with the drivers' locations.
+	FindNearby(ctx context.Context, lat, lon float64, vehicleType string, radiusMeters int) ([]models.Pool, error)
+}
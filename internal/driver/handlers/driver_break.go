@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/services"
+)
+
+func (h *DriverHandler) StartBreak(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+	defer r.Body.Close()
+
+	if err := h.service.StartBreak(r.Context(), driver_id); err != nil {
+		writeStatusError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"status":  models.OnBreak.String(),
+		"message": "You are on a break, your session stays open",
+	})
+}
+
+func (h *DriverHandler) EndBreak(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+	defer r.Body.Close()
+
+	onBreak, err := h.service.EndBreak(r.Context(), driver_id)
+	if err != nil {
+		writeStatusError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"status":      models.Available.String(),
+		"break_hours": onBreak.Hours(),
+		"message":     "Welcome back, you are ready to accept rides",
+	})
+}
+
+func writeStatusError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrStatusTransition):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, services.ErrFatigueLimit):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/destination"
+)
+
+func (h *DriverHandler) GetDestination(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+
+	status, err := h.destinations.Status(r.Context(), driver_id, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(status)
+}
+
+// SetDestination puts the driver in destination mode, replacing a destination already set.
+func (h *DriverHandler) SetDestination(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+	defer r.Body.Close()
+
+	var req models.DestinationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	dest, err := h.destinations.Set(r.Context(), driver_id, req, time.Now())
+	if err != nil {
+		if errors.Is(err, destination.ErrDailyLimit) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dest)
+}
+
+func (h *DriverHandler) ClearDestination(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+
+	if err := h.destinations.Clear(r.Context(), driver_id); err != nil {
+		if errors.Is(err, models.ErrDestinationNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"ride-hail/internal/shared/onboarding"
+)
+
+// maxUploadMemory is how much of a multipart upload is kept in memory, the rest
+// goes to a temporary file.
+const maxUploadMemory = 1 << 20
+
+// UploadDocument takes a multipart form with the document type, its expiry date
+// (2006-01-02 or RFC 3339) and the scan in the "file" field.
+func (h *DriverHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+	r.Body = http.MaxBytesReader(w, r.Body, onboarding.MaxFileSize+maxUploadMemory)
+	defer r.Body.Close()
+
+	if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+		http.Error(w, "invalid multipart form or file too large", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	expiresAt, err := parseExpiry(r.FormValue("expires_at"))
+	if err != nil {
+		http.Error(w, "expires_at must be a date like 2027-01-31", http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	doc, err := h.onboarding.UploadDocument(r.Context(), driver_id, r.FormValue("type"), expiresAt, header.Filename, file, header.Size)
+	if err != nil {
+		if errors.Is(err, onboarding.ErrInvalidDocument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(doc)
+}
+
+func (h *DriverHandler) ListDocuments(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+
+	docs, err := h.onboarding.Documents(r.Context(), driver_id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"documents": docs,
+		"missing":   onboarding.Missing(docs, now),
+	})
+}
+
+func parseExpiry(v string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
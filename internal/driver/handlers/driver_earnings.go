@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ride-hail/internal/shared/earnings"
+)
+
+func (h *DriverHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+
+	page, pageSize := 1, 20
+	if v := r.URL.Query().Get("page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p < 1 {
+			http.Error(w, "invalid page parameter", http.StatusBadRequest)
+			return
+		}
+		page = p
+	}
+	if v := r.URL.Query().Get("page_size"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p < 1 || p > 100 {
+			http.Error(w, "invalid page_size parameter", http.StatusBadRequest)
+			return
+		}
+		pageSize = p
+	}
+
+	history, err := h.service.SessionHistory(r.Context(), driver_id, page, pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(history)
+}
+
+// GetEarnings reports on the period given by ?period=day|week|month, the current
+// one unless ?date=2006-01-02 picks another.
+func (h *DriverHandler) GetEarnings(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = earnings.PeriodWeek
+	}
+	at := time.Now()
+	if v := r.URL.Query().Get("date"); v != "" {
+		d, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			http.Error(w, "date must look like 2026-01-31", http.StatusBadRequest)
+			return
+		}
+		at = d
+	}
+
+	report, err := h.service.Earnings(r.Context(), driver_id, period, at)
+	if err != nil {
+		if errors.Is(err, earnings.ErrInvalidPeriod) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
@@ -2,7 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
 	"net/http"
 	"time"
 
@@ -13,12 +13,16 @@ import (
 const path_value string = "driver_id"
 
 type DriverHandler struct {
-	service *services.DriverService
+	service      *services.DriverService
+	onboarding   *services.OnboardingService
+	destinations *services.DestinationService
 }
 
-func NewDriverHandler(service *services.DriverService) *DriverHandler {
+func NewDriverHandler(service *services.DriverService, onboarding *services.OnboardingService, destinations *services.DestinationService) *DriverHandler {
 	return &DriverHandler{
-		service: service,
+		service:      service,
+		onboarding:   onboarding,
+		destinations: destinations,
 	}
 }
 
@@ -35,6 +39,10 @@ func (h *DriverHandler) ChangeDriverStatusToOnline(w http.ResponseWriter, r *htt
 
 	session_id, err := h.service.GoOnline(r.Context(), driver_id, req.Latitude, req.Longitude)
 	if err != nil {
+		if errors.Is(err, services.ErrFatigueLimit) || errors.Is(err, services.ErrNotVerified) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -84,6 +92,10 @@ func (h *DriverHandler) UpdateDriverLocation(w http.ResponseWriter, r *http.Requ
 
 	coordID, err := h.service.UpdateLocation(r.Context(), driver_id, &req)
 	if err != nil {
+		if errors.Is(err, services.ErrLocationRejected) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -110,9 +122,18 @@ func (h *DriverHandler) StartRide(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.service.StartRide(r.Context(), driver_id, req.RideID, req.Location.Latitude, req.Location.Longitude)
+	err := h.service.StartRide(r.Context(), driver_id, req.RideID, req.PickupPIN, req.Location.Latitude, req.Location.Longitude)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		switch {
+		case errors.Is(err, services.ErrTooManyPINAttempts):
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+		case errors.Is(err, services.ErrInvalidPickupPIN), errors.Is(err, services.ErrRideNotAssigned):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, models.ErrRideNotAvailable):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
 		return
 	}
 
@@ -126,11 +147,79 @@ func (h *DriverHandler) StartRide(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *DriverHandler) MarkArrived(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+	defer r.Body.Close()
+	var req models.ArrivedRequest
+
+	// Decode the JSON request body
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	info, err := h.service.MarkArrived(r.Context(), driver_id, req.RideID, req.Location.Latitude, req.Location.Longitude)
+	if err != nil {
+		if errors.Is(err, services.ErrRideNotAssigned) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"ride_id":              req.RideID,
+		"status":               "ARRIVED",
+		"arrived_at":           info.ArrivedAt.Format(time.RFC3339),
+		"waiting_free_until":   info.FreeUntil.Format(time.RFC3339),
+		"no_show_available_at": info.NoShowAt.Format(time.RFC3339),
+		"message":              "Arrival recorded, waiting for the passenger",
+	})
+}
+
+func (h *DriverHandler) MarkNoShow(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+	defer r.Body.Close()
+	var req models.NoShowRequest
+
+	// Decode the JSON request body
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	fee, err := h.service.MarkNoShow(r.Context(), driver_id, req.RideID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrRideNotAssigned):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, services.ErrNoShowTooEarly), errors.Is(err, models.ErrRideNotAvailable):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"ride_id":      req.RideID,
+		"status":       "AVAILABLE",
+		"no_show_fee":  fee.Decimal(),
+		"currency":     fee.Currency,
+		"cancelled_at": time.Now().Format(time.RFC3339),
+		"message":      "Ride cancelled, passenger did not show up",
+	})
+}
+
 func (h *DriverHandler) CompleteRide(w http.ResponseWriter, r *http.Request) {
 	driver_id := r.PathValue(path_value)
 	defer r.Body.Close()
 	var req models.CompleteRideRequest
-	var earnings float64
 
 	// Decode the JSON request body
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -140,6 +229,10 @@ func (h *DriverHandler) CompleteRide(w http.ResponseWriter, r *http.Request) {
 
 	earnings, err := h.service.CompleteRide(r.Context(), driver_id, req.RideID, req.Location.Latitude, req.Location.Longitude, req.ActualDistanceKm, req.ActualDurationMinutes)
 	if err != nil {
+		if errors.Is(err, models.ErrRideNotAvailable) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -150,7 +243,7 @@ func (h *DriverHandler) CompleteRide(w http.ResponseWriter, r *http.Request) {
 		"ride_id":         req.RideID,
 		"status":          "AVAILABLE",
 		"completed_at":    time.Now().Format(time.RFC3339),
-		"driver_earnings": fmt.Sprintf("%.2f", earnings),
+		"driver_earnings": earnings.Decimal(),
 		"message":         "Ride completed successfully",
 	})
 }
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"ride-hail/internal/driver/domain/models"
+)
+
+func (h *DriverHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+
+	prefs, err := h.service.Preferences(r.Context(), driver_id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(prefs)
+}
+
+// UpdatePreferences replaces the driver's preferences, fields left out of the
+// body take their defaults.
+func (h *DriverHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+
+	prefs := models.DefaultPreferences
+	if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	saved, err := h.service.UpdatePreferences(r.Context(), driver_id, prefs)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidPreferences) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(saved)
+}
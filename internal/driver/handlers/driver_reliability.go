@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/services"
+)
+
+// GetProfile returns the driver with their rolling offer rates.
+func (h *DriverHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+
+	profile, err := h.service.Profile(r.Context(), driver_id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(profile)
+}
+
+func (h *DriverHandler) CancelRide(w http.ResponseWriter, r *http.Request) {
+	driver_id := r.PathValue(path_value)
+	defer r.Body.Close()
+
+	var req models.CancelRideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CancelRide(r.Context(), driver_id, req.RideID, req.Reason); err != nil {
+		switch {
+		case errors.Is(err, services.ErrRideNotAssigned):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, services.ErrRideAlreadyStarted), errors.Is(err, models.ErrRideNotAvailable):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"ride_id":      req.RideID,
+		"status":       "CANCELLED",
+		"cancelled_at": time.Now().Format(time.RFC3339),
+		"message":      "Ride cancelled, it counts towards your cancellation rate",
+	})
+}
@@ -16,9 +16,33 @@ func RegisterRoutes(handler *DriverHandler, ws *ws.WSHandler) http.Handler {
 	mux.HandleFunc("POST /drivers/{driver_id}/online", middleware.WrapHandler(handler.ChangeDriverStatusToOnline))
 	mux.HandleFunc("/ws/drivers/{driver_id}", middleware.WrapHandler(ws.ServeWS))
 	mux.HandleFunc("POST /drivers/{driver_id}/offline", middleware.WrapHandler(handler.ChangeDriverStatusToOffline))
+	mux.HandleFunc("POST /drivers/{driver_id}/break/start", middleware.WrapHandler(handler.StartBreak))
+	mux.HandleFunc("POST /drivers/{driver_id}/break/end", middleware.WrapHandler(handler.EndBreak))
 	mux.HandleFunc("POST /drivers/{driver_id}/location", middleware.WrapHandler(handler.UpdateDriverLocation))
+	mux.HandleFunc("POST /drivers/{driver_id}/arrived", middleware.WrapHandler(handler.MarkArrived))
+	mux.HandleFunc("POST /drivers/{driver_id}/no-show", middleware.WrapHandler(handler.MarkNoShow))
+	mux.HandleFunc("POST /drivers/{driver_id}/cancel", middleware.WrapHandler(handler.CancelRide))
 	mux.HandleFunc("POST /drivers/{driver_id}/start", middleware.WrapHandler(handler.StartRide))
 	// mux.HandleFunc("POST /drivers/{driver_id}/complete", nil)
+	mux.HandleFunc("PUT /drivers/{driver_id}/preferences", middleware.WrapHandler(handler.UpdatePreferences))
+	mux.HandleFunc("PUT /drivers/{driver_id}/destination", middleware.WrapHandler(handler.SetDestination))
+
+	// Reads and multipart document uploads have no JSON body, so they skip the content type check
+	authOnly := middlewares.NewMiddlewareChain(authMiddleware)
+	mux.HandleFunc("POST /drivers/{driver_id}/documents", authOnly.WrapHandler(handler.UploadDocument))
+	mux.HandleFunc("GET /drivers/{driver_id}/documents", authOnly.WrapHandler(handler.ListDocuments))
+
+	// Profile with offer acceptance and cancellation rates
+	mux.HandleFunc("GET /drivers/{driver_id}/profile", authOnly.WrapHandler(handler.GetProfile))
+
+	// Session history and earnings
+	mux.HandleFunc("GET /drivers/{driver_id}/sessions", authOnly.WrapHandler(handler.ListSessions))
+	mux.HandleFunc("GET /drivers/{driver_id}/earnings", authOnly.WrapHandler(handler.GetEarnings))
+	mux.HandleFunc("GET /drivers/{driver_id}/preferences", authOnly.WrapHandler(handler.GetPreferences))
+
+	// Destination mode
+	mux.HandleFunc("GET /drivers/{driver_id}/destination", authOnly.WrapHandler(handler.GetDestination))
+	mux.HandleFunc("DELETE /drivers/{driver_id}/destination", authOnly.WrapHandler(handler.ClearDestination))
 
 	return mux
 }
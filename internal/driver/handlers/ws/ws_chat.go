@@ -0,0 +1,40 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"ride-hail/internal/shared/chat"
+)
+
+type chatCommand struct {
+	RideID string `json:"ride_id"`
+	Body   string `json:"body"`
+}
+
+// RegisterChatCommands lets drivers write to and read the chat of their ride,
+// and replays the chat history when a driver reconnects.
+func RegisterChatCommands(d *Dispatcher, svc *chat.Service) {
+	d.Handle("chat_message", func(ctx context.Context, driverID string, raw []byte) (any, error) {
+		var cmd chatCommand
+		if err := json.Unmarshal(raw, &cmd); err != nil {
+			return nil, err
+		}
+		return svc.Send(ctx, driverID, cmd.RideID, cmd.Body)
+	})
+
+	d.Handle("chat_read", func(ctx context.Context, driverID string, raw []byte) (any, error) {
+		var cmd chatCommand
+		if err := json.Unmarshal(raw, &cmd); err != nil {
+			return nil, err
+		}
+		return nil, svc.MarkRead(ctx, driverID, cmd.RideID)
+	})
+
+	d.OnConnect(func(ctx context.Context, driverID string) {
+		if err := svc.Replay(ctx, driverID); err != nil {
+			slog.Error("failed to replay chat", "driver_id", driverID, "error", err.Error())
+		}
+	})
+}
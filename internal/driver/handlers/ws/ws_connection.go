@@ -1,10 +1,14 @@
 package ws
 
 import (
+	"context"
+	"encoding/json"
 	"log"
 	"net/http"
 	"time"
 
+	"ride-hail/internal/shared/chat"
+
 	"github.com/gorilla/websocket"
 )
 
@@ -15,18 +19,36 @@ var upgrader = websocket.Upgrader{
 }
 
 type connection struct {
-	ws       *websocket.Conn
-	driverID string
-	send     chan []byte
-	hub      *Hub
+	ws         *websocket.Conn
+	driverID   string
+	send       chan []byte
+	hub        *Hub
+	dispatcher *Dispatcher
+}
+
+// sendJSON queues a reply for the writer. Replies to a client that stopped reading are dropped.
+func (c *connection) sendJSON(v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	if c.hub != nil {
+		c.hub.SendToConnection(c, b)
+	}
 }
 
-func (c *connection) readPump() {
+func (c *connection) readPump(ctx context.Context) {
 	defer func() {
+		// the hub closes send, so the writer exits as well
+		if c.hub != nil {
+			c.hub.Unregister(c)
+		}
 		c.ws.Close()
-		close(c.send)
+		if c.dispatcher != nil {
+			c.dispatcher.disconnected(ctx, c.driverID)
+		}
 	}()
-	c.ws.SetReadLimit(512)
+	c.ws.SetReadLimit(chat.MaxFrameSize)
 	c.ws.SetReadDeadline(time.Now().Add(60 * time.Second))
 	c.ws.SetPongHandler(func(string) error {
 		c.ws.SetReadDeadline(time.Now().Add(60 * time.Second))
@@ -40,8 +62,9 @@ func (c *connection) readPump() {
 			}
 			break
 		}
-		// Echo back; replace with application logic or send to hub
-		c.send <- msg
+		if c.dispatcher != nil {
+			c.dispatcher.dispatch(ctx, c, msg)
+		}
 	}
 }
 
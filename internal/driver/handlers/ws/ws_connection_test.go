@@ -0,0 +1,72 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ride-hail/internal/shared/chat"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestConnection_ReadsLongestChatMessage(t *testing.T) {
+	hub := NewHub()
+	hub.Start()
+	defer hub.Stop()
+
+	received := make(chan string, 1)
+	dispatcher := NewDispatcher()
+	dispatcher.Handle("chat_message", func(ctx context.Context, driverID string, raw []byte) (any, error) {
+		var cmd chatCommand
+		if err := json.Unmarshal(raw, &cmd); err != nil {
+			return nil, err
+		}
+		received <- cmd.Body
+		return nil, nil
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws/drivers/{driver_id}", NewWSHandler(hub, dispatcher).ServeWS)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws/drivers/driver-1", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	// 4-byte characters are the worst case for the frame size
+	body := strings.Repeat("😀", chat.MaxBodyLength)
+	frame, _ := json.Marshal(map[string]string{
+		"type":       "chat_message",
+		"request_id": "req-1",
+		"ride_id":    "550e8400-e29b-41d4-a716-446655440000",
+		"body":       body,
+	})
+	if err := conn.WriteMessage(websocket.TextMessage, frame); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got != body {
+			t.Fatalf("expected the whole message to arrive, got %d bytes", len(got))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("message was not read")
+	}
+
+	var reply commandReply
+	if err := conn.ReadJSON(&reply); err != nil {
+		t.Fatalf("read reply: %v", err)
+	}
+	if reply.Type != "command_ack" {
+		t.Fatalf("expected command_ack, got %s: %s", reply.Type, reply.Message)
+	}
+}
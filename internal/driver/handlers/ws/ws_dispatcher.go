@@ -0,0 +1,99 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CommandFunc handles a typed message sent by a driver and returns the data for the ack.
+type CommandFunc func(ctx context.Context, driverID string, raw []byte) (any, error)
+
+// Dispatcher routes driver messages by their type field and answers each one
+// with command_ack or command_error carrying the request ID.
+type Dispatcher struct {
+	commands     map[string]CommandFunc
+	onConnect    []func(ctx context.Context, driverID string)
+	onDisconnect []func(ctx context.Context, driverID string)
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{commands: make(map[string]CommandFunc)}
+}
+
+// Handle registers the handler of a message type.
+func (d *Dispatcher) Handle(msgType string, fn CommandFunc) {
+	d.commands[msgType] = fn
+}
+
+// OnConnect registers a hook run after a driver connects.
+func (d *Dispatcher) OnConnect(fn func(ctx context.Context, driverID string)) {
+	d.onConnect = append(d.onConnect, fn)
+}
+
+// OnDisconnect registers a hook run after a driver's connection is closed.
+func (d *Dispatcher) OnDisconnect(fn func(ctx context.Context, driverID string)) {
+	d.onDisconnect = append(d.onDisconnect, fn)
+}
+
+type commandReply struct {
+	Type      string `json:"type"`
+	RequestID string `json:"request_id"`
+	Command   string `json:"command"`
+	Data      any    `json:"data,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+func (d *Dispatcher) connected(ctx context.Context, driverID string) {
+	for _, fn := range d.onConnect {
+		fn(ctx, driverID)
+	}
+}
+
+func (d *Dispatcher) disconnected(ctx context.Context, driverID string) {
+	for _, fn := range d.onDisconnect {
+		fn(ctx, driverID)
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, c *connection, raw []byte) {
+	var header struct {
+		Type      string `json:"type"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		c.sendJSON(map[string]any{"type": "error", "message": "Invalid JSON"})
+		return
+	}
+	if header.Type == "ping" {
+		c.sendJSON(map[string]any{"type": "pong"})
+		return
+	}
+	if header.RequestID == "" {
+		header.RequestID = fmt.Sprintf("req-%d", time.Now().UnixNano())
+	}
+
+	reply := commandReply{
+		Type:      "command_ack",
+		RequestID: header.RequestID,
+		Command:   header.Type,
+	}
+
+	fn, ok := d.commands[header.Type]
+	if !ok {
+		reply.Type = "command_error"
+		reply.Message = fmt.Sprintf("unknown command %q", header.Type)
+		c.sendJSON(reply)
+		return
+	}
+
+	data, err := fn(ctx, c.driverID, raw)
+	if err != nil {
+		reply.Type = "command_error"
+		reply.Message = err.Error()
+	} else {
+		reply.Data = data
+	}
+	c.sendJSON(reply)
+}
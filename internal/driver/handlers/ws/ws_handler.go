@@ -1,16 +1,18 @@
 package ws
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 )
 
 type WSHandler struct {
-	hub *Hub
+	hub        *Hub
+	dispatcher *Dispatcher
 }
 
-func NewWSHandler(h *Hub) *WSHandler {
-	return &WSHandler{hub: h}
+func NewWSHandler(h *Hub, dispatcher *Dispatcher) *WSHandler {
+	return &WSHandler{hub: h, dispatcher: dispatcher}
 }
 
 func (h *WSHandler) ServeWS(w http.ResponseWriter, r *http.Request) {
@@ -21,15 +23,22 @@ func (h *WSHandler) ServeWS(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	c := &connection{
-		ws:       ws,
-		send:     make(chan []byte, 256),
-		hub:      h.hub,
-		driverID: driverID,
+		ws:         ws,
+		send:       make(chan []byte, 256),
+		hub:        h.hub,
+		driverID:   driverID,
+		dispatcher: h.dispatcher,
 	}
 	// register connection with hub so it will receive broadcasts
 	if h.hub != nil {
 		h.hub.Register(c)
 	}
+	// the connection outlives the request
+	ctx := context.WithoutCancel(r.Context())
+
 	go c.writePump()
-	go c.readPump()
+	go c.readPump(ctx)
+	if h.dispatcher != nil {
+		go h.dispatcher.connected(ctx, driverID)
+	}
 }
@@ -3,15 +3,18 @@ package ws
 import (
 	"encoding/json"
 	"log"
+	"sync"
 )
 
 type driverMessage struct {
 	driverID string
+	conn     *connection // set for replies to one connection
 	msg      []byte
 }
 
 // ...existing code...
 type Hub struct {
+	mu           sync.RWMutex // guards clients for readers outside Run
 	clients      map[*connection]bool
 	register     chan *connection
 	unregister   chan *connection
@@ -37,12 +40,14 @@ func (h *Hub) Run() {
 	for {
 		select {
 		case c := <-h.register:
+			h.mu.Lock()
 			h.clients[c] = true
+			h.mu.Unlock()
 			log.Printf("ws: registered connection (total=%d)", len(h.clients))
 
 		case c := <-h.unregister:
 			if _, ok := h.clients[c]; ok {
-				delete(h.clients, c)
+				h.drop(c)
 				// close send to signal writer goroutine to exit
 				close(c.send)
 				log.Printf("ws: unregistered connection (total=%d)", len(h.clients))
@@ -56,21 +61,21 @@ func (h *Hub) Run() {
 				default:
 					// client is not reading; drop client
 					close(c.send)
-					delete(h.clients, c)
+					h.drop(c)
 					log.Printf("ws: dropped slow client (total=%d)", len(h.clients))
 				}
 			}
 
 		case dm := <-h.sendToDriver:
 			for c := range h.clients {
-				if c.driverID == dm.driverID {
+				if (dm.conn == nil && c.driverID == dm.driverID) || c == dm.conn {
 					select {
 					case c.send <- dm.msg:
 						// sent
 					default:
 						// slow client -> drop
 						close(c.send)
-						delete(h.clients, c)
+						h.drop(c)
 					}
 				}
 			}
@@ -78,13 +83,31 @@ func (h *Hub) Run() {
 			// cleanup
 			for c := range h.clients {
 				close(c.send)
-				delete(h.clients, c)
+				h.drop(c)
 			}
 			return
 		}
 	}
 }
 
+func (h *Hub) drop(c *connection) {
+	h.mu.Lock()
+	delete(h.clients, c)
+	h.mu.Unlock()
+}
+
+// IsConnected reports whether the driver has an open WebSocket connection.
+func (h *Hub) IsConnected(driverID string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.clients {
+		if c.driverID == driverID {
+			return true
+		}
+	}
+	return false
+}
+
 // Start runs the hub loop in a new goroutine.
 func (h *Hub) Start() {
 	go h.Run()
@@ -126,6 +149,15 @@ func (h *Hub) SendToDriver(driverID string, b []byte) {
 	}
 }
 
+// SendToConnection sends a message to a single connection, e.g. a reply to its command.
+func (h *Hub) SendToConnection(c *connection, b []byte) {
+	select {
+	case h.sendToDriver <- driverMessage{conn: c, msg: b}:
+	default:
+		// drop if channel full
+	}
+}
+
 // SendToDriverJSON marshals v and sends to the specified driver.
 func (h *Hub) SendToDriverJSON(driverID string, v interface{}) error {
 	b, err := json.Marshal(v)
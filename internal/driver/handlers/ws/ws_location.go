@@ -0,0 +1,35 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"ride-hail/internal/driver/domain/models"
+)
+
+// LocationReporter takes the positions drivers stream while online.
+type LocationReporter interface {
+	ReportLocation(ctx context.Context, driverID string, update *models.LocationUpdate) (bool, error)
+}
+
+// RegisterLocationCommands lets drivers send their position over the WebSocket.
+// Updates sent too often are acknowledged as skipped.
+func RegisterLocationCommands(d *Dispatcher, svc LocationReporter) {
+	d.Handle("location_update", func(ctx context.Context, driverID string, raw []byte) (any, error) {
+		var update models.LocationUpdate
+		if err := json.Unmarshal(raw, &update); err != nil {
+			return nil, err
+		}
+
+		accepted, err := svc.ReportLocation(ctx, driverID, &update)
+		if err != nil {
+			return nil, err
+		}
+
+		status := "accepted"
+		if !accepted {
+			status = "skipped"
+		}
+		return map[string]string{"status": status}, nil
+	})
+}
@@ -18,5 +18,23 @@ func (w *WSNotifier) Notify(event interface{}) error {
 	return w.hub.BroadcastJSON(event)
 }
 
+// NotifyDriver implements ports.Notifier by sending JSON to a single driver.
+func (w *WSNotifier) NotifyDriver(driverID string, event interface{}) error {
+	if w.hub == nil {
+		return nil
+	}
+	return w.hub.SendToDriverJSON(driverID, event)
+}
+
+// IsConnected reports whether the driver is connected to this service.
+func (w *WSNotifier) IsConnected(driverID string) bool {
+	return w.hub != nil && w.hub.IsConnected(driverID)
+}
+
+// Send sends JSON to a single driver.
+func (w *WSNotifier) Send(driverID string, event any) error {
+	return w.NotifyDriver(driverID, event)
+}
+
 // Ensure WSNotifier implements ports.Notifier
 var _ ports.Notifier = (*WSNotifier)(nil)
@@ -0,0 +1,42 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"ride-hail/internal/driver/domain/models"
+)
+
+// OfferResponder records a driver's answer to a ride offer.
+type OfferResponder interface {
+	RespondToOffer(ctx context.Context, driverID, rideID string, accepted bool, lat, lon float64) error
+}
+
+type rideResponse struct {
+	OfferID         string          `json:"offer_id"`
+	RideID          string          `json:"ride_id"`
+	Accepted        bool            `json:"accepted"`
+	CurrentLocation models.Location `json:"current_location"`
+}
+
+// RegisterOfferCommands lets drivers accept or decline the rides offered to them.
+func RegisterOfferCommands(d *Dispatcher, svc OfferResponder) {
+	d.Handle("ride_response", func(ctx context.Context, driverID string, raw []byte) (any, error) {
+		var cmd rideResponse
+		if err := json.Unmarshal(raw, &cmd); err != nil {
+			return nil, err
+		}
+
+		err := svc.RespondToOffer(ctx, driverID, cmd.RideID, cmd.Accepted,
+			cmd.CurrentLocation.Latitude, cmd.CurrentLocation.Longitude)
+		if err != nil {
+			return nil, err
+		}
+
+		status := "DECLINED"
+		if cmd.Accepted {
+			status = models.RideStatusMatched.String()
+		}
+		return map[string]string{"ride_id": cmd.RideID, "status": status}, nil
+	})
+}
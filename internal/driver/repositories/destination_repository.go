@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/postgres"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type DestinationRepository struct {
+	db *postgres.Database
+}
+
+func NewDestinationRepository(db *postgres.Database) ports.DestinationRepository {
+	return &DestinationRepository{
+		db: db,
+	}
+}
+
+const destinationColumns = `id, driver_id, latitude, longitude, COALESCE(address, ''),
+	created_at, expires_at, ended_at, COALESCE(end_reason, '')`
+
+// conn returns the transaction of the context, if any, or the database.
+func (r *DestinationRepository) conn(ctx context.Context) postgres.Querier {
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+// Create implements [ports.DestinationRepository].
+func (r *DestinationRepository) Create(ctx context.Context, d *models.Destination) error {
+	q := `INSERT INTO driver_destinations (driver_id, latitude, longitude, address, expires_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING id, created_at`
+	return r.conn(ctx).QueryRow(ctx, q, d.DriverID, d.Latitude, d.Longitude, d.Address, d.ExpiresAt).
+		Scan(&d.ID, &d.CreatedAt)
+}
+
+// Active implements [ports.DestinationRepository].
+func (r *DestinationRepository) Active(ctx context.Context, driverIDs []string) (map[string]models.Destination, error) {
+	q := `SELECT ` + destinationColumns + ` FROM driver_destinations
+		WHERE driver_id = ANY($1) AND ended_at IS NULL AND expires_at > now()`
+
+	dests, err := r.list(ctx, q, driverIDs)
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[string]models.Destination, len(dests))
+	for _, d := range dests {
+		active[d.DriverID] = d
+	}
+	return active, nil
+}
+
+// CountSince implements [ports.DestinationRepository].
+func (r *DestinationRepository) CountSince(ctx context.Context, driverID string, since time.Time) (int, error) {
+	q := `SELECT count(*) FROM driver_destinations WHERE driver_id = $1 AND created_at >= $2`
+
+	var n int
+	err := r.conn(ctx).QueryRow(ctx, q, driverID, since).Scan(&n)
+	return n, err
+}
+
+// End implements [ports.DestinationRepository].
+func (r *DestinationRepository) End(ctx context.Context, id, reason string) (bool, error) {
+	q := `UPDATE driver_destinations SET ended_at = now(), end_reason = $2
+		WHERE id = $1 AND ended_at IS NULL`
+
+	tag, err := r.conn(ctx).Exec(ctx, q, id, reason)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListExpired implements [ports.DestinationRepository].
+func (r *DestinationRepository) ListExpired(ctx context.Context, before time.Time) ([]models.Destination, error) {
+	q := `SELECT ` + destinationColumns + ` FROM driver_destinations
+		WHERE ended_at IS NULL AND expires_at <= $1
+		ORDER BY expires_at`
+	return r.list(ctx, q, before)
+}
+
+func (r *DestinationRepository) list(ctx context.Context, q string, args ...interface{}) ([]models.Destination, error) {
+	rows, err := r.conn(ctx).Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dests []models.Destination
+	for rows.Next() {
+		d, err := scanDestination(rows)
+		if err != nil {
+			return nil, err
+		}
+		dests = append(dests, d)
+	}
+	return dests, rows.Err()
+}
+
+func scanDestination(row pgx.Row) (models.Destination, error) {
+	var d models.Destination
+	err := row.Scan(
+		&d.ID,
+		&d.DriverID,
+		&d.Latitude,
+		&d.Longitude,
+		&d.Address,
+		&d.CreatedAt,
+		&d.ExpiresAt,
+		&d.EndedAt,
+		&d.EndReason,
+	)
+	return d, err
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/onboarding"
+	"ride-hail/internal/shared/postgres"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type DocumentRepository struct {
+	db *postgres.Database
+}
+
+func NewDocumentRepository(db *postgres.Database) ports.DocumentRepository {
+	return &DocumentRepository{
+		db: db,
+	}
+}
+
+const documentColumns = `id, driver_id, doc_type, file_name, content_type, size_bytes, storage_key,
+	expires_at, status, COALESCE(review_note, ''), reviewed_at, created_at`
+
+// Create implements [ports.DocumentRepository].
+func (r *DocumentRepository) Create(ctx context.Context, doc *onboarding.Document) error {
+	q := `INSERT INTO driver_documents
+			(driver_id, doc_type, file_name, content_type, size_bytes, storage_key, expires_at, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at`
+	doc.Status = onboarding.StatusPending
+	return r.db.QueryRow(ctx, q,
+		doc.DriverID, doc.Type, doc.FileName, doc.ContentType, doc.Size, doc.StorageKey, doc.ExpiresAt, doc.Status,
+	).Scan(&doc.ID, &doc.CreatedAt)
+}
+
+// ListByDriver implements [ports.DocumentRepository].
+func (r *DocumentRepository) ListByDriver(ctx context.Context, driverID string) ([]onboarding.Document, error) {
+	q := `SELECT ` + documentColumns + ` FROM driver_documents
+		WHERE driver_id = $1
+		ORDER BY created_at DESC`
+	return r.list(ctx, q, driverID)
+}
+
+// ListExpiring implements [ports.DocumentRepository].
+func (r *DocumentRepository) ListExpiring(ctx context.Context, before time.Time) ([]onboarding.Document, error) {
+	q := `SELECT ` + documentColumns + ` FROM driver_documents d
+		WHERE d.status = 'APPROVED'
+			AND d.expiry_notified_at IS NULL
+			AND d.expires_at <= $1
+			AND NOT EXISTS (
+				SELECT 1 FROM driver_documents n
+				WHERE n.driver_id = d.driver_id
+					AND n.doc_type = d.doc_type
+					AND n.status = 'APPROVED'
+					AND n.expires_at > $1
+			)
+		ORDER BY d.expires_at`
+	return r.list(ctx, q, before)
+}
+
+// MarkExpiryNotified implements [ports.DocumentRepository].
+func (r *DocumentRepository) MarkExpiryNotified(ctx context.Context, id string) error {
+	q := `UPDATE driver_documents SET expiry_notified_at = now() WHERE id = $1`
+	_, err := r.db.Exec(ctx, q, id)
+	return err
+}
+
+func (r *DocumentRepository) list(ctx context.Context, q string, args ...interface{}) ([]onboarding.Document, error) {
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var docs []onboarding.Document
+	for rows.Next() {
+		doc, err := scanDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, rows.Err()
+}
+
+func scanDocument(row pgx.Row) (onboarding.Document, error) {
+	var doc onboarding.Document
+	err := row.Scan(
+		&doc.ID,
+		&doc.DriverID,
+		&doc.Type,
+		&doc.FileName,
+		&doc.ContentType,
+		&doc.Size,
+		&doc.StorageKey,
+		&doc.ExpiresAt,
+		&doc.Status,
+		&doc.ReviewNote,
+		&doc.ReviewedAt,
+		&doc.CreatedAt,
+	)
+	return doc, err
+}
@@ -3,10 +3,19 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
 
 	"ride-hail/internal/driver/domain/models"
 	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/money"
 	"ride-hail/internal/shared/postgres"
+	"ride-hail/internal/shared/pricing"
+	"ride-hail/internal/shared/promo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type DriverRepository struct {
@@ -22,7 +31,7 @@ func NewDriverRepository(db *postgres.Database) ports.DriverRepository {
 // GetById implements [ports.DriverRepository].
 func (d *DriverRepository) GetById(ctx context.Context, id string) (*models.Driver, error) {
 	q := `SELECT 
-            id, license_number, COALESCE(vehicle_type, 'ECONOMY'), vehicle_attrs, rating, total_rides, total_earnings, COALESCE(status, 'OFFLINE')
+            id, license_number, COALESCE(vehicle_type, 'ECONOMY'), vehicle_attrs, rating, total_rides, total_earnings, COALESCE(status, 'OFFLINE'), is_stale, COALESCE(is_verified, false), cooldown_until
         FROM 
             drivers 
         WHERE 
@@ -40,6 +49,9 @@ func (d *DriverRepository) GetById(ctx context.Context, id string) (*models.Driv
 		&driver.TotalRides,
 		&driver.TotalEarnings,
 		&statusStr,
+		&driver.IsStale,
+		&driver.IsVerifed,
+		&driver.CooldownUntil,
 	)
 	if err != nil {
 		return nil, err
@@ -100,14 +112,67 @@ func (d *DriverRepository) UpdateStatus(ctx context.Context, id string, status m
 }
 
 func (d *DriverRepository) updateStatusWithTx(ctx context.Context, tx *postgres.Tx, id string, status models.DriverStatus) error {
+	// Only moves the transition rules allow; the current status is checked in the same statement
 	q := `UPDATE 
             drivers 
         SET status = $1, updated_at = NOW() 
-        WHERE id = $2`
-	_, err := tx.Exec(ctx, q, status.String(), id)
+        WHERE id = $2 AND COALESCE(status, 'OFFLINE') = ANY($3)`
+
+	var from []string
+	for _, s := range status.AllowedFrom() {
+		from = append(from, s.String())
+	}
+
+	tag, err := tx.Exec(ctx, q, status.String(), id, from)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: driver %s cannot become %s", models.ErrStatusTransition, id, status)
+	}
+	return nil
+}
+
+// SetStale implements [ports.DriverRepository].
+func (d *DriverRepository) SetStale(ctx context.Context, id string, stale bool) error {
+	q := `UPDATE drivers SET is_stale = $1, updated_at = NOW() WHERE id = $2`
+
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		_, err := tx.Exec(ctx, q, stale, id)
+		return err
+	}
+	_, err := d.db.Exec(ctx, q, stale, id)
 	return err
 }
 
+// ListActivity implements [ports.DriverRepository].
+func (d *DriverRepository) ListActivity(ctx context.Context) ([]models.DriverActivity, error) {
+	q := `
+SELECT d.id, d.status, d.is_stale, COALESCE(c.created_at, d.updated_at)
+FROM drivers d
+LEFT JOIN coordinates c ON c.entity_id = d.id
+  AND c.entity_type = 'driver'
+  AND c.is_current = true
+WHERE d.status <> 'OFFLINE'
+`
+
+	rows, err := d.db.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var activity []models.DriverActivity
+	for rows.Next() {
+		var a models.DriverActivity
+		if err := rows.Scan(&a.DriverID, &a.Status, &a.IsStale, &a.LastLocationAt); err != nil {
+			return nil, err
+		}
+		activity = append(activity, a)
+	}
+	return activity, rows.Err()
+}
+
 // UpdateRideStatus implements [ports.DriverRepository].
 func (d *DriverRepository) UpdateRideStatus(ctx context.Context, rideID string, status models.RideStatus) error {
 	tx := postgres.GetTxFromContext(ctx)
@@ -120,35 +185,178 @@ func (d *DriverRepository) UpdateRideStatus(ctx context.Context, rideID string,
 	})
 }
 
+// SetRideFinalFare implements [ports.DriverRepository].
+func (d *DriverRepository) SetRideFinalFare(ctx context.Context, rideID string, fare pricing.Breakdown) error {
+	q := `UPDATE rides SET final_fare = $1, final_fare_breakdown = $2, updated_at = NOW() WHERE id = $3`
+
+	tx := postgres.GetTxFromContext(ctx)
+	if tx != nil {
+		_, err := tx.Exec(ctx, q, fare.Total, fare, rideID)
+		return err
+	}
+
+	_, err := d.db.Exec(ctx, q, fare.Total, fare, rideID)
+	return err
+}
+
+// ConsumePromoRedemption implements [ports.DriverRepository].
+func (d *DriverRepository) ConsumePromoRedemption(ctx context.Context, rideID string, discount money.Money) error {
+	q := `UPDATE promo_redemptions SET status = 'CONSUMED', discount = $2, updated_at = NOW()
+        WHERE ride_id = $1 AND status = 'RESERVED'`
+
+	tx := postgres.GetTxFromContext(ctx)
+	if tx != nil {
+		_, err := tx.Exec(ctx, q, rideID, discount)
+		return err
+	}
+
+	_, err := d.db.Exec(ctx, q, rideID, discount)
+	return err
+}
+
+// AssignRide implements [ports.DriverRepository].
+func (d *DriverRepository) AssignRide(ctx context.Context, rideID, driverID, pickupPIN string) error {
+	q := `UPDATE rides
+        SET driver_id = $2, pickup_pin = $3, status = 'MATCHED', matched_at = NOW(), updated_at = NOW()
+        WHERE id = $1 AND status = 'REQUESTED'`
+
+	var tag pgconn.CommandTag
+	var err error
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		tag, err = tx.Exec(ctx, q, rideID, driverID, pickupPIN)
+	} else {
+		tag, err = d.db.Exec(ctx, q, rideID, driverID, pickupPIN)
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrRideNotAvailable
+	}
+	return nil
+}
+
+// SetRideWaitingCharge implements [ports.DriverRepository].
+func (d *DriverRepository) SetRideWaitingCharge(ctx context.Context, rideID string, charge money.Money) error {
+	q := `UPDATE rides SET waiting_charge = $1, updated_at = NOW() WHERE id = $2`
+
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		_, err := tx.Exec(ctx, q, charge, rideID)
+		return err
+	}
+	_, err := d.db.Exec(ctx, q, charge, rideID)
+	return err
+}
+
+// CancelRide implements [ports.DriverRepository].
+func (d *DriverRepository) CancelRide(ctx context.Context, rideID, reason string, fee pricing.Breakdown) error {
+	q := `WITH cancelled AS (
+            UPDATE rides
+            SET status = 'CANCELLED', cancelled_at = NOW(), cancellation_reason = $2,
+                final_fare = $3, final_fare_breakdown = $4, updated_at = NOW()
+            WHERE id = $1 AND status NOT IN ('COMPLETED', 'CANCELLED')
+            RETURNING id
+        ), released AS (
+            UPDATE promo_redemptions SET status = 'RELEASED', updated_at = NOW()
+            WHERE ride_id IN (SELECT id FROM cancelled) AND status = 'RESERVED'
+        )
+        SELECT count(*) FROM cancelled`
+
+	var cancelled int
+	var err error
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		err = tx.QueryRow(ctx, q, rideID, reason, fee.Total, fee).Scan(&cancelled)
+	} else {
+		err = d.db.QueryRow(ctx, q, rideID, reason, fee.Total, fee).Scan(&cancelled)
+	}
+	if err != nil {
+		return err
+	}
+	if cancelled == 0 {
+		return models.ErrRideNotAvailable
+	}
+	return nil
+}
+
+// AddRideEvent implements [ports.DriverRepository].
+func (d *DriverRepository) AddRideEvent(ctx context.Context, rideID, eventType string, data map[string]any) error {
+	q := `INSERT INTO ride_events (ride_id, event_type, event_data) VALUES ($1, $2, $3)`
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		_, err = tx.Exec(ctx, q, rideID, eventType, payload)
+		return err
+	}
+	_, err = d.db.Exec(ctx, q, rideID, eventType, payload)
+	return err
+}
+
+// CountRideEvents implements [ports.DriverRepository].
+func (d *DriverRepository) CountRideEvents(ctx context.Context, rideID, eventType string, since time.Time) (int, error) {
+	q := `SELECT COUNT(*) FROM ride_events WHERE ride_id = $1 AND event_type = $2 AND created_at >= $3`
+
+	var count int
+	var err error
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		err = tx.QueryRow(ctx, q, rideID, eventType, since).Scan(&count)
+	} else {
+		err = d.db.QueryRow(ctx, q, rideID, eventType, since).Scan(&count)
+	}
+	return count, err
+}
+
+// LockRide implements [ports.DriverRepository].
+func (d *DriverRepository) LockRide(ctx context.Context, rideID string) error {
+	tx := postgres.GetTxFromContext(ctx)
+	if tx == nil {
+		return errors.New("locking a ride requires a transaction")
+	}
+
+	var id string
+	return tx.QueryRow(ctx, `SELECT id FROM rides WHERE id = $1 FOR UPDATE`, rideID).Scan(&id)
+}
+
 func (d *DriverRepository) FindAvailableDriversNearby(
 	ctx context.Context,
 	lat, lon float64,
-	vehicleType string,
+	offer models.Offer,
+	upgrades []string,
 	radiusMeters int,
 ) ([]models.DriverWithDistance, error) {
+	// Same rules as models.Preferences.Accepts; drivers without preferences take everything
 	q := `
 SELECT d.id, u.email, d.rating, c.latitude, c.longitude,
        ST_Distance(
          ST_MakePoint(c.longitude, c.latitude)::geography,
          ST_MakePoint($1, $2)::geography
-       ) / 1000 as distance_km
+       ) / 1000 as distance_km,
+       d.vehicle_type, d.acceptance_rate, d.cancellation_rate
 FROM drivers d
 JOIN users u ON d.id = u.id
 JOIN coordinates c ON c.entity_id = d.id
   AND c.entity_type = 'driver'
   AND c.is_current = true
+LEFT JOIN driver_preferences p ON p.driver_id = d.id
 WHERE d.status = 'AVAILABLE'
-  AND d.vehicle_type = $3
+  AND NOT d.is_stale
+  AND (d.cooldown_until IS NULL OR d.cooldown_until <= now())
+  AND (d.vehicle_type = $3 OR (d.vehicle_type = ANY($4) AND COALESCE(p.accept_lower_classes, false)))
   AND ST_DWithin(
         ST_MakePoint(c.longitude, c.latitude)::geography,
         ST_MakePoint($1, $2)::geography,
-        $4
+        LEAST($5, COALESCE(p.max_pickup_km * 1000, $5))
       )
-ORDER BY distance_km, d.rating DESC
+  AND ($6::float8 = 0 OR COALESCE(p.min_trip_km, 0) <= $6::float8)
+  AND CASE WHEN $7::text = 'CASH' THEN COALESCE(p.accept_cash, true) ELSE COALESCE(p.accept_card, true) END
+ORDER BY d.vehicle_type <> $3, distance_km, d.rating DESC
 LIMIT 10;
 `
 
-	rows, err := d.db.Query(ctx, q, lat, lon, vehicleType, radiusMeters)
+	rows, err := d.db.Query(ctx, q, lon, lat, offer.VehicleType, upgrades, float64(radiusMeters), offer.TripKm, offer.PaymentMethod)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +372,9 @@ LIMIT 10;
 			&dr.Latitude,
 			&dr.Longitude,
 			&dr.DistanceKm,
+			&dr.VehicleType,
+			&dr.AcceptanceRate,
+			&dr.CancellationRate,
 		); err != nil {
 			return nil, err
 		}
@@ -177,43 +388,63 @@ LIMIT 10;
 	return drivers, nil
 }
 
+// updateRideStatusWithTx moves the ride on only from the statuses allowed before
+// the new one, so a ride cancelled in the meantime is never started or completed.
 func (d *DriverRepository) updateRideStatusWithTx(ctx context.Context, tx *postgres.Tx, rideID string, status models.RideStatus) error {
-	var query string
-	var args []interface{}
-
+	column := ""
 	switch status {
 	case models.RideStatusMatched:
-		query = `UPDATE rides SET status = $1, matched_at = NOW(), updated_at = NOW() WHERE id = $2`
-		args = []interface{}{status.String(), rideID}
+		column = "matched_at = NOW(), "
 	case models.RideStatusInProgress:
-		query = `UPDATE rides SET status = $1, started_at = NOW(), updated_at = NOW() WHERE id = $2`
-		args = []interface{}{status.String(), rideID}
+		column = "started_at = NOW(), "
 	case models.RideStatusCompleted:
-		query = `UPDATE rides SET status = $1, completed_at = NOW(), updated_at = NOW() WHERE id = $2`
-		args = []interface{}{status.String(), rideID}
+		column = "completed_at = NOW(), "
 	case models.RideStatusArrived:
-		query = `UPDATE rides SET status = $1, arrived_at = NOW(), updated_at = NOW() WHERE id = $2`
-		args = []interface{}{status.String(), rideID}
-	default:
-		query = `UPDATE rides SET status = $1, updated_at = NOW() WHERE id = $2`
-		args = []interface{}{status.String(), rideID}
+		column = "arrived_at = NOW(), "
 	}
 
-	_, err := tx.Exec(ctx, query, args...)
-	return err
+	from := make([]string, 0, len(status.AllowedFrom()))
+	for _, st := range status.AllowedFrom() {
+		from = append(from, st.String())
+	}
+
+	query := `UPDATE rides SET status = $1, ` + column + `updated_at = NOW()
+		WHERE id = $2 AND status = ANY($3)`
+	tag, err := tx.Exec(ctx, query, status.String(), rideID, from)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: ride %s cannot become %s", models.ErrRideNotAvailable, rideID, status)
+	}
+	return nil
 }
 
 // GetRideByID implements [ports.DriverRepository].
 func (d *DriverRepository) GetRideByID(ctx context.Context, rideID string) (*models.Ride, error) {
 	q := `SELECT 
-            id, ride_number, passenger_id, driver_id, vehicle_type, status, 
-            estimated_fare, final_fare, created_at
-        FROM rides 
-        WHERE id = $1`
+            r.id, r.ride_number, r.passenger_id, r.driver_id, r.vehicle_type, r.status, 
+            r.estimated_fare, r.final_fare, r.zone_surcharge, COALESCE(r.city_id::text, ''),
+            COALESCE(c.currency, ''), COALESCE(r.pickup_pin, ''), r.waiting_charge,
+            r.estimated_fare_breakdown, r.arrived_at, r.started_at, r.created_at,
+            COALESCE(p.latitude, 0), COALESCE(p.longitude, 0), COALESCE(d.latitude, 0), COALESCE(d.longitude, 0),
+            r.is_pooled, COALESCE(r.pool_id::text, ''),
+            pc.id, COALESCE(pc.code, ''), COALESCE(pc.discount_type, ''), COALESCE(pc.percent_off, 0),
+            pc.amount_off, pc.max_discount
+        FROM rides r
+        LEFT JOIN cities c ON c.id = r.city_id
+        LEFT JOIN coordinates p ON p.id = r.pickup_coordinate_id
+        LEFT JOIN coordinates d ON d.id = r.destination_coordinate_id
+        LEFT JOIN promo_redemptions pr ON pr.ride_id = r.id AND pr.status = 'RESERVED'
+        LEFT JOIN promo_campaigns pc ON pc.id = pr.campaign_id
+        WHERE r.id = $1`
 
 	var ride models.Ride
-	var driverID, finalFare *string
+	var driverID *string
+	var finalFare *money.Money
 	var statusStr string
+	var promoID *string
+	var campaign promo.Campaign
 
 	err := d.db.QueryRow(ctx, q, rideID).Scan(
 		&ride.ID,
@@ -224,24 +455,87 @@ func (d *DriverRepository) GetRideByID(ctx context.Context, rideID string) (*mod
 		&statusStr,
 		&ride.EstimatedFare,
 		&finalFare,
+		&ride.ZoneSurcharge,
+		&ride.CityID,
+		&ride.Currency,
+		&ride.PickupPIN,
+		&ride.WaitingCharge,
+		&ride.FareBreakdown,
+		&ride.ArrivedAt,
+		&ride.StartedAt,
 		&ride.CreatedAt,
+		&ride.Pickup.Latitude,
+		&ride.Pickup.Longitude,
+		&ride.Destination.Latitude,
+		&ride.Destination.Longitude,
+		&ride.Pooled,
+		&ride.PoolID,
+		&promoID,
+		&campaign.Code,
+		&campaign.DiscountType,
+		&campaign.PercentOff,
+		&campaign.AmountOff,
+		&campaign.MaxDiscount,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if promoID != nil {
+		campaign.ID = *promoID
+		ride.Promo = &campaign
+	}
+
+	ride.EstimatedFare.Currency = ride.Currency
+	ride.ZoneSurcharge.Currency = ride.Currency
+	ride.WaitingCharge.Currency = ride.Currency
+
 	if driverID != nil {
 		ride.DriverID = *driverID
 	}
 
 	if finalFare != nil {
-		// Parse final fare
-		ride.FinalFare = ride.EstimatedFare // Use estimated if final not set
+		ride.FinalFare = finalFare.WithCurrency(ride.Currency)
 	} else {
-		ride.FinalFare = ride.EstimatedFare
+		ride.FinalFare = ride.EstimatedFare // Use estimated if final not set
 	}
 
 	ride.Status = models.RideStatus(statusStr)
 
 	return &ride, nil
 }
+
+// GetPreferences implements [ports.DriverRepository].
+func (d *DriverRepository) GetPreferences(ctx context.Context, driverID string) (models.Preferences, error) {
+	q := `SELECT COALESCE(max_pickup_km, 0), accept_lower_classes, min_trip_km, accept_cash, accept_card
+		FROM driver_preferences WHERE driver_id = $1`
+
+	prefs := models.DefaultPreferences
+	err := d.db.QueryRow(ctx, q, driverID).Scan(
+		&prefs.MaxPickupKm,
+		&prefs.AcceptLowerClasses,
+		&prefs.MinTripKm,
+		&prefs.AcceptCash,
+		&prefs.AcceptCard,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.DefaultPreferences, nil
+	}
+	return prefs, err
+}
+
+// SavePreferences implements [ports.DriverRepository].
+func (d *DriverRepository) SavePreferences(ctx context.Context, driverID string, prefs models.Preferences) error {
+	q := `INSERT INTO driver_preferences
+			(driver_id, max_pickup_km, accept_lower_classes, min_trip_km, accept_cash, accept_card)
+		VALUES ($1, NULLIF($2::float8, 0), $3, $4, $5, $6)
+		ON CONFLICT (driver_id) DO UPDATE SET
+			max_pickup_km = EXCLUDED.max_pickup_km,
+			accept_lower_classes = EXCLUDED.accept_lower_classes,
+			min_trip_km = EXCLUDED.min_trip_km,
+			accept_cash = EXCLUDED.accept_cash,
+			accept_card = EXCLUDED.accept_card,
+			updated_at = now()`
+	_, err := d.db.Exec(ctx, q, driverID, prefs.MaxPickupKm, prefs.AcceptLowerClasses, prefs.MinTripKm, prefs.AcceptCash, prefs.AcceptCard)
+	return err
+}
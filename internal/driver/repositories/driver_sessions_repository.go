@@ -2,9 +2,11 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"ride-hail/internal/driver/domain/models"
 	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/fatigue"
 	"ride-hail/internal/shared/postgres"
 )
 
@@ -131,3 +133,160 @@ func (h *DriverSessionsRepository) closeWithTx(ctx context.Context, tx *postgres
 	_, err := tx.Exec(ctx, q, driverSessionId)
 	return err
 }
+
+// StartBreak implements [ports.DriverSessionsRepository].
+func (h *DriverSessionsRepository) StartBreak(ctx context.Context, sessionID, driverID string) error {
+	q := `INSERT INTO driver_breaks (session_id, driver_id, started_at) VALUES ($1, $2, NOW())`
+
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		_, err := tx.Exec(ctx, q, sessionID, driverID)
+		return err
+	}
+	_, err := h.db.Exec(ctx, q, sessionID, driverID)
+	return err
+}
+
+// EndBreak implements [ports.DriverSessionsRepository].
+func (h *DriverSessionsRepository) EndBreak(ctx context.Context, sessionID string) error {
+	q := `UPDATE driver_breaks SET ended_at = NOW() WHERE session_id = $1 AND ended_at IS NULL`
+
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		_, err := tx.Exec(ctx, q, sessionID)
+		return err
+	}
+	_, err := h.db.Exec(ctx, q, sessionID)
+	return err
+}
+
+// BreakTime implements [ports.DriverSessionsRepository].
+func (h *DriverSessionsRepository) BreakTime(ctx context.Context, sessionID string) (time.Duration, error) {
+	q := `SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(ended_at, NOW()) - started_at)), 0)::float8
+		FROM driver_breaks WHERE session_id = $1`
+
+	var seconds float64
+	var err error
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		err = tx.QueryRow(ctx, q, sessionID).Scan(&seconds)
+	} else {
+		err = h.db.QueryRow(ctx, q, sessionID).Scan(&seconds)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// SessionsSince implements [ports.DriverSessionsRepository].
+func (h *DriverSessionsRepository) SessionsSince(ctx context.Context, driverID string, since time.Time) ([]fatigue.Interval, error) {
+	q := `SELECT started_at, ended_at FROM driver_sessions
+		WHERE driver_id = $1 AND (ended_at IS NULL OR ended_at > $2)
+		ORDER BY started_at`
+	return h.intervals(ctx, q, driverID, since)
+}
+
+// BreaksSince implements [ports.DriverSessionsRepository].
+func (h *DriverSessionsRepository) BreaksSince(ctx context.Context, driverID string, since time.Time) ([]fatigue.Interval, error) {
+	q := `SELECT started_at, ended_at FROM driver_breaks
+		WHERE driver_id = $1 AND (ended_at IS NULL OR ended_at > $2)
+		ORDER BY started_at`
+	return h.intervals(ctx, q, driverID, since)
+}
+
+func (h *DriverSessionsRepository) intervals(ctx context.Context, q string, args ...interface{}) ([]fatigue.Interval, error) {
+	rows, err := h.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []fatigue.Interval
+	for rows.Next() {
+		var in fatigue.Interval
+		var endedAt *time.Time
+		if err := rows.Scan(&in.Start, &endedAt); err != nil {
+			return nil, err
+		}
+		if endedAt != nil {
+			in.End = *endedAt
+		}
+		result = append(result, in)
+	}
+	return result, rows.Err()
+}
+
+// sessionHours are the hours online outside breaks and on breaks of the session s,
+// an open session or break counted up to now.
+const sessionHours = `
+	EXTRACT(EPOCH FROM COALESCE(s.ended_at, now()) - s.started_at)::float8 / 3600 - breaks.hours AS online_hours,
+	breaks.hours AS break_hours`
+
+const sessionBreaks = `
+	CROSS JOIN LATERAL (
+		SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(b.ended_at, now()) - b.started_at)), 0)::float8 / 3600 AS hours
+		FROM driver_breaks b WHERE b.session_id = s.id
+	) breaks`
+
+// History implements [ports.DriverSessionsRepository].
+func (h *DriverSessionsRepository) History(ctx context.Context, driverID string, limit, offset int) ([]models.SessionRecord, int, error) {
+	var total int
+	if err := h.db.QueryRow(ctx, `SELECT COUNT(*) FROM driver_sessions WHERE driver_id = $1`, driverID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	q := `SELECT s.id, s.started_at, s.ended_at, s.total_rides, s.total_earnings,` + sessionHours + `,
+			(SELECT COUNT(*) FROM rides r
+				WHERE r.driver_id = s.driver_id AND r.status = 'CANCELLED'
+					AND r.matched_at >= s.started_at AND r.matched_at < COALESCE(s.ended_at, now()))
+		FROM driver_sessions s` + sessionBreaks + `
+		WHERE s.driver_id = $1
+		ORDER BY s.started_at DESC
+		LIMIT $2 OFFSET $3`
+	rows, err := h.db.Query(ctx, q, driverID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	result := []models.SessionRecord{}
+	for rows.Next() {
+		var rec models.SessionRecord
+		if err := rows.Scan(
+			&rec.SessionID,
+			&rec.StartedAt,
+			&rec.EndedAt,
+			&rec.RidesCompleted,
+			&rec.Earnings,
+			&rec.OnlineHours,
+			&rec.BreakHours,
+			&rec.RidesCancelled,
+		); err != nil {
+			return nil, 0, err
+		}
+		result = append(result, rec)
+	}
+	return result, total, rows.Err()
+}
+
+// Report implements [ports.DriverSessionsRepository].
+func (h *DriverSessionsRepository) Report(ctx context.Context, driverID string, from, to time.Time) (*models.EarningsReport, error) {
+	report := &models.EarningsReport{From: from, To: to}
+
+	q := `SELECT COUNT(*), COALESCE(SUM(rides), 0), COALESCE(SUM(earnings), 0),
+			COALESCE(SUM(online_hours), 0), COALESCE(SUM(break_hours), 0)
+		FROM (
+			SELECT s.total_rides AS rides, s.total_earnings AS earnings,` + sessionHours + `
+			FROM driver_sessions s` + sessionBreaks + `
+			WHERE s.driver_id = $1 AND s.started_at >= $2 AND s.started_at < $3
+		) sessions`
+	if err := h.db.QueryRow(ctx, q, driverID, from, to).Scan(
+		&report.Sessions,
+		&report.RidesCompleted,
+		&report.Earnings,
+		&report.OnlineHours,
+		&report.BreakHours,
+	); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"ride-hail/internal/driver/domain/models"
 	"ride-hail/internal/driver/domain/ports"
@@ -21,37 +23,92 @@ func NewHistoryLocationRepository(db *postgres.Database) ports.HistoryLocationRe
 // AddLocation implements [ports.HistoryLocationRepository].
 func (h *HistoryLocationRepository) AddLocation(ctx context.Context, historyLocation *models.LocationHistory) error {
 	q := `INSERT INTO location_history 
-			(driver_id, latitude, longitude, accuracy_meters, speed_kmh, heading_degrees, recorded_at, ride_id, coordinate_id) 
+			(driver_id, latitude, longitude, accuracy_meters, speed_kmh, heading_degrees, recorded_at, ride_id, coordinate_id,
+			 raw_latitude, raw_longitude, gps_verdict, gps_reason) 
 		VALUES 
-			($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
 
 	// Check if we have a transaction in context
 	tx := postgres.GetTxFromContext(ctx)
 	if tx != nil {
-		_, err := tx.Exec(ctx, q,
-			historyLocation.DriverID,
-			historyLocation.Latitude,
-			historyLocation.Longitude,
-			historyLocation.AccuracyMeters,
-			historyLocation.SpeedKmh,
-			historyLocation.HeadingDegrees,
-			historyLocation.RecordedAt,
-			historyLocation.RideID,
-			historyLocation.CoordinateID,
-		)
+		_, err := tx.Exec(ctx, q, historyArgs(historyLocation)...)
 		return err
 	}
 
-	_, err := h.db.Exec(ctx, q,
-		historyLocation.DriverID,
-		historyLocation.Latitude,
-		historyLocation.Longitude,
-		historyLocation.AccuracyMeters,
-		historyLocation.SpeedKmh,
-		historyLocation.HeadingDegrees,
-		historyLocation.RecordedAt,
-		historyLocation.RideID,
-		historyLocation.CoordinateID,
-	)
+	_, err := h.db.Exec(ctx, q, historyArgs(historyLocation)...)
 	return err
 }
+
+// AddLocations implements [ports.HistoryLocationRepository]. All rows are written
+// with a single statement.
+func (h *HistoryLocationRepository) AddLocations(ctx context.Context, locations []*models.LocationHistory) error {
+	if len(locations) == 0 {
+		return nil
+	}
+
+	var q strings.Builder
+	q.WriteString(`INSERT INTO location_history 
+			(driver_id, latitude, longitude, accuracy_meters, speed_kmh, heading_degrees, recorded_at, ride_id, coordinate_id,
+			 raw_latitude, raw_longitude, gps_verdict, gps_reason) 
+		VALUES `)
+
+	var args []interface{}
+	for i, loc := range locations {
+		if i > 0 {
+			q.WriteString(", ")
+		}
+		row := historyArgs(loc)
+		q.WriteString("(")
+		for j := range row {
+			if j > 0 {
+				q.WriteString(", ")
+			}
+			fmt.Fprintf(&q, "$%d", len(args)+j+1)
+		}
+		q.WriteString(")")
+		args = append(args, row...)
+	}
+
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		_, err := tx.Exec(ctx, q.String(), args...)
+		return err
+	}
+	_, err := h.db.Exec(ctx, q.String(), args...)
+	return err
+}
+
+// historyArgs returns the values of a location_history row in column order. Points
+// rejected by the GPS filter have no coordinate; rows written without the filter,
+// such as the position a ride starts at, are stored as reported.
+func historyArgs(loc *models.LocationHistory) []interface{} {
+	verdict := loc.GPSVerdict
+	if verdict == "" {
+		verdict = "ACCEPTED"
+	}
+	rawLat, rawLon := loc.RawLatitude, loc.RawLongitude
+	if rawLat == 0 && rawLon == 0 {
+		rawLat, rawLon = loc.Latitude, loc.Longitude
+	}
+	return []interface{}{
+		loc.DriverID,
+		loc.Latitude,
+		loc.Longitude,
+		loc.AccuracyMeters,
+		loc.SpeedKmh,
+		loc.HeadingDegrees,
+		loc.RecordedAt,
+		loc.RideID,
+		nullIfEmpty(loc.CoordinateID),
+		rawLat,
+		rawLon,
+		verdict,
+		nullIfEmpty(loc.GPSReason),
+	}
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
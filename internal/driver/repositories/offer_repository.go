@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/postgres"
+	"ride-hail/internal/shared/reliability"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type OfferRepository struct {
+	db *postgres.Database
+}
+
+func NewOfferRepository(db *postgres.Database) ports.OfferRepository {
+	return &OfferRepository{
+		db: db,
+	}
+}
+
+// conn returns the transaction of the context, if any, or the database.
+func (r *OfferRepository) conn(ctx context.Context) postgres.Querier {
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+// Create implements [ports.OfferRepository].
+func (r *OfferRepository) Create(ctx context.Context, offer *models.RideOffer) error {
+	q := `INSERT INTO driver_offers (driver_id, ride_id, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING id, offered_at`
+	offer.Outcome = reliability.OutcomePending
+	return r.conn(ctx).QueryRow(ctx, q, offer.DriverID, offer.RideID, offer.ExpiresAt).Scan(&offer.ID, &offer.OfferedAt)
+}
+
+// Decide implements [ports.OfferRepository].
+func (r *OfferRepository) Decide(ctx context.Context, driverID, rideID, outcome string) (bool, error) {
+	from := []string{reliability.OutcomePending}
+	switch outcome {
+	case reliability.OutcomeAccepted:
+		from = append(from, reliability.OutcomeExpired)
+	case reliability.OutcomeCancelled:
+		from = []string{reliability.OutcomeAccepted}
+	}
+
+	// Only the latest offer of the ride to the driver is decided
+	q := `UPDATE driver_offers SET outcome = $3, decided_at = now()
+		WHERE id = (
+			SELECT id FROM driver_offers
+			WHERE driver_id = $1 AND ride_id = $2
+			ORDER BY offered_at DESC
+			LIMIT 1
+		) AND outcome = ANY($4)`
+	tag, err := r.conn(ctx).Exec(ctx, q, driverID, rideID, outcome, from)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// IsOpen implements [ports.OfferRepository].
+func (r *OfferRepository) IsOpen(ctx context.Context, driverID, rideID string, now time.Time) (bool, error) {
+	q := `SELECT id FROM driver_offers
+		WHERE driver_id = $1 AND ride_id = $2 AND outcome = 'PENDING' AND expires_at > $3
+		ORDER BY offered_at DESC
+		LIMIT 1
+		FOR UPDATE`
+
+	var id string
+	err := r.conn(ctx).QueryRow(ctx, q, driverID, rideID, now).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ListExpired implements [ports.OfferRepository].
+func (r *OfferRepository) ListExpired(ctx context.Context, before time.Time) ([]models.RideOffer, error) {
+	q := `SELECT id, driver_id, ride_id, offered_at, expires_at, outcome
+		FROM driver_offers
+		WHERE outcome = 'PENDING' AND expires_at <= $1
+		ORDER BY expires_at`
+	rows, err := r.conn(ctx).Query(ctx, q, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var offers []models.RideOffer
+	for rows.Next() {
+		var o models.RideOffer
+		if err := rows.Scan(&o.ID, &o.DriverID, &o.RideID, &o.OfferedAt, &o.ExpiresAt, &o.Outcome); err != nil {
+			return nil, err
+		}
+		offers = append(offers, o)
+	}
+	return offers, rows.Err()
+}
+
+// Recent implements [ports.OfferRepository].
+func (r *OfferRepository) Recent(ctx context.Context, driverID string, window int) (reliability.Counts, error) {
+	q := `SELECT outcome FROM driver_offers
+		WHERE driver_id = $1 AND outcome <> 'PENDING'
+		ORDER BY offered_at DESC
+		LIMIT $2`
+	rows, err := r.conn(ctx).Query(ctx, q, driverID, window)
+	if err != nil {
+		return reliability.Counts{}, err
+	}
+	defer rows.Close()
+
+	var counts reliability.Counts
+	for rows.Next() {
+		var outcome string
+		if err := rows.Scan(&outcome); err != nil {
+			return reliability.Counts{}, err
+		}
+		counts.Add(outcome)
+	}
+	return counts, rows.Err()
+}
+
+// Between implements [ports.OfferRepository].
+func (r *OfferRepository) Between(ctx context.Context, driverID string, from, to time.Time) (reliability.Counts, error) {
+	q := `SELECT outcome FROM driver_offers
+		WHERE driver_id = $1 AND outcome <> 'PENDING' AND offered_at >= $2 AND offered_at < $3`
+	rows, err := r.conn(ctx).Query(ctx, q, driverID, from, to)
+	if err != nil {
+		return reliability.Counts{}, err
+	}
+	defer rows.Close()
+
+	var counts reliability.Counts
+	for rows.Next() {
+		var outcome string
+		if err := rows.Scan(&outcome); err != nil {
+			return reliability.Counts{}, err
+		}
+		counts.Add(outcome)
+	}
+	return counts, rows.Err()
+}
+
+// GetStanding implements [ports.OfferRepository].
+func (r *OfferRepository) GetStanding(ctx context.Context, driverID string) (models.Reliability, error) {
+	q := `SELECT acceptance_rate, cancellation_rate, reliability_level, COALESCE(reliability_reason, ''), cooldown_until
+		FROM drivers WHERE id = $1`
+
+	var rel models.Reliability
+	err := r.conn(ctx).QueryRow(ctx, q, driverID).Scan(
+		&rel.AcceptanceRate,
+		&rel.CancellationRate,
+		&rel.Level,
+		&rel.Reason,
+		&rel.CooldownUntil,
+	)
+	return rel, err
+}
+
+// SaveStanding implements [ports.OfferRepository].
+func (r *OfferRepository) SaveStanding(ctx context.Context, driverID string, stats reliability.Stats, cooldownUntil *time.Time) error {
+	q := `UPDATE drivers SET
+			acceptance_rate = $2,
+			cancellation_rate = $3,
+			reliability_level = $4,
+			reliability_reason = NULLIF($5, ''),
+			cooldown_until = COALESCE($6, cooldown_until),
+			updated_at = now()
+		WHERE id = $1`
+	_, err := r.conn(ctx).Exec(ctx, q, driverID, stats.AcceptanceRate, stats.CancellationRate, stats.Level, stats.Reason, cooldownUntil)
+	return err
+}
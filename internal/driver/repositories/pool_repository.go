@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/pool"
+	"ride-hail/internal/shared/postgres"
+)
+
+type PoolRepository struct {
+	db *postgres.Database
+}
+
+func NewPoolRepository(db *postgres.Database) ports.PoolRepository {
+	return &PoolRepository{
+		db: db,
+	}
+}
+
+// conn returns the transaction of the context, if any, or the database.
+func (p *PoolRepository) conn(ctx context.Context) postgres.Querier {
+	if tx := postgres.GetTxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return p.db
+}
+
+// GetActive implements [ports.PoolRepository].
+func (p *PoolRepository) GetActive(ctx context.Context, driverID string) (*models.Pool, error) {
+	q := `SELECT id, driver_id, plan FROM ride_pools WHERE driver_id = $1 AND status = 'ACTIVE'`
+
+	var result models.Pool
+	err := p.conn(ctx).QueryRow(ctx, q, driverID).Scan(&result.ID, &result.DriverID, &result.Plan)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPoolNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Create implements [ports.PoolRepository].
+func (p *PoolRepository) Create(ctx context.Context, driverID, rideID string, plan pool.Plan) (string, error) {
+	var poolID string
+	err := p.conn(ctx).QueryRow(ctx,
+		`INSERT INTO ride_pools (driver_id, plan) VALUES ($1, $2) RETURNING id`,
+		driverID,
+		plan,
+	).Scan(&poolID)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = p.conn(ctx).Exec(ctx, `UPDATE rides SET pool_id = $1, updated_at = NOW() WHERE id = $2`, poolID, rideID)
+	return poolID, err
+}
+
+// AddRide implements [ports.PoolRepository].
+func (p *PoolRepository) AddRide(ctx context.Context, poolID, rideID string, plan pool.Plan) error {
+	if _, err := p.conn(ctx).Exec(ctx, `UPDATE rides SET pool_id = $1, updated_at = NOW() WHERE id = $2`, poolID, rideID); err != nil {
+		return err
+	}
+	return p.UpdatePlan(ctx, poolID, plan)
+}
+
+// UpdatePlan implements [ports.PoolRepository].
+func (p *PoolRepository) UpdatePlan(ctx context.Context, poolID string, plan pool.Plan) error {
+	q := `UPDATE ride_pools
+        SET plan = $2,
+            status = CASE WHEN jsonb_array_length($2::jsonb) = 0 THEN 'COMPLETED' ELSE status END,
+            updated_at = NOW()
+        WHERE id = $1`
+
+	_, err := p.conn(ctx).Exec(ctx, q, poolID, plan)
+	return err
+}
+
+// FindNearby implements [ports.PoolRepository].
+func (p *PoolRepository) FindNearby(ctx context.Context, lat, lon float64, vehicleType string, radiusMeters int) ([]models.Pool, error) {
+	q := `
+SELECT rp.id, rp.driver_id, rp.plan, c.latitude, c.longitude
+FROM ride_pools rp
+JOIN drivers d ON d.id = rp.driver_id
+JOIN coordinates c ON c.entity_id = d.id
+  AND c.entity_type = 'driver'
+  AND c.is_current = true
+WHERE rp.status = 'ACTIVE'
+  AND d.status = 'BUSY'
+  AND NOT d.is_stale
+  AND (d.cooldown_until IS NULL OR d.cooldown_until <= now())
+  AND d.vehicle_type = $3
+  AND ST_DWithin(
+        ST_MakePoint(c.longitude, c.latitude)::geography,
+        ST_MakePoint($1, $2)::geography,
+        $4
+      )
+ORDER BY ST_Distance(
+           ST_MakePoint(c.longitude, c.latitude)::geography,
+           ST_MakePoint($1, $2)::geography
+         )
+LIMIT 10;
+`
+
+	rows, err := p.conn(ctx).Query(ctx, q, lon, lat, vehicleType, radiusMeters)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pools []models.Pool
+	for rows.Next() {
+		var result models.Pool
+		if err := rows.Scan(
+			&result.ID,
+			&result.DriverID,
+			&result.Plan,
+			&result.DriverLocation.Latitude,
+			&result.DriverLocation.Longitude,
+		); err != nil {
+			return nil, err
+		}
+		pools = append(pools, result)
+	}
+
+	return pools, rows.Err()
+}
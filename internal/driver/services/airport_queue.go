@@ -0,0 +1,129 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+type queueEntry struct {
+	driverID     string
+	joinedAt     time.Time
+	offeredUntil time.Time
+}
+
+// AirportQueue keeps a first-in-first-out queue of drivers per airport staging zone.
+type AirportQueue struct {
+	mu     sync.Mutex
+	queues map[string][]*queueEntry
+}
+
+func NewAirportQueue() *AirportQueue {
+	return &AirportQueue{
+		queues: make(map[string][]*queueEntry),
+	}
+}
+
+// Sync puts the driver in exactly the queues listed in zoneIDs, keeping their
+// place in queues they are already in. It returns the zones whose queue changed.
+func (q *AirportQueue) Sync(driverID string, zoneIDs []string, now time.Time) []string {
+	want := make(map[string]bool, len(zoneIDs))
+	for _, id := range zoneIDs {
+		want[id] = true
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var changed []string
+	for zoneID, entries := range q.queues {
+		if want[zoneID] {
+			continue
+		}
+		if idx := indexOf(entries, driverID); idx >= 0 {
+			q.queues[zoneID] = append(entries[:idx], entries[idx+1:]...)
+			changed = append(changed, zoneID)
+		}
+	}
+
+	for zoneID := range want {
+		if indexOf(q.queues[zoneID], driverID) >= 0 {
+			continue
+		}
+		q.queues[zoneID] = append(q.queues[zoneID], &queueEntry{driverID: driverID, joinedAt: now})
+		changed = append(changed, zoneID)
+	}
+
+	return changed
+}
+
+// Remove takes the driver out of every queue and returns the zones whose queue changed.
+func (q *AirportQueue) Remove(driverID string) []string {
+	return q.Sync(driverID, nil, time.Now())
+}
+
+// Drivers returns the driver IDs queued in the zone, head first.
+func (q *AirportQueue) Drivers(zoneID string) []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entries := q.queues[zoneID]
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.driverID)
+	}
+	return ids
+}
+
+// Position returns the 1-based position of the driver in the zone queue and the queue length.
+// Position is 0 when the driver is not queued.
+func (q *AirportQueue) Position(zoneID, driverID string) (int, int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entries := q.queues[zoneID]
+	return indexOf(entries, driverID) + 1, len(entries)
+}
+
+// NextCandidate returns the first queued driver without an outstanding offer
+// that passes the eligible check, and reserves them for offerTTL.
+func (q *AirportQueue) NextCandidate(zoneID string, now time.Time, offerTTL time.Duration, eligible func(driverID string) bool) (string, bool) {
+	q.mu.Lock()
+	candidates := make([]*queueEntry, 0, len(q.queues[zoneID]))
+	for _, e := range q.queues[zoneID] {
+		if now.Before(e.offeredUntil) {
+			continue
+		}
+		candidates = append(candidates, e)
+	}
+	q.mu.Unlock()
+
+	// eligible may hit the database, so it runs outside the lock
+	for _, e := range candidates {
+		if eligible != nil && !eligible(e.driverID) {
+			continue
+		}
+
+		q.mu.Lock()
+		reserved := false
+		if indexOf(q.queues[zoneID], e.driverID) >= 0 && !now.Before(e.offeredUntil) {
+			e.offeredUntil = now.Add(offerTTL)
+			reserved = true
+		}
+		q.mu.Unlock()
+
+		if reserved {
+			return e.driverID, true
+		}
+	}
+
+	return "", false
+}
+
+func indexOf(entries []*queueEntry, driverID string) int {
+	for i, e := range entries {
+		if e.driverID == driverID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/destination"
+	"ride-hail/internal/shared/geo"
+)
+
+// DestinationService runs destination mode: drivers heading somewhere, usually
+// home, are only matched to rides that bring them closer. The mode ends when
+// the driver gets there or when it times out.
+type DestinationService struct {
+	destinations ports.DestinationRepository
+	txManager    ports.TransactionManager
+	notifier     ports.Notifier
+	policy       destination.Policy
+}
+
+func NewDestinationService(
+	destinations ports.DestinationRepository,
+	txManager ports.TransactionManager,
+	notifier ports.Notifier,
+	policy destination.Policy,
+) *DestinationService {
+	return &DestinationService{
+		destinations: destinations,
+		txManager:    txManager,
+		notifier:     notifier,
+		policy:       policy,
+	}
+}
+
+// Status returns the driver's destination, if any, and how many uses are left today.
+func (s *DestinationService) Status(ctx context.Context, driverID string, now time.Time) (*models.DestinationStatus, error) {
+	if driverID == "" {
+		return nil, errors.New("driverID cannot be empty")
+	}
+
+	active, err := s.destinations.Active(ctx, []string{driverID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination: %w", err)
+	}
+	used, err := s.destinations.CountSince(ctx, driverID, destination.DayStart(now))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count destinations: %w", err)
+	}
+
+	status := &models.DestinationStatus{UsedToday: used, LeftToday: max(s.policy.MaxPerDay-used, 0)}
+	if d, ok := active[driverID]; ok {
+		status.Destination = &d
+	}
+	return status, nil
+}
+
+// Set puts the driver in destination mode. A destination already set is
+// replaced, and the new one counts against the daily limit all the same.
+func (s *DestinationService) Set(ctx context.Context, driverID string, req models.DestinationRequest, now time.Time) (*models.Destination, error) {
+	if driverID == "" {
+		return nil, errors.New("driverID cannot be empty")
+	}
+	if err := validateLatLon(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+
+	dest := &models.Destination{
+		DriverID:  driverID,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Address:   req.Address,
+		ExpiresAt: now.Add(s.policy.Timeout),
+	}
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		used, err := s.destinations.CountSince(txCtx, driverID, destination.DayStart(now))
+		if err != nil {
+			return fmt.Errorf("failed to count destinations: %w", err)
+		}
+		if used >= s.policy.MaxPerDay {
+			return fmt.Errorf("%w: %d of %d set", destination.ErrDailyLimit, used, s.policy.MaxPerDay)
+		}
+
+		if err := s.endActive(txCtx, driverID, destination.EndCleared); err != nil && !errors.Is(err, models.ErrDestinationNotFound) {
+			return err
+		}
+		if err := s.destinations.Create(txCtx, dest); err != nil {
+			return fmt.Errorf("failed to save destination: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("destination mode on", "driver_id", driverID, "destination_id", dest.ID, "expires_at", dest.ExpiresAt)
+	return dest, nil
+}
+
+// Clear takes the driver out of destination mode. The use still counts for the day.
+func (s *DestinationService) Clear(ctx context.Context, driverID string) error {
+	if driverID == "" {
+		return errors.New("driverID cannot be empty")
+	}
+	return s.endActive(ctx, driverID, destination.EndCleared)
+}
+
+func (s *DestinationService) endActive(ctx context.Context, driverID, reason string) error {
+	active, err := s.destinations.Active(ctx, []string{driverID})
+	if err != nil {
+		return fmt.Errorf("failed to get destination: %w", err)
+	}
+	d, ok := active[driverID]
+	if !ok {
+		return models.ErrDestinationNotFound
+	}
+	if _, err := s.destinations.End(ctx, d.ID, reason); err != nil {
+		return fmt.Errorf("failed to end destination: %w", err)
+	}
+	return nil
+}
+
+// Allowed returns which of the drivers, at the given positions, may be offered
+// a trip of tripKm ending at dropoff. Drivers not in destination mode always may.
+func (s *DestinationService) Allowed(ctx context.Context, positions map[string]geo.Point, dropoff geo.Point, tripKm float64) (map[string]bool, error) {
+	ids := make([]string, 0, len(positions))
+	for id := range positions {
+		ids = append(ids, id)
+	}
+	active, err := s.destinations.Active(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]bool, len(positions))
+	for id, from := range positions {
+		d, ok := active[id]
+		allowed[id] = !ok || s.policy.Allows(from, dropoff, d.Point(), tripKm)
+	}
+	return allowed, nil
+}
+
+// CheckArrival ends destination mode once the driver reaches the destination.
+func (s *DestinationService) CheckArrival(ctx context.Context, driverID string, at geo.Point) {
+	active, err := s.destinations.Active(ctx, []string{driverID})
+	if err != nil {
+		slog.Error("failed to get destination", "driver_id", driverID, "error", err.Error())
+		return
+	}
+	d, ok := active[driverID]
+	if !ok || !s.policy.Arrived(at, d.Point()) {
+		return
+	}
+	s.end(ctx, d, destination.EndArrived, "destination_reached")
+}
+
+// Run ends timed out destinations every interval until ctx is cancelled.
+func (s *DestinationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.Expire(ctx, now); err != nil {
+				slog.Error("failed to expire destinations", "error", err.Error())
+			}
+		}
+	}
+}
+
+// Expire ends destinations that timed out by now.
+func (s *DestinationService) Expire(ctx context.Context, now time.Time) error {
+	expired, err := s.destinations.ListExpired(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, d := range expired {
+		s.end(ctx, d, destination.EndExpired, "destination_expired")
+	}
+	return nil
+}
+
+// end ends the destination and tells the driver, unless it had ended already.
+func (s *DestinationService) end(ctx context.Context, d models.Destination, reason, eventType string) {
+	ended, err := s.destinations.End(ctx, d.ID, reason)
+	if err != nil {
+		slog.Error("failed to end destination", "driver_id", d.DriverID, "destination_id", d.ID, "error", err.Error())
+		return
+	}
+	if !ended {
+		return
+	}
+
+	slog.Info("destination mode off", "driver_id", d.DriverID, "destination_id", d.ID, "reason", reason)
+	if s.notifier == nil {
+		return
+	}
+	_ = s.notifier.NotifyDriver(d.DriverID, map[string]any{
+		"type":           eventType,
+		"destination_id": d.ID,
+		"latitude":       d.Latitude,
+		"longitude":      d.Longitude,
+		"address":        d.Address,
+	})
+}
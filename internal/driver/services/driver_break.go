@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+)
+
+// StartBreak takes an available driver out of matching without closing their
+// session. Time on the break is counted apart from the time spent working.
+func (s *DriverService) StartBreak(ctx context.Context, driverID string) error {
+	if driverID == "" {
+		return errors.New("driverID cannot be empty")
+	}
+
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		driver, err := s.repo.GetById(txCtx, driverID)
+		if err != nil {
+			return fmt.Errorf("failed to get driver: %w", err)
+		}
+		if driver.Status == models.OnBreak || !driver.Status.CanTransitionTo(models.OnBreak) {
+			return fmt.Errorf("%w: cannot start a break while %s", models.ErrStatusTransition, driver.Status)
+		}
+
+		session, err := s.sessionRepo.GetActiveByDriverID(txCtx, driverID)
+		if err != nil {
+			return fmt.Errorf("failed to get active session: %w", err)
+		}
+		if err := s.sessionRepo.StartBreak(txCtx, session.ID, driverID); err != nil {
+			return fmt.Errorf("failed to start break: %w", err)
+		}
+
+		return s.repo.UpdateStatus(txCtx, driverID, models.OnBreak)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishStatus(ctx, driverID, models.OnBreak)
+
+	// Nobody waits in an airport queue during a break
+	if s.airportQueue != nil {
+		s.notifyQueuePositions(driverID, s.airportQueue.Remove(driverID))
+	}
+	return nil
+}
+
+// EndBreak makes the driver available again. It returns the break time of the
+// session so far.
+func (s *DriverService) EndBreak(ctx context.Context, driverID string) (time.Duration, error) {
+	if driverID == "" {
+		return 0, errors.New("driverID cannot be empty")
+	}
+
+	// A break taken because of the fatigue limits lasts until the driver rested enough
+	if err := s.checkFatigue(ctx, driverID); err != nil {
+		return 0, err
+	}
+
+	var onBreak time.Duration
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		driver, err := s.repo.GetById(txCtx, driverID)
+		if err != nil {
+			return fmt.Errorf("failed to get driver: %w", err)
+		}
+		if driver.Status != models.OnBreak {
+			return fmt.Errorf("%w: not on a break, current status %s", models.ErrStatusTransition, driver.Status)
+		}
+
+		session, err := s.sessionRepo.GetActiveByDriverID(txCtx, driverID)
+		if err != nil {
+			return fmt.Errorf("failed to get active session: %w", err)
+		}
+		if err := s.sessionRepo.EndBreak(txCtx, session.ID); err != nil {
+			return fmt.Errorf("failed to end break: %w", err)
+		}
+		if onBreak, err = s.sessionRepo.BreakTime(txCtx, session.ID); err != nil {
+			return fmt.Errorf("failed to get break time: %w", err)
+		}
+
+		return s.repo.UpdateStatus(txCtx, driverID, models.Available)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.publishStatus(ctx, driverID, models.Available)
+
+	if loc, err := s.coordinateRepo.GetCurrent(ctx, driverID, "driver"); err == nil {
+		s.syncAirportQueue(driverID, models.Available, loc.Latitude, loc.Longitude)
+	}
+	return onBreak, nil
+}
+
+// publishStatus sends the driver's new status to driver_topic.
+func (s *DriverService) publishStatus(ctx context.Context, driverID string, status models.DriverStatus) {
+	statusUpdate := map[string]interface{}{
+		"driver_id": driverID,
+		"status":    status.String(),
+		"timestamp": time.Now(),
+	}
+
+	data, _ := json.Marshal(statusUpdate)
+	routingKey := fmt.Sprintf("driver.status.%s", driverID)
+	_ = s.publish.Publish(ctx, "driver_topic", routingKey, data)
+}
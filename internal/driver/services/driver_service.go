@@ -5,12 +5,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"ride-hail/internal/driver/domain/models"
 	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/fatigue"
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/gps"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pool"
+	"ride-hail/internal/shared/pricing"
 )
 
+// driverShare is the percentage of the fare paid out to the driver.
+const driverShare = 80
+
 type DriverService struct {
 	repo           ports.DriverRepository
 	sessionRepo    ports.DriverSessionsRepository
@@ -19,6 +29,20 @@ type DriverService struct {
 	consume        ports.Consume
 	publish        ports.Publish
 	txManager      ports.TransactionManager
+	notifier       ports.Notifier
+	zoneTracker    *ZoneTracker
+	airportQueue   *AirportQueue
+	catalog        *pricing.Catalog
+	waiting        pricing.WaitingPolicy
+	pools          ports.PoolRepository
+	poolPolicy     pool.Policy
+	limiter        *RateLimiter
+	locationWriter *LocationWriter
+	gpsFilter      *gps.Filter
+	fatigue        fatigue.Policy
+	documents      ports.DocumentRepository
+	destinations   *DestinationService
+	reliability    *ReliabilityService
 }
 
 func NewDriverService(
@@ -29,6 +53,20 @@ func NewDriverService(
 	consume ports.Consume,
 	publish ports.Publish,
 	txManager ports.TransactionManager,
+	notifier ports.Notifier,
+	zoneTracker *ZoneTracker,
+	airportQueue *AirportQueue,
+	catalog *pricing.Catalog,
+	waiting pricing.WaitingPolicy,
+	pools ports.PoolRepository,
+	poolPolicy pool.Policy,
+	limiter *RateLimiter,
+	locationWriter *LocationWriter,
+	gpsFilter *gps.Filter,
+	fatiguePolicy fatigue.Policy,
+	documents ports.DocumentRepository,
+	destinations *DestinationService,
+	reliability *ReliabilityService,
 ) *DriverService {
 	return &DriverService{
 		repo:           repo,
@@ -38,6 +76,20 @@ func NewDriverService(
 		consume:        consume,
 		publish:        publish,
 		txManager:      txManager,
+		notifier:       notifier,
+		zoneTracker:    zoneTracker,
+		airportQueue:   airportQueue,
+		catalog:        catalog,
+		waiting:        waiting,
+		pools:          pools,
+		poolPolicy:     poolPolicy,
+		limiter:        limiter,
+		locationWriter: locationWriter,
+		gpsFilter:      gpsFilter,
+		fatigue:        fatiguePolicy,
+		documents:      documents,
+		destinations:   destinations,
+		reliability:    reliability,
 	}
 }
 
@@ -50,6 +102,11 @@ func (s *DriverService) GoOnline(ctx context.Context, driverID string, lat, lon
 		return "", err
 	}
 
+	// Drivers who used up their driving hours have to rest first
+	if err := s.checkFatigue(ctx, driverID); err != nil {
+		return "", err
+	}
+
 	// Start transaction
 	var sessionID string
 	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
@@ -63,6 +120,12 @@ func (s *DriverService) GoOnline(ctx context.Context, driverID string, lat, lon
 		if driver.Status != models.Offline {
 			return fmt.Errorf("cannot go online: current status %s", driver.Status)
 		}
+		if err := s.checkOnboarding(txCtx, driver); err != nil {
+			return err
+		}
+		if err := s.clearStale(txCtx, driver); err != nil {
+			return err
+		}
 
 		// Create new session
 		sessionID, err = s.sessionRepo.Create(txCtx, driverID)
@@ -111,6 +174,9 @@ func (s *DriverService) GoOnline(ctx context.Context, driverID string, lat, lon
 	routingKey := fmt.Sprintf("driver.status.%s", driverID)
 	_ = s.publish.Publish(ctx, "driver_topic", routingKey, data)
 
+	s.notifyZoneChanges(driverID, lat, lon)
+	s.syncAirportQueue(driverID, models.Available, lat, lon)
+
 	return sessionID, nil
 }
 
@@ -128,8 +194,8 @@ func (s *DriverService) GoOffline(ctx context.Context, driverID string) (*models
 			return fmt.Errorf("failed to get driver: %w", err)
 		}
 
-		// Verify driver can go offline (must be AVAILABLE, not BUSY or EN_ROUTE)
-		if driver.Status != models.Available {
+		// Verify driver can go offline (AVAILABLE or ON_BREAK, not BUSY or EN_ROUTE)
+		if driver.Status == models.Offline || !driver.Status.CanTransitionTo(models.Offline) {
 			return fmt.Errorf("cannot go offline: current status %s (complete active ride first)", driver.Status)
 		}
 
@@ -139,6 +205,15 @@ func (s *DriverService) GoOffline(ctx context.Context, driverID string) (*models
 			return fmt.Errorf("failed to get active session: %w", err)
 		}
 
+		// A break still going on ends with the session
+		if err := s.sessionRepo.EndBreak(txCtx, session.ID); err != nil {
+			return fmt.Errorf("failed to end break: %w", err)
+		}
+		onBreak, err := s.sessionRepo.BreakTime(txCtx, session.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get break time: %w", err)
+		}
+
 		// Close the session
 		if err := s.sessionRepo.Close(txCtx, session.ID); err != nil {
 			return fmt.Errorf("failed to close session: %w", err)
@@ -154,6 +229,7 @@ func (s *DriverService) GoOffline(ctx context.Context, driverID string) (*models
 		summary = &models.SessionSummary{
 			SessionID:      session.ID,
 			DurationHours:  duration,
+			BreakHours:     onBreak.Hours(),
 			RidesCompleted: session.TotalRides,
 			Earnings:       session.TotalEarnings,
 		}
@@ -165,15 +241,17 @@ func (s *DriverService) GoOffline(ctx context.Context, driverID string) (*models
 	}
 
 	// Publish driver status update
-	statusUpdate := map[string]interface{}{
-		"driver_id": driverID,
-		"status":    models.Offline.String(),
-		"timestamp": time.Now(),
-	}
+	s.publishStatus(ctx, driverID, models.Offline)
 
-	data, _ := json.Marshal(statusUpdate)
-	routingKey := fmt.Sprintf("driver.status.%s", driverID)
-	_ = s.publish.Publish(ctx, "driver_topic", routingKey, data)
+	if s.zoneTracker != nil {
+		s.zoneTracker.Forget(driverID)
+	}
+	if s.gpsFilter != nil {
+		s.gpsFilter.Reset(driverID)
+	}
+	if s.airportQueue != nil {
+		s.notifyQueuePositions(driverID, s.airportQueue.Remove(driverID))
+	}
 
 	return summary, nil
 }
@@ -197,36 +275,99 @@ func (s *DriverService) UpdateLocation(ctx context.Context, driverID string, upd
 		return "", errors.New("cannot update location: driver offline")
 	}
 
+	history := s.filterLocation(driverID, update, time.Now())
+	if rejected(history) {
+		// Kept for disputes, but the driver stays where they were
+		if err := s.locationRepo.AddLocation(ctx, &history); err != nil {
+			return "", fmt.Errorf("failed to add location history: %w", err)
+		}
+		return "", fmt.Errorf("%w: %s", ErrLocationRejected, history.GPSReason)
+	}
+
 	// Update location in transaction
 	var coordID string
 	err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		if err := s.clearStale(txCtx, driver); err != nil {
+			return err
+		}
+
 		// Create/Update coordinate
 		coordID, err = s.coordinateRepo.CreateOrUpdate(txCtx, driverID, "driver",
-			update.Latitude, update.Longitude, update.Address)
+			history.Latitude, history.Longitude, update.Address)
 		if err != nil {
 			return fmt.Errorf("failed to update coordinate: %w", err)
 		}
 
 		// Add to location history
-		historyLoc := &models.LocationHistory{
-			CoordinateID:   coordID,
-			DriverID:       driverID,
-			Latitude:       update.Latitude,
-			Longitude:      update.Longitude,
-			AccuracyMeters: update.AccuracyMeters,
-			SpeedKmh:       update.SpeedKmh,
-			HeadingDegrees: update.HeadingDegrees,
-			RideID:         update.RideID,
-			RecordedAt:     time.Now(),
-		}
-
-		return s.locationRepo.AddLocation(txCtx, historyLoc)
+		history.CoordinateID = coordID
+		return s.locationRepo.AddLocation(txCtx, &history)
 	})
 	if err != nil {
 		return "", err
 	}
 
-	// Broadcast location update to fanout exchange
+	s.broadcastLocation(ctx, driverID, driver.Status, filteredUpdate(update, history))
+
+	return coordID, nil
+}
+
+// ReportLocation handles the stream of positions a driver sends over the WebSocket.
+// Updates coming faster than the rate limiter allows are skipped and it returns
+// false. Accepted positions go to location_fanout at once and are written to the
+// database in the background.
+func (s *DriverService) ReportLocation(ctx context.Context, driverID string, update *models.LocationUpdate) (bool, error) {
+	if driverID == "" {
+		return false, errors.New("driverID cannot be empty")
+	}
+
+	if err := validateLatLon(update.Latitude, update.Longitude); err != nil {
+		return false, err
+	}
+
+	if !s.limiter.Allow(driverID) {
+		return false, nil
+	}
+
+	driver, err := s.repo.GetById(ctx, driverID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get driver: %w", err)
+	}
+
+	if driver.Status == models.Offline {
+		return false, errors.New("cannot update location: driver offline")
+	}
+
+	history := s.filterLocation(driverID, update, time.Now())
+	if !s.locationWriter.Enqueue(history, update.Address) {
+		slog.Warn("location queue is full, position not stored", "driver_id", driverID)
+	}
+	if rejected(history) {
+		return false, fmt.Errorf("%w: %s", ErrLocationRejected, history.GPSReason)
+	}
+	if err := s.clearStale(ctx, driver); err != nil {
+		return false, err
+	}
+
+	s.broadcastLocation(ctx, driverID, driver.Status, filteredUpdate(update, history))
+
+	return true, nil
+}
+
+// clearStale lets a stale driver who reported again back into matching.
+func (s *DriverService) clearStale(ctx context.Context, driver *models.Driver) error {
+	if !driver.IsStale {
+		return nil
+	}
+	if err := s.repo.SetStale(ctx, driver.ID, false); err != nil {
+		return fmt.Errorf("failed to clear stale driver: %w", err)
+	}
+	driver.IsStale = false
+	return nil
+}
+
+// broadcastLocation sends the driver's position to location_fanout, updates
+// the zones and the airport queue the driver is in and ends destination mode on arrival.
+func (s *DriverService) broadcastLocation(ctx context.Context, driverID string, status models.DriverStatus, update *models.LocationUpdate) {
 	locationMsg := map[string]interface{}{
 		"driver_id": driverID,
 		"ride_id":   update.RideID,
@@ -242,14 +383,24 @@ func (s *DriverService) UpdateLocation(ctx context.Context, driverID string, upd
 	data, _ := json.Marshal(locationMsg)
 	_ = s.publish.Publish(ctx, "location_fanout", "", data)
 
-	return coordID, nil
+	s.notifyZoneChanges(driverID, update.Latitude, update.Longitude)
+	s.syncAirportQueue(driverID, status, update.Latitude, update.Longitude)
+	if s.destinations != nil {
+		s.destinations.CheckArrival(ctx, driverID, geo.Point{Lat: update.Latitude, Lng: update.Longitude})
+	}
 }
 
-func (s *DriverService) StartRide(ctx context.Context, driverID, rideID string, lat, lon float64) error {
+// StartRide starts a ride after the driver confirms the passenger's pickup PIN.
+func (s *DriverService) StartRide(ctx context.Context, driverID, rideID, pickupPIN string, lat, lon float64) error {
 	if rideID == "" {
 		return errors.New("rideID cannot be empty")
 	}
 
+	if err := s.verifyPickupPIN(ctx, driverID, rideID, pickupPIN); err != nil {
+		return err
+	}
+
+	var ridePool *models.Pool
 	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
 		// Verify driver status
 		driver, err := s.repo.GetById(txCtx, driverID)
@@ -261,11 +412,27 @@ func (s *DriverService) StartRide(ctx context.Context, driverID, rideID string,
 			return fmt.Errorf("cannot start ride: driver status is %s, must be BUSY", driver.Status)
 		}
 
+		// Waiting at pickup ends here: charge the minutes after the grace period
+		ride, err := s.repo.GetRideByID(txCtx, rideID)
+		if err != nil {
+			return fmt.Errorf("failed to get ride: %w", err)
+		}
+		if charge := s.waitingCharge(ride, time.Now()); !charge.IsZero() {
+			if err := s.repo.SetRideWaitingCharge(txCtx, rideID, charge); err != nil {
+				return fmt.Errorf("failed to save waiting charge: %w", err)
+			}
+		}
+
 		// Update ride status to IN_PROGRESS
 		if err := s.repo.UpdateRideStatus(txCtx, rideID, models.RideStatusInProgress); err != nil {
 			return fmt.Errorf("failed to update ride status: %w", err)
 		}
 
+		// The rider is in the car: the pickup is off the pool plan
+		if ridePool, err = s.leavePool(txCtx, ride, pool.StopPickup); err != nil {
+			return err
+		}
+
 		// Record start location
 		coordID, err := s.coordinateRepo.CreateOrUpdate(txCtx, driverID, "driver", lat, lon, "")
 		if err != nil {
@@ -299,17 +466,23 @@ func (s *DriverService) StartRide(ctx context.Context, driverID, rideID string,
 	routingKey := fmt.Sprintf("ride.status.%s", models.RideStatusInProgress.String())
 	_ = s.publish.Publish(ctx, "ride_topic", routingKey, data)
 
+	s.notifyPlan(driverID, ridePool)
+	s.syncAirportQueue(driverID, models.Busy, lat, lon)
+
 	return nil
 }
 
 func (s *DriverService) CompleteRide(ctx context.Context, driverID, rideID string,
 	finalLat, finalLon, actualDistance float64, actualDuration int,
-) (float64, error) {
+) (money.Money, error) {
 	if rideID == "" {
-		return 0.0, errors.New("rideID cannot be empty")
+		return money.Money{}, errors.New("rideID cannot be empty")
 	}
 
-	var driverEarnings float64
+	var driverEarnings, finalFare money.Money
+	var breakdown pricing.Breakdown
+	var ridePool *models.Pool
+	driverStatus := models.Available
 
 	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
 		// Update ride status to COMPLETED
@@ -337,18 +510,40 @@ func (s *DriverService) CompleteRide(ctx context.Context, driverID, rideID strin
 			return err
 		}
 
-		// Update driver status back to AVAILABLE
-		if err := s.repo.UpdateStatus(txCtx, driverID, models.Available); err != nil {
-			return fmt.Errorf("failed to update driver status: %w", err)
-		}
-
 		// Get ride details for earnings calculation (80% to driver)
 		ride, err := s.repo.GetRideByID(txCtx, rideID)
 		if err != nil {
 			return fmt.Errorf("failed to get ride: %w", err)
 		}
 
-		driverEarnings = ride.FinalFare * 0.80 // 80% to driver, 20% commission
+		// Update driver status back to AVAILABLE, unless riders of their pool are still waiting
+		if ridePool, err = s.leavePool(txCtx, ride, ""); err != nil {
+			return err
+		}
+		if ridePool != nil && len(ridePool.Plan) > 0 {
+			driverStatus = models.Busy
+		}
+		if err := s.repo.UpdateStatus(txCtx, driverID, driverStatus); err != nil {
+			return fmt.Errorf("failed to update driver status: %w", err)
+		}
+
+		// Final fare uses the actual trip and the tariff that was in effect when the ride was requested
+		breakdown = s.calculateFinalFare(ride, actualDistance, actualDuration)
+		if err := s.repo.SetRideFinalFare(txCtx, rideID, breakdown); err != nil {
+			return fmt.Errorf("failed to save final fare: %w", err)
+		}
+		finalFare = breakdown.Total
+
+		discount := breakdown.Amount(pricing.ItemDiscount).Neg()
+		if ride.Promo != nil {
+			if err := s.repo.ConsumePromoRedemption(txCtx, rideID, discount); err != nil {
+				return fmt.Errorf("failed to consume promo code: %w", err)
+			}
+		}
+
+		// 80% to driver, 20% commission; the odd cent goes to the driver.
+		// The platform pays for promo discounts, so earnings are on the fare before the discount.
+		driverEarnings = finalFare.Add(discount).Allocate(driverShare, 100-driverShare)[0]
 
 		// Update driver totals
 		driver, err := s.repo.GetById(txCtx, driverID)
@@ -357,7 +552,7 @@ func (s *DriverService) CompleteRide(ctx context.Context, driverID, rideID strin
 		}
 
 		driver.TotalRides++
-		driver.TotalEarnings += driverEarnings
+		driver.TotalEarnings = driver.TotalEarnings.Add(driverEarnings)
 
 		if err := s.repo.Update(txCtx, driver); err != nil {
 			return fmt.Errorf("failed to update driver totals: %w", err)
@@ -370,12 +565,12 @@ func (s *DriverService) CompleteRide(ctx context.Context, driverID, rideID strin
 		}
 
 		session.TotalRides++
-		session.TotalEarnings += driverEarnings
+		session.TotalEarnings = session.TotalEarnings.Add(driverEarnings)
 
 		return s.sessionRepo.Update(txCtx, session)
 	})
 	if err != nil {
-		return 0.0, err
+		return money.Money{}, err
 	}
 
 	// Publish ride completion
@@ -383,7 +578,10 @@ func (s *DriverService) CompleteRide(ctx context.Context, driverID, rideID strin
 		"ride_id":         rideID,
 		"status":          models.RideStatusCompleted.String(),
 		"driver_id":       driverID,
+		"final_fare":      finalFare,
+		"fare_breakdown":  breakdown,
 		"driver_earnings": driverEarnings,
+		"currency":        finalFare.Currency,
 		"timestamp":       time.Now(),
 	}
 
@@ -391,9 +589,126 @@ func (s *DriverService) CompleteRide(ctx context.Context, driverID, rideID strin
 	routingKey := fmt.Sprintf("ride.status.%s", models.RideStatusCompleted.String())
 	_ = s.publish.Publish(ctx, "ride_topic", routingKey, data)
 
+	// A driver AVAILABLE again after a drop-off inside a staging area is re-queued at the back
+	s.notifyPlan(driverID, ridePool)
+	s.syncAirportQueue(driverID, driverStatus, finalLat, finalLon)
+
 	return driverEarnings, nil
 }
 
+// calculateFinalFare prices the completed trip from the actual distance and duration,
+// adds the charge for waiting at pickup and takes off the reserved promo discount.
+// Without a tariff or trip data the fare already stored on the ride is kept, itemized
+// like the estimate when it still matches it. Pooled rides keep their upfront fare:
+// the detours for the other riders are not charged.
+func (s *DriverService) calculateFinalFare(ride *models.Ride, actualDistance float64, actualDuration int) pricing.Breakdown {
+	var tariff pricing.Tariff
+	ok := false
+	if s.catalog != nil && !ride.Pooled && (actualDistance > 0 || actualDuration > 0) {
+		tariff, ok = s.catalog.TariffAt(ride.VehicleType, ride.CityID, ride.CreatedAt)
+	}
+
+	var b pricing.Breakdown
+	switch {
+	case ok:
+		b = tariff.Breakdown(actualDistance, float64(actualDuration))
+		b.Add(pricing.ItemZoneSurcharge, ride.ZoneSurcharge)
+	case ride.FareBreakdown != nil && ride.FareBreakdown.Total.Amount == ride.FinalFare.Amount:
+		// The estimate is already discounted: rebuild it without the discount
+		for _, item := range ride.FareBreakdown.Items {
+			if item.Type != pricing.ItemDiscount {
+				b.Add(item.Type, item.Amount)
+			}
+		}
+	default:
+		b.Add(pricing.ItemFare, ride.FinalFare)
+	}
+	b.Add(pricing.ItemWaiting, ride.WaitingCharge)
+	if ride.Promo != nil {
+		b.Add(pricing.ItemDiscount, ride.Promo.Discount(b.Total).Neg())
+	}
+
+	return b.WithCurrency(ride.Currency)
+}
+
+// notifyZoneChanges tells the driver over the WebSocket which zones they entered or left.
+func (s *DriverService) notifyZoneChanges(driverID string, lat, lon float64) {
+	if s.zoneTracker == nil || s.notifier == nil {
+		return
+	}
+
+	entered, exited := s.zoneTracker.Update(driverID, geo.Point{Lat: lat, Lng: lon})
+	for _, z := range entered {
+		_ = s.notifier.NotifyDriver(driverID, zoneEvent("zone_entered", z))
+	}
+	for _, z := range exited {
+		_ = s.notifier.NotifyDriver(driverID, zoneEvent("zone_exited", z))
+	}
+}
+
+// syncAirportQueue keeps the driver in the FIFO queue of every airport staging
+// zone they are in while AVAILABLE, and out of all queues otherwise.
+func (s *DriverService) syncAirportQueue(driverID string, status models.DriverStatus, lat, lon float64) {
+	if s.airportQueue == nil || s.zoneTracker == nil {
+		return
+	}
+
+	var staging []string
+	if status == models.Available {
+		for _, z := range s.zoneTracker.zones.ZonesAt(geo.Point{Lat: lat, Lng: lon}) {
+			if z.Type == geo.ZoneTypeAirportStaging {
+				staging = append(staging, z.ID)
+			}
+		}
+	}
+
+	changed := s.airportQueue.Sync(driverID, staging, time.Now())
+	s.notifyQueuePositions(driverID, changed)
+}
+
+// notifyQueuePositions pushes the current position to every driver in the changed
+// queues. The driver that triggered the change is told when they left a queue.
+func (s *DriverService) notifyQueuePositions(driverID string, zoneIDs []string) {
+	if s.notifier == nil {
+		return
+	}
+
+	for _, zoneID := range zoneIDs {
+		drivers := s.airportQueue.Drivers(zoneID)
+		queued := false
+		for i, id := range drivers {
+			if id == driverID {
+				queued = true
+			}
+			_ = s.notifier.NotifyDriver(id, queuePositionEvent(zoneID, i+1, len(drivers)))
+		}
+		if !queued {
+			_ = s.notifier.NotifyDriver(driverID, queuePositionEvent(zoneID, 0, len(drivers)))
+		}
+	}
+}
+
+func queuePositionEvent(zoneID string, position, length int) map[string]interface{} {
+	return map[string]interface{}{
+		"type":         "airport_queue_position",
+		"zone_id":      zoneID,
+		"position":     position,
+		"queue_length": length,
+		"timestamp":    time.Now(),
+	}
+}
+
+func zoneEvent(eventType string, z geo.Zone) map[string]interface{} {
+	return map[string]interface{}{
+		"type":             eventType,
+		"zone_id":          z.ID,
+		"zone_name":        z.Name,
+		"zone_type":        z.Type,
+		"surcharge_amount": z.Surcharge,
+		"timestamp":        time.Now(),
+	}
+}
+
 func validateLatLon(lat, lon float64) error {
 	if lat < -90 || lat > 90 {
 		return errors.New("latitude must be between -90 and 90")
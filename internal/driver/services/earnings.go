@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/earnings"
+)
+
+// SessionHistory returns a page of the driver's sessions, newest first.
+func (s *DriverService) SessionHistory(ctx context.Context, driverID string, page, pageSize int) (*models.SessionHistory, error) {
+	if driverID == "" {
+		return nil, errors.New("driverID cannot be empty")
+	}
+
+	sessions, total, err := s.sessionRepo.History(ctx, driverID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	for i := range sessions {
+		sessions[i].EarningsPerHour = earnings.PerHour(sessions[i].Earnings, sessions[i].OnlineHours)
+	}
+
+	return &models.SessionHistory{
+		Sessions: sessions,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}, nil
+}
+
+// Earnings reports on the day, week or month that contains at. Offers and rates
+// come from the offer outcomes, the same ones the driver's profile rates are
+// worked out from.
+func (s *DriverService) Earnings(ctx context.Context, driverID, period string, at time.Time) (*models.EarningsReport, error) {
+	if driverID == "" {
+		return nil, errors.New("driverID cannot be empty")
+	}
+
+	from, to, err := earnings.Bounds(period, at)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.sessionRepo.Report(ctx, driverID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build earnings report: %w", err)
+	}
+	report.Period = period
+	report.EarningsPerHour = earnings.PerHour(report.Earnings, report.OnlineHours)
+
+	if s.reliability != nil {
+		offers, err := s.reliability.Between(ctx, driverID, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count offers: %w", err)
+		}
+		report.RidesAccepted = offers.Accepted + offers.Cancelled
+		report.RidesCancelled = offers.Cancelled
+		report.OffersDeclined = offers.Declined
+		report.OffersExpired = offers.Expired
+		report.AcceptanceRate = offers.AcceptanceRate()
+		report.CancellationRate = offers.CancellationRate()
+	}
+	return report, nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ride-hail/internal/shared/reliability"
+)
+
+func TestEarnings_OfferRates(t *testing.T) {
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name             string
+		counts           reliability.Counts
+		wantAccepted     int
+		wantAcceptance   *float64
+		wantCancellation *float64
+	}{
+		{
+			name:             "expired offers count against acceptance",
+			counts:           reliability.Counts{Accepted: 5, Declined: 1, Expired: 3, Cancelled: 1},
+			wantAccepted:     6,
+			wantAcceptance:   ptr(0.6),
+			wantCancellation: ptr(1.0 / 6),
+		},
+		{
+			name: "no offers",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			offers := &mockOfferRepo{counts: tc.counts}
+			svc := &DriverService{
+				sessionRepo: &mockReportRepo{},
+				reliability: NewReliabilityService(offers, nil, reliability.DefaultPolicy),
+			}
+
+			report, err := svc.Earnings(context.Background(), "driver-1", "day", at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !offers.from.Equal(report.From) || !offers.to.Equal(report.To) {
+				t.Fatalf("expected offers counted over [%v, %v), got [%v, %v)", report.From, report.To, offers.from, offers.to)
+			}
+			if report.RidesAccepted != tc.wantAccepted || report.OffersExpired != tc.counts.Expired {
+				t.Fatalf("expected %d accepted and %d expired, got %d and %d",
+					tc.wantAccepted, tc.counts.Expired, report.RidesAccepted, report.OffersExpired)
+			}
+			if !sameRate(report.AcceptanceRate, tc.wantAcceptance) {
+				t.Fatalf("expected acceptance %v, got %v", fmtRate(tc.wantAcceptance), fmtRate(report.AcceptanceRate))
+			}
+			if !sameRate(report.CancellationRate, tc.wantCancellation) {
+				t.Fatalf("expected cancellation %v, got %v", fmtRate(tc.wantCancellation), fmtRate(report.CancellationRate))
+			}
+		})
+	}
+}
+
+func ptr(f float64) *float64 {
+	return &f
+}
+
+func sameRate(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a-*b < 1e-9 && *b-*a < 1e-9
+}
+
+func fmtRate(r *float64) any {
+	if r == nil {
+		return nil
+	}
+	return *r
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ride-hail/internal/shared/fatigue"
+)
+
+var ErrFatigueLimit = errors.New("driving hours limit reached")
+
+// FatigueStatus evaluates the driver's sessions and breaks against the fatigue policy.
+func (s *DriverService) FatigueStatus(ctx context.Context, driverID string, now time.Time) (fatigue.Status, error) {
+	since := now.Add(-s.fatigue.Lookback())
+
+	sessions, err := s.sessionRepo.SessionsSince(ctx, driverID, since)
+	if err != nil {
+		return fatigue.Status{}, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	breaks, err := s.sessionRepo.BreaksSince(ctx, driverID, since)
+	if err != nil {
+		return fatigue.Status{}, fmt.Errorf("failed to get breaks: %w", err)
+	}
+	return s.fatigue.Evaluate(sessions, breaks, now), nil
+}
+
+// checkFatigue refuses more work to a driver who reached a limit. Rides already
+// under way are not affected.
+func (s *DriverService) checkFatigue(ctx context.Context, driverID string) error {
+	status, err := s.FatigueStatus(ctx, driverID, time.Now())
+	if err != nil {
+		return err
+	}
+	if status.Exceeded() {
+		return fmt.Errorf("%w: %s, rest until %s", ErrFatigueLimit, status.Limit, status.RestUntil.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/fatigue"
+)
+
+// FatigueMonitor keeps online drivers within the fatigue limits. Drivers are
+// warned over the WebSocket before they reach a limit. Once they do, available
+// drivers are sent on the mandatory break or offline, depending on the limit;
+// drivers on a ride finish it first and are not offered new ones.
+type FatigueMonitor struct {
+	repo     ports.DriverRepository
+	drivers  *DriverService
+	notifier ports.Notifier
+
+	mu     sync.Mutex
+	warned map[string]string // driver ID -> limit the driver was warned about
+}
+
+func NewFatigueMonitor(repo ports.DriverRepository, drivers *DriverService, notifier ports.Notifier) *FatigueMonitor {
+	return &FatigueMonitor{
+		repo:     repo,
+		drivers:  drivers,
+		notifier: notifier,
+		warned:   make(map[string]string),
+	}
+}
+
+// Run checks every interval until ctx is cancelled.
+func (m *FatigueMonitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := m.Check(ctx, now); err != nil {
+				slog.Error("failed to check driver fatigue", "error", err.Error())
+			}
+		}
+	}
+}
+
+// Check evaluates every online driver once.
+func (m *FatigueMonitor) Check(ctx context.Context, now time.Time) error {
+	activity, err := m.repo.ListActivity(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range activity {
+		status, err := m.drivers.FatigueStatus(ctx, a.DriverID, now)
+		if err != nil {
+			slog.Error("failed to get fatigue status", "driver_id", a.DriverID, "error", err.Error())
+			continue
+		}
+
+		switch {
+		case status.Exceeded():
+			m.enforce(ctx, a, status)
+		case m.drivers.fatigue.Warn(status):
+			if m.markWarned(a.DriverID, status.Limit) {
+				m.notify(a.DriverID, "fatigue_warning", status)
+			}
+		default:
+			m.forget(a.DriverID)
+		}
+	}
+	return nil
+}
+
+func (m *FatigueMonitor) enforce(ctx context.Context, a models.DriverActivity, status fatigue.Status) {
+	var err error
+	switch {
+	case a.Status == models.Available && status.Limit == fatigue.LimitContinuous:
+		err = m.drivers.StartBreak(ctx, a.DriverID)
+	case a.Status == models.Available, a.Status == models.OnBreak && status.Limit == fatigue.LimitDaily:
+		_, err = m.drivers.GoOffline(ctx, a.DriverID)
+	default:
+		// On a ride, or resting on a break already: tell the driver once
+		if m.markWarned(a.DriverID, "reached:"+status.Limit) {
+			m.notify(a.DriverID, "fatigue_limit", status)
+		}
+		return
+	}
+	if err != nil {
+		slog.Error("failed to enforce fatigue limit", "driver_id", a.DriverID, "limit", status.Limit, "error", err.Error())
+		return
+	}
+
+	slog.Info("fatigue limit enforced", "driver_id", a.DriverID, "limit", status.Limit)
+	m.notify(a.DriverID, "fatigue_limit", status)
+	m.forget(a.DriverID)
+}
+
+func (m *FatigueMonitor) notify(driverID, eventType string, status fatigue.Status) {
+	if m.notifier == nil {
+		return
+	}
+	event := map[string]any{
+		"type":              eventType,
+		"limit":             status.Limit,
+		"remaining_minutes": int(status.Remaining.Minutes()),
+		"online_hours":      status.Online.Hours(),
+	}
+	if !status.RestUntil.IsZero() {
+		event["rest_until"] = status.RestUntil.UTC().Format(time.RFC3339)
+	}
+	_ = m.notifier.NotifyDriver(driverID, event)
+}
+
+// markWarned records the warning and reports whether it is new.
+func (m *FatigueMonitor) markWarned(driverID, key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.warned[driverID] == key {
+		return false
+	}
+	m.warned[driverID] = key
+	return true
+}
+
+func (m *FatigueMonitor) forget(driverID string) {
+	m.mu.Lock()
+	delete(m.warned, driverID)
+	m.mu.Unlock()
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/gps"
+)
+
+var ErrLocationRejected = errors.New("location rejected")
+
+// filterLocation runs the driver's update through the GPS filter and returns the
+// history row with the filtered position next to the raw one. Without a filter
+// every update is taken as reported.
+func (s *DriverService) filterLocation(driverID string, update *models.LocationUpdate, at time.Time) models.LocationHistory {
+	raw := geo.Point{Lat: update.Latitude, Lng: update.Longitude}
+	result := gps.Result{Point: raw, Raw: raw, Verdict: gps.Accepted}
+	if s.gpsFilter != nil {
+		result = s.gpsFilter.Apply(driverID, gps.Fix{
+			Point:          raw,
+			AccuracyMeters: update.AccuracyMeters,
+			SpeedKmh:       update.SpeedKmh,
+			At:             at,
+		})
+	}
+
+	if result.Verdict != gps.Accepted {
+		slog.Warn("driver location flagged by GPS filter",
+			"driver_id", driverID,
+			"verdict", result.Verdict,
+			"reason", result.Reason,
+		)
+	}
+
+	return models.LocationHistory{
+		DriverID:       driverID,
+		Latitude:       result.Point.Lat,
+		Longitude:      result.Point.Lng,
+		RawLatitude:    result.Raw.Lat,
+		RawLongitude:   result.Raw.Lng,
+		GPSVerdict:     string(result.Verdict),
+		GPSReason:      result.Reason,
+		AccuracyMeters: update.AccuracyMeters,
+		SpeedKmh:       update.SpeedKmh,
+		HeadingDegrees: update.HeadingDegrees,
+		RideID:         update.RideID,
+		RecordedAt:     at,
+	}
+}
+
+// rejected reports whether the GPS filter dropped the position of the row.
+func rejected(history models.LocationHistory) bool {
+	return history.GPSVerdict == string(gps.Rejected)
+}
+
+// filteredUpdate returns the update moved to the filtered position of the row.
+func filteredUpdate(update *models.LocationUpdate, history models.LocationHistory) *models.LocationUpdate {
+	filtered := *update
+	filtered.Latitude = history.Latitude
+	filtered.Longitude = history.Longitude
+	return &filtered
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/domain/ports"
+)
+
+// shutdownFlushTimeout bounds the last flush once the writer is stopped.
+const shutdownFlushTimeout = 5 * time.Second
+
+type pendingLocation struct {
+	history models.LocationHistory
+	address string
+}
+
+// LocationWriter stores driver positions in the background. Positions are queued
+// and written in small batches, one transaction per batch: every row goes to the
+// location history, only the latest accepted position of each driver becomes current.
+type LocationWriter struct {
+	coordinateRepo ports.CoordinateRepository
+	locationRepo   ports.HistoryLocationRepository
+	txManager      ports.TransactionManager
+	queue          chan pendingLocation
+	batchSize      int
+	flushInterval  time.Duration
+}
+
+func NewLocationWriter(
+	coordinateRepo ports.CoordinateRepository,
+	locationRepo ports.HistoryLocationRepository,
+	txManager ports.TransactionManager,
+	batchSize int,
+	flushInterval time.Duration,
+) *LocationWriter {
+	return &LocationWriter{
+		coordinateRepo: coordinateRepo,
+		locationRepo:   locationRepo,
+		txManager:      txManager,
+		queue:          make(chan pendingLocation, batchSize*4),
+		batchSize:      batchSize,
+		flushInterval:  flushInterval,
+	}
+}
+
+// Enqueue queues a position for writing. It returns false when the queue is full
+// and the position was dropped; the next one from the driver supersedes it anyway.
+func (w *LocationWriter) Enqueue(history models.LocationHistory, address string) bool {
+	select {
+	case w.queue <- pendingLocation{history: history, address: address}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run writes queued positions every flush interval, or as soon as a batch is full,
+// until ctx is cancelled. Positions still queued then are written before it returns.
+func (w *LocationWriter) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]pendingLocation, 0, w.batchSize)
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		if err := w.flush(ctx, batch); err != nil {
+			slog.Error("failed to write driver locations", "error", err.Error(), "count", len(batch))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+		drain:
+			for {
+				select {
+				case loc := <-w.queue:
+					batch = append(batch, loc)
+				default:
+					break drain
+				}
+			}
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownFlushTimeout)
+			flush(flushCtx)
+			cancel()
+			return
+		case loc := <-w.queue:
+			batch = append(batch, loc)
+			if len(batch) >= w.batchSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
+
+func (w *LocationWriter) flush(ctx context.Context, batch []pendingLocation) error {
+	// The last position of each driver in the batch is the current one. Positions
+	// the GPS filter rejected only go to the history.
+	latest := make(map[string]int)
+	for i, loc := range batch {
+		if !rejected(loc.history) {
+			latest[loc.history.DriverID] = i
+		}
+	}
+
+	return w.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		coordIDs := make(map[string]string, len(latest))
+		for driverID, i := range latest {
+			loc := batch[i]
+			coordID, err := w.coordinateRepo.CreateOrUpdate(txCtx, driverID, "driver",
+				loc.history.Latitude, loc.history.Longitude, loc.address)
+			if err != nil {
+				return fmt.Errorf("failed to update coordinate: %w", err)
+			}
+			coordIDs[driverID] = coordID
+		}
+
+		history := make([]*models.LocationHistory, 0, len(batch))
+		for i := range batch {
+			loc := batch[i].history
+			if !rejected(loc) {
+				loc.CoordinateID = coordIDs[loc.DriverID]
+			}
+			history = append(history, &loc)
+		}
+		if err := w.locationRepo.AddLocations(txCtx, history); err != nil {
+			return fmt.Errorf("failed to add location history: %w", err)
+		}
+		return nil
+	})
+}
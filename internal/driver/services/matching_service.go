@@ -4,30 +4,65 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"slices"
+	"sort"
+	"time"
 
+	"ride-hail/internal/driver/domain/models"
 	"ride-hail/internal/driver/domain/ports"
 	"ride-hail/internal/shared/broker/messages"
 	"ride-hail/internal/shared/broker/rabbitmq"
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/pool"
+	"ride-hail/internal/shared/pricing"
 )
 
+// defaultOfferTimeout is used when the ride request does not carry its own timeout.
+const defaultOfferTimeout = 60 * time.Second
+
 type MatchingService struct {
-	notifier   ports.Notifier
-	consume    ports.Consume
-	driverRepo ports.DriverRepository
+	notifier     ports.Notifier
+	consume      ports.Consume
+	driverRepo   ports.DriverRepository
+	zones        *geo.ZoneCache
+	airportQueue *AirportQueue
+	pools        ports.PoolRepository
+	poolPolicy   pool.Policy
+	catalog      *pricing.Catalog
+	destinations *DestinationService
+	reliability  *ReliabilityService
 }
 
-func NewMatchingService(notifier ports.Notifier, consume ports.Consume, driverRepo ports.DriverRepository) *MatchingService {
+func NewMatchingService(
+	notifier ports.Notifier,
+	consume ports.Consume,
+	driverRepo ports.DriverRepository,
+	zones *geo.ZoneCache,
+	airportQueue *AirportQueue,
+	pools ports.PoolRepository,
+	poolPolicy pool.Policy,
+	catalog *pricing.Catalog,
+	destinations *DestinationService,
+	reliability *ReliabilityService,
+) *MatchingService {
 	return &MatchingService{
-		notifier:   notifier,
-		consume:    consume,
-		driverRepo: driverRepo,
+		notifier:     notifier,
+		consume:      consume,
+		driverRepo:   driverRepo,
+		zones:        zones,
+		airportQueue: airportQueue,
+		pools:        pools,
+		poolPolicy:   poolPolicy,
+		catalog:      catalog,
+		destinations: destinations,
+		reliability:  reliability,
 	}
 }
 
 func (m *MatchingService) Start(ctx context.Context) error {
-	// Start consuming from the matching queue.
+	// Start consuming from the ride requests queue.
 	// The Consume interface returns a channel of raw message bytes.
-	ch, err := m.consume.Consume(ctx, messages.ExchangeDriverTopic, "ride.request")
+	ch, err := m.consume.Consume(ctx, messages.QueueRideRequests, "")
 	if err != nil {
 		return err
 	}
@@ -43,12 +78,13 @@ func (m *MatchingService) processMessages(ctx context.Context, ch <-chan rabbitm
 		if err := m.handleMessage(ctx, msg); err != nil {
 			log.Printf("error handling message: %v", err)
 		}
+		_ = msg.Ack(false)
 	}
 }
 
-// handleMessage processes a single message from the queue.
-// Decodes JSON and routes to a driver via notifier or broadcasts.
 // handleMessage processes a RideMatchRequest from the queue.
+// Pooled rides first go to a nearby pool they fit into. Airport pickups go to
+// the head of the staging queue, everything else to the nearest driver.
 func (m *MatchingService) handleMessage(ctx context.Context, msg rabbitmq.Message) error {
 	var req messages.RideMatchRequest
 	if err := json.Unmarshal(msg.Body(), &req); err != nil {
@@ -56,12 +92,24 @@ func (m *MatchingService) handleMessage(ctx context.Context, msg rabbitmq.Messag
 		return err
 	}
 
-	// Find available drivers nearby the pickup location
+	if req.Pooled {
+		if driverID, plan, ok := m.pickFromPools(ctx, req); ok {
+			return m.offer(ctx, req, driverID, plan)
+		}
+	}
+
+	if driverID, ok := m.pickFromAirportQueue(ctx, req); ok {
+		return m.offer(ctx, req, driverID, nil)
+	}
+
+	// Find available drivers nearby the pickup location who want the ride
+	offer := offerFor(req)
 	drivers, err := m.driverRepo.FindAvailableDriversNearby(
 		ctx,
 		req.PickupLocation.Lat,
 		req.PickupLocation.Lng,
-		req.RideType,
+		offer,
+		m.upgradesFor(offer.VehicleType),
 		5000,
 	)
 	if err != nil {
@@ -69,25 +117,194 @@ func (m *MatchingService) handleMessage(ctx context.Context, msg rabbitmq.Messag
 		return err
 	}
 
-	if len(drivers) == 0 {
-		log.Printf("no available drivers for ride %s", req.RideID)
+	positions := make(map[string]geo.Point, len(drivers))
+	for _, d := range drivers {
+		positions[d.ID] = geo.Point{Lat: d.Latitude, Lng: d.Longitude}
+	}
+	allowed := m.headingTowards(ctx, req, positions)
+	m.rank(drivers, offer.VehicleType)
+
+	// Select the best ranked available driver the ride does not take off course
+	for _, d := range drivers {
+		if allowed[d.ID] {
+			return m.offer(ctx, req, d.ID, nil)
+		}
+	}
+
+	log.Printf("no available drivers for ride %s", req.RideID)
+	return nil
+}
+
+// pickFromPools returns the nearest driver with an active pool the ride fits
+// into, with the plan the driver would follow.
+func (m *MatchingService) pickFromPools(ctx context.Context, req messages.RideMatchRequest) (string, pool.Plan, bool) {
+	if m.pools == nil {
+		return "", nil, false
+	}
+
+	pools, err := m.pools.FindNearby(ctx, req.PickupLocation.Lat, req.PickupLocation.Lng, req.DispatchVehicleType(), 5000)
+	if err != nil {
+		log.Printf("failed to find pools: %v", err)
+		return "", nil, false
+	}
+
+	pickup := pool.Stop{RideID: req.RideID, Kind: pool.StopPickup, Location: geo.Point{Lat: req.PickupLocation.Lat, Lng: req.PickupLocation.Lng}, Address: req.PickupLocation.Address}
+	dropoff := pool.Stop{RideID: req.RideID, Kind: pool.StopDropoff, Location: geo.Point{Lat: req.Destination.Lat, Lng: req.Destination.Lng}, Address: req.Destination.Address}
+	offer := offerFor(req)
+	for _, p := range pools {
+		start := geo.Point{Lat: p.DriverLocation.Latitude, Lng: p.DriverLocation.Longitude}
+		prefs, err := m.driverRepo.GetPreferences(ctx, p.DriverID)
+		if err != nil || !prefs.Accepts(offer, geo.DistanceKm(start, pickup.Location), false) {
+			continue
+		}
+		if !m.headingTowards(ctx, req, map[string]geo.Point{p.DriverID: start})[p.DriverID] {
+			continue
+		}
+		if plan, err := pool.Insert(start, p.Plan, pickup, dropoff, m.poolPolicy); err == nil {
+			return p.DriverID, plan, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// pickFromAirportQueue returns the first eligible driver queued for the airport
+// the pickup is in. It reports false when the pickup is not at an airport or the queue is empty.
+func (m *MatchingService) pickFromAirportQueue(ctx context.Context, req messages.RideMatchRequest) (string, bool) {
+	if m.zones == nil || m.airportQueue == nil {
+		return "", false
+	}
+
+	pickup := geo.Point{Lat: req.PickupLocation.Lat, Lng: req.PickupLocation.Lng}
+	ttl := offerTTL(req)
+
+	offer := offerFor(req)
+	upgrades := m.upgradesFor(offer.VehicleType)
+	eligible := func(driverID string) bool {
+		driver, err := m.driverRepo.GetById(ctx, driverID)
+		if err != nil || driver.Status != models.Available || driver.IsStale {
+			return false
+		}
+		if driver.CooldownUntil != nil && time.Now().Before(*driver.CooldownUntil) {
+			return false
+		}
+		lowerClass := driver.VehicleType != offer.VehicleType
+		if lowerClass && !slices.Contains(upgrades, driver.VehicleType) {
+			return false
+		}
+		// Queued drivers are already at the airport, so the pickup distance does not count
+		prefs, err := m.driverRepo.GetPreferences(ctx, driverID)
+		if err != nil || !prefs.Accepts(offer, 0, lowerClass) {
+			return false
+		}
+		return m.headingTowards(ctx, req, map[string]geo.Point{driverID: pickup})[driverID]
+	}
+
+	for _, airport := range m.zones.ZonesAt(pickup) {
+		if airport.Type != geo.ZoneTypeAirport {
+			continue
+		}
+		for _, staging := range m.zones.StagingFor(airport.ID) {
+			if driverID, ok := m.airportQueue.NextCandidate(staging.ID, time.Now(), ttl, eligible); ok {
+				return driverID, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// offerFor describes the ride to the drivers' preferences. The trip length falls
+// back to the straight line when the ride service did not estimate it.
+func offerFor(req messages.RideMatchRequest) models.Offer {
+	tripKm := req.DistanceKm
+	if tripKm == 0 {
+		tripKm = geo.DistanceKm(
+			geo.Point{Lat: req.PickupLocation.Lat, Lng: req.PickupLocation.Lng},
+			geo.Point{Lat: req.Destination.Lat, Lng: req.Destination.Lng},
+		)
+	}
+	return models.Offer{VehicleType: req.DispatchVehicleType(), TripKm: tripKm, PaymentMethod: req.PaymentMethod}
+}
+
+// headingTowards returns which of the drivers, at the given positions, the ride
+// does not take away from the destination they set. It allows none of them if
+// destinations cannot be checked.
+func (m *MatchingService) headingTowards(ctx context.Context, req messages.RideMatchRequest, positions map[string]geo.Point) map[string]bool {
+	if m.destinations == nil {
+		allowed := make(map[string]bool, len(positions))
+		for id := range positions {
+			allowed[id] = true
+		}
+		return allowed
+	}
+
+	dropoff := geo.Point{Lat: req.Destination.Lat, Lng: req.Destination.Lng}
+	allowed, err := m.destinations.Allowed(ctx, positions, dropoff, offerFor(req).TripKm)
+	if err != nil {
+		log.Printf("failed to check driver destinations: %v", err)
+		return nil
+	}
+	return allowed
+}
+
+// upgradesFor returns the classes whose drivers may take a ride of the class
+// when they accept lower classes.
+func (m *MatchingService) upgradesFor(vehicleType string) []string {
+	if m.catalog == nil {
 		return nil
 	}
+	return m.catalog.UpgradesFor(vehicleType)
+}
+
+// rank orders drivers of the ride's class before drivers of other classes, and
+// each by distance with a penalty for ignoring or cancelling offers.
+func (m *MatchingService) rank(drivers []models.DriverWithDistance, vehicleType string) {
+	if m.reliability == nil {
+		return
+	}
+	sort.SliceStable(drivers, func(i, j int) bool {
+		if a, b := drivers[i].VehicleType == vehicleType, drivers[j].VehicleType == vehicleType; a != b {
+			return a
+		}
+		return m.reliability.Score(drivers[i]) < m.reliability.Score(drivers[j])
+	})
+}
+
+// offerTTL is how long the driver has to answer an offer of the ride.
+func offerTTL(req messages.RideMatchRequest) time.Duration {
+	if req.TimeoutSeconds > 0 {
+		return time.Duration(req.TimeoutSeconds) * time.Second
+	}
+	return defaultOfferTimeout
+}
 
-	// Select the first (nearest) available driver
-	matchedDriver := drivers[0]
+// offer sends the ride request to a single driver over the WebSocket and records
+// it for the driver's rates. Offers to join a pool carry the plan the driver
+// would follow after accepting.
+func (m *MatchingService) offer(ctx context.Context, req messages.RideMatchRequest, driverID string, plan pool.Plan) error {
+	if m.reliability != nil {
+		if err := m.reliability.Offered(ctx, driverID, req.RideID, offerTTL(req)); err != nil {
+			log.Printf("failed to record offer of ride %s to driver %s: %v", req.RideID, driverID, err)
+		}
+	}
 
-	// Notify the matched driver
 	evt := map[string]interface{}{
 		"type":           "ride_match",
 		"ride_id":        req.RideID,
-		"driver_id":      matchedDriver.ID,
+		"driver_id":      driverID,
 		"pickup":         req.PickupLocation,
 		"destination":    req.Destination,
 		"ride_type":      req.RideType,
 		"estimated_fare": req.EstimatedFare,
 		"correlation_id": req.CorrelationID,
 	}
+	if req.Pooled {
+		evt["pooled"] = true
+	}
+	if plan != nil {
+		evt["plan"] = plan
+	}
 
-	return m.notifier.Notify(evt)
+	return m.notifier.NotifyDriver(driverID, evt)
 }
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/fatigue"
+	"ride-hail/internal/shared/pool"
+	"ride-hail/internal/shared/reliability"
+)
+
+type mockTxManager struct{}
+
+func (mockTxManager) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// mockDriverRepo implements the calls the tests make; any other call panics.
+type mockDriverRepo struct {
+	ports.DriverRepository
+	rides    map[string]*models.Ride
+	statuses map[string]models.DriverStatus
+	activity []models.DriverActivity
+	stale    map[string]bool
+	// locked and pinFailures record LockRide calls and failed PIN events; eventErr fails AddRideEvent
+	locked      []string
+	pinFailures int
+	eventErr    error
+}
+
+func (m *mockDriverRepo) GetById(ctx context.Context, id string) (*models.Driver, error) {
+	return &models.Driver{ID: id, Status: m.statuses[id]}, nil
+}
+
+func (m *mockDriverRepo) ListActivity(ctx context.Context) ([]models.DriverActivity, error) {
+	return m.activity, nil
+}
+
+func (m *mockDriverRepo) SetStale(ctx context.Context, id string, stale bool) error {
+	m.stale[id] = stale
+	return nil
+}
+
+func (m *mockDriverRepo) GetRideByID(ctx context.Context, rideID string) (*models.Ride, error) {
+	ride, ok := m.rides[rideID]
+	if !ok {
+		return nil, errors.New("ride not found")
+	}
+	return ride, nil
+}
+
+func (m *mockDriverRepo) UpdateStatus(ctx context.Context, id string, status models.DriverStatus) error {
+	m.statuses[id] = status
+	return nil
+}
+
+type mockPoolRepo struct {
+	ports.PoolRepository
+	active *models.Pool
+}
+
+func (m *mockPoolRepo) GetActive(ctx context.Context, driverID string) (*models.Pool, error) {
+	if m.active == nil || m.active.DriverID != driverID {
+		return nil, models.ErrPoolNotFound
+	}
+	return m.active, nil
+}
+
+func (m *mockPoolRepo) UpdatePlan(ctx context.Context, poolID string, plan pool.Plan) error {
+	m.active.Plan = plan
+	return nil
+}
+
+type mockCoordinateRepo struct {
+	ports.CoordinateRepository
+}
+
+func (mockCoordinateRepo) GetCurrent(ctx context.Context, entityID, entityType string) (*models.Coordinate, error) {
+	return nil, errors.New("no location")
+}
+
+type mockSessionRepo struct {
+	ports.DriverSessionsRepository
+	closed []string
+}
+
+func (m *mockSessionRepo) GetActiveByDriverID(ctx context.Context, driverID string) (*models.DriverSession, error) {
+	return &models.DriverSession{ID: "session-" + driverID, DriverID: driverID, StartedAt: time.Now().Add(-time.Hour)}, nil
+}
+
+func (m *mockSessionRepo) EndBreak(ctx context.Context, sessionID string) error {
+	return nil
+}
+
+func (m *mockSessionRepo) BreakTime(ctx context.Context, sessionID string) (time.Duration, error) {
+	return 0, nil
+}
+
+func (m *mockSessionRepo) Close(ctx context.Context, sessionID string) error {
+	m.closed = append(m.closed, sessionID)
+	return nil
+}
+
+type mockPublisher struct{}
+
+func (mockPublisher) Publish(ctx context.Context, exchange, queueKey string, body []byte) error {
+	return nil
+}
+
+type mockOfferRepo struct {
+	ports.OfferRepository
+	counts   reliability.Counts
+	from, to time.Time
+	// open holds the open offers by driver and ride, "driver/ride"
+	open map[string]bool
+}
+
+func (m *mockOfferRepo) Between(ctx context.Context, driverID string, from, to time.Time) (reliability.Counts, error) {
+	m.from, m.to = from, to
+	return m.counts, nil
+}
+
+type mockReportRepo struct {
+	mockSessionRepo
+}
+
+func (m *mockReportRepo) Report(ctx context.Context, driverID string, from, to time.Time) (*models.EarningsReport, error) {
+	return &models.EarningsReport{From: from, To: to, Sessions: 1}, nil
+}
+
+func (m *mockOfferRepo) IsOpen(ctx context.Context, driverID, rideID string, now time.Time) (bool, error) {
+	return m.open[driverID+"/"+rideID], nil
+}
+
+func (m *mockOfferRepo) Decide(ctx context.Context, driverID, rideID, outcome string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockDriverRepo) LockRide(ctx context.Context, rideID string) error {
+	m.locked = append(m.locked, rideID)
+	return nil
+}
+
+func (m *mockDriverRepo) CountRideEvents(ctx context.Context, rideID, eventType string, since time.Time) (int, error) {
+	return m.pinFailures, nil
+}
+
+func (m *mockDriverRepo) AddRideEvent(ctx context.Context, rideID, eventType string, data map[string]any) error {
+	if m.eventErr != nil {
+		return m.eventErr
+	}
+	m.pinFailures++
+	return nil
+}
+
+func (m *mockDriverRepo) AssignRide(ctx context.Context, rideID, driverID, pickupPIN string) error {
+	m.rides[rideID].DriverID = driverID
+	return nil
+}
+
+func (m *mockSessionRepo) SessionsSince(ctx context.Context, driverID string, since time.Time) ([]fatigue.Interval, error) {
+	return nil, nil
+}
+
+func (m *mockSessionRepo) BreaksSince(ctx context.Context, driverID string, since time.Time) ([]fatigue.Interval, error) {
+	return nil, nil
+}
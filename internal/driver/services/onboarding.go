@@ -0,0 +1,179 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/onboarding"
+	"ride-hail/internal/shared/storage"
+)
+
+var ErrNotVerified = errors.New("driver is not verified")
+
+// OnboardingService takes document uploads from drivers and reminds them of
+// documents about to expire. Reviews are done by admins in the admin service.
+type OnboardingService struct {
+	documents ports.DocumentRepository
+	storage   storage.Storage
+	notifier  ports.Notifier
+	presence  Presence
+	// notice is how long before expiry the driver is told
+	notice time.Duration
+}
+
+func NewOnboardingService(
+	documents ports.DocumentRepository,
+	storage storage.Storage,
+	notifier ports.Notifier,
+	presence Presence,
+	notice time.Duration,
+) *OnboardingService {
+	return &OnboardingService{
+		documents: documents,
+		storage:   storage,
+		notifier:  notifier,
+		presence:  presence,
+		notice:    notice,
+	}
+}
+
+// UploadDocument stores the file and queues the document for review.
+func (s *OnboardingService) UploadDocument(
+	ctx context.Context,
+	driverID, docType string,
+	expiresAt time.Time,
+	fileName string,
+	file io.Reader,
+	size int64,
+) (*onboarding.Document, error) {
+	if driverID == "" {
+		return nil, errors.New("driverID cannot be empty")
+	}
+	docType = strings.ToUpper(strings.TrimSpace(docType))
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	head = head[:n]
+
+	ext, err := onboarding.ValidateUpload(docType, expiresAt, head, size, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+	doc := &onboarding.Document{
+		DriverID:    driverID,
+		Type:        docType,
+		FileName:    fileName,
+		ContentType: onboarding.ContentType(head),
+		Size:        size,
+		StorageKey:  onboarding.StorageKey(driverID, name, ext),
+		ExpiresAt:   expiresAt,
+	}
+
+	if err := s.storage.Save(ctx, doc.StorageKey, io.MultiReader(bytes.NewReader(head), file)); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+	if err := s.documents.Create(ctx, doc); err != nil {
+		if delErr := s.storage.Delete(context.WithoutCancel(ctx), doc.StorageKey); delErr != nil {
+			slog.Error("failed to delete orphaned document file", "key", doc.StorageKey, "error", delErr.Error())
+		}
+		return nil, fmt.Errorf("failed to save document: %w", err)
+	}
+
+	slog.Info("driver document uploaded", "driver_id", driverID, "document_id", doc.ID, "type", doc.Type)
+	return doc, nil
+}
+
+// Documents returns the driver's documents, newest first.
+func (s *OnboardingService) Documents(ctx context.Context, driverID string) ([]onboarding.Document, error) {
+	return s.documents.ListByDriver(ctx, driverID)
+}
+
+// Run sends expiry notices every interval until ctx is cancelled.
+func (s *OnboardingService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.NotifyExpiring(ctx, now); err != nil {
+				slog.Error("failed to send document expiry notices", "error", err.Error())
+			}
+		}
+	}
+}
+
+// NotifyExpiring tells connected drivers about documents expiring within the
+// notice period. A document is marked only once the notice went out, so drivers
+// who are not connected hear about it the next time they are.
+func (s *OnboardingService) NotifyExpiring(ctx context.Context, now time.Time) error {
+	docs, err := s.documents.ListExpiring(ctx, now.Add(s.notice))
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		if !s.presence.IsConnected(doc.DriverID) {
+			continue
+		}
+		event := map[string]any{
+			"type":          "document_expiring",
+			"document_id":   doc.ID,
+			"document_type": doc.Type,
+			"expires_at":    doc.ExpiresAt.UTC().Format(time.RFC3339),
+			"days_left":     doc.DaysLeft(now),
+		}
+		if err := s.notifier.NotifyDriver(doc.DriverID, event); err != nil {
+			slog.Error("failed to send document expiry notice", "driver_id", doc.DriverID, "error", err.Error())
+			continue
+		}
+		if err := s.documents.MarkExpiryNotified(ctx, doc.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkOnboarding lets only verified drivers with approved, unexpired documents work.
+func (s *DriverService) checkOnboarding(ctx context.Context, driver *models.Driver) error {
+	if !driver.IsVerifed {
+		return fmt.Errorf("%w: documents have not been approved yet", ErrNotVerified)
+	}
+
+	docs, err := s.documents.ListByDriver(ctx, driver.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get documents: %w", err)
+	}
+	if missing := onboarding.Missing(docs, time.Now()); len(missing) > 0 {
+		return fmt.Errorf("%w: missing or expired %s", ErrNotVerified, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/broker/rabbitmq"
+)
+
+// CancelledRides is told about rides the passenger cancelled after a driver was assigned.
+type CancelledRides interface {
+	RideCancelled(ctx context.Context, driverID, rideID string) error
+}
+
+// PassengerRelay forwards passenger events published by the ride service
+// (live location, pickup changes, cancellations) to the driver of the ride.
+type PassengerRelay struct {
+	notifier  ports.Notifier
+	consume   ports.Consume
+	cancelled CancelledRides
+}
+
+func NewPassengerRelay(notifier ports.Notifier, consume ports.Consume, cancelled CancelledRides) *PassengerRelay {
+	return &PassengerRelay{
+		notifier:  notifier,
+		consume:   consume,
+		cancelled: cancelled,
+	}
+}
+
+func (p *PassengerRelay) Start(ctx context.Context) error {
+	ch, err := p.consume.Consume(ctx, messages.QueuePassengerUpdates, "")
+	if err != nil {
+		return err
+	}
+
+	go p.processMessages(ctx, ch)
+	return nil
+}
+
+func (p *PassengerRelay) processMessages(ctx context.Context, ch <-chan rabbitmq.Message) {
+	for msg := range ch {
+		if err := p.handleMessage(ctx, msg.Body()); err != nil {
+			log.Printf("error relaying passenger update: %v", err)
+		}
+		_ = msg.Ack(false)
+	}
+}
+
+// handleMessage sends the update to the driver as is: the type field tells the app what happened.
+// Cancellations are passed on first so that the driver is free again, or a shared
+// trip drops the rider's stops.
+func (p *PassengerRelay) handleMessage(ctx context.Context, body []byte) error {
+	var update messages.PassengerUpdate
+	if err := json.Unmarshal(body, &update); err != nil {
+		return err
+	}
+	if update.DriverID == "" {
+		return nil
+	}
+
+	if update.Type == messages.PassengerUpdateCancelled && p.cancelled != nil {
+		if err := p.cancelled.RideCancelled(ctx, update.DriverID, update.RideID); err != nil {
+			log.Printf("failed to handle cancelled ride %s: %v", update.RideID, err)
+		}
+	}
+
+	return p.notifier.NotifyDriver(update.DriverID, update)
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+)
+
+const (
+	// maxPINAttempts is how many wrong PINs a ride accepts within pinAttemptWindow.
+	maxPINAttempts = 5
+	// pinAttemptWindow is how long failed attempts count against the ride.
+	pinAttemptWindow = 10 * time.Minute
+)
+
+var (
+	ErrRideNotAssigned    = errors.New("ride is not assigned to the driver")
+	ErrInvalidPickupPIN   = errors.New("invalid pickup PIN")
+	ErrTooManyPINAttempts = errors.New("too many wrong pickup PIN attempts, try again later")
+)
+
+// newPickupPIN returns a random 4-digit PIN.
+func newPickupPIN() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(10000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%04d", n.Int64()), nil
+}
+
+// verifyPickupPIN checks the PIN the passenger told the driver. Failed attempts are
+// stored as ride events, which also limits how many guesses a ride gets. The ride row
+// stays locked while the attempts are counted and recorded, so concurrent guesses
+// cannot get past maxPINAttempts.
+func (s *DriverService) verifyPickupPIN(ctx context.Context, driverID, rideID, pin string) error {
+	var failed int
+	var matched bool
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		if err := s.repo.LockRide(txCtx, rideID); err != nil {
+			return fmt.Errorf("failed to lock ride: %w", err)
+		}
+		ride, err := s.repo.GetRideByID(txCtx, rideID)
+		if err != nil {
+			return fmt.Errorf("failed to get ride: %w", err)
+		}
+		if ride.DriverID != driverID {
+			return ErrRideNotAssigned
+		}
+
+		failed, err = s.repo.CountRideEvents(txCtx, rideID, models.RideEventPINFailed, time.Now().Add(-pinAttemptWindow))
+		if err != nil {
+			return fmt.Errorf("failed to count PIN attempts: %w", err)
+		}
+		if failed >= maxPINAttempts {
+			return ErrTooManyPINAttempts
+		}
+
+		if ride.PickupPIN != "" && subtle.ConstantTimeCompare([]byte(pin), []byte(ride.PickupPIN)) == 1 {
+			matched = true
+			return nil
+		}
+
+		// The attempt is committed with the transaction, so the wrong PIN is reported after it
+		failed++
+		if err := s.repo.AddRideEvent(txCtx, rideID, models.RideEventPINFailed, map[string]any{
+			"driver_id": driverID,
+			"attempt":   failed,
+		}); err != nil {
+			return fmt.Errorf("failed to record PIN attempt: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if matched {
+		return nil
+	}
+	if failed >= maxPINAttempts {
+		return ErrTooManyPINAttempts
+	}
+	return fmt.Errorf("%w: %d attempts left", ErrInvalidPickupPIN, maxPINAttempts-failed)
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ride-hail/internal/driver/domain/models"
+)
+
+func TestVerifyPickupPIN(t *testing.T) {
+	dbErr := errors.New("db error")
+
+	cases := []struct {
+		name         string
+		pin          string
+		failures     int
+		eventErr     error
+		wantErr      error
+		wantFailures int
+	}{
+		{name: "correct PIN", pin: "1234"},
+		{name: "wrong PIN", pin: "0000", wantErr: ErrInvalidPickupPIN, wantFailures: 1},
+		{name: "last attempt", pin: "0000", failures: maxPINAttempts - 1, wantErr: ErrTooManyPINAttempts, wantFailures: maxPINAttempts},
+		{name: "attempts used up", pin: "1234", failures: maxPINAttempts, wantErr: ErrTooManyPINAttempts, wantFailures: maxPINAttempts},
+		{name: "attempt not recorded", pin: "0000", eventErr: dbErr, wantErr: dbErr},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mockDriverRepo{
+				rides:       map[string]*models.Ride{"ride-1": {ID: "ride-1", DriverID: "driver-1", PickupPIN: "1234"}},
+				pinFailures: tc.failures,
+				eventErr:    tc.eventErr,
+			}
+			svc := &DriverService{repo: repo, txManager: mockTxManager{}}
+
+			err := svc.verifyPickupPIN(context.Background(), "driver-1", "ride-1", tc.pin)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if repo.pinFailures != tc.wantFailures {
+				t.Fatalf("expected %d failed attempts, got %d", tc.wantFailures, repo.pinFailures)
+			}
+			if len(repo.locked) != 1 || repo.locked[0] != "ride-1" {
+				t.Fatalf("expected the ride to be locked once, got %v", repo.locked)
+			}
+		})
+	}
+}
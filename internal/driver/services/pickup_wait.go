@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
+)
+
+// noShowReason is stored as the cancellation reason of no-show rides.
+const noShowReason = "Passenger did not show up"
+
+var (
+	ErrNotHeadingToPickup = errors.New("ride is not waiting for the driver to arrive")
+	ErrNotWaitingAtPickup = errors.New("driver is not waiting at pickup")
+	ErrNoShowTooEarly     = errors.New("passenger can not be marked as a no-show yet")
+)
+
+// WaitingInfo tells the driver when waiting becomes paid and when they may give up.
+type WaitingInfo struct {
+	ArrivedAt time.Time `json:"arrived_at"`
+	FreeUntil time.Time `json:"waiting_free_until"`
+	NoShowAt  time.Time `json:"no_show_available_at"`
+}
+
+// MarkArrived records that the driver is at the pickup point. The grace period
+// and the no-show timeout both start now.
+func (s *DriverService) MarkArrived(ctx context.Context, driverID, rideID string, lat, lon float64) (WaitingInfo, error) {
+	if rideID == "" {
+		return WaitingInfo{}, errors.New("rideID cannot be empty")
+	}
+	if err := validateLatLon(lat, lon); err != nil {
+		return WaitingInfo{}, err
+	}
+
+	now := time.Now()
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		ride, err := s.repo.GetRideByID(txCtx, rideID)
+		if err != nil {
+			return fmt.Errorf("failed to get ride: %w", err)
+		}
+		if ride.DriverID != driverID {
+			return ErrRideNotAssigned
+		}
+		if ride.Status != models.RideStatusMatched && ride.Status != models.RideStatusEnRoute {
+			return ErrNotHeadingToPickup
+		}
+
+		if err := s.repo.UpdateRideStatus(txCtx, rideID, models.RideStatusArrived); err != nil {
+			return fmt.Errorf("failed to update ride status: %w", err)
+		}
+
+		_, err = s.coordinateRepo.CreateOrUpdate(txCtx, driverID, "driver", lat, lon, "")
+		return err
+	})
+	if err != nil {
+		return WaitingInfo{}, err
+	}
+
+	info := WaitingInfo{
+		ArrivedAt: now,
+		FreeUntil: s.waiting.FreeUntil(now),
+		NoShowAt:  s.waiting.NoShowAt(now),
+	}
+
+	s.publishRideStatus(ctx, messages.RideStatusUpdate{
+		RideID:           rideID,
+		DriverID:         driverID,
+		Status:           models.RideStatusArrived.String(),
+		Timestamp:        now,
+		WaitingFreeUntil: &info.FreeUntil,
+		Message:          "Your driver has arrived at the pickup location",
+	})
+
+	return info, nil
+}
+
+// MarkNoShow cancels a ride whose passenger did not come out before the no-show
+// timeout. The passenger is charged the no-show fee, the driver gets their share
+// of it and is available again, unless other riders of their pool are still ahead.
+func (s *DriverService) MarkNoShow(ctx context.Context, driverID, rideID string) (money.Money, error) {
+	if rideID == "" {
+		return money.Money{}, errors.New("rideID cannot be empty")
+	}
+
+	now := time.Now()
+	var fee pricing.Breakdown
+	var waited time.Duration
+	var ridePool *models.Pool
+	driverStatus := models.Available
+
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		ride, err := s.repo.GetRideByID(txCtx, rideID)
+		if err != nil {
+			return fmt.Errorf("failed to get ride: %w", err)
+		}
+		if ride.DriverID != driverID {
+			return ErrRideNotAssigned
+		}
+		if ride.Status != models.RideStatusArrived || ride.ArrivedAt == nil {
+			return ErrNotWaitingAtPickup
+		}
+		if noShowAt := s.waiting.NoShowAt(*ride.ArrivedAt); now.Before(noShowAt) {
+			return fmt.Errorf("%w: wait until %s", ErrNoShowTooEarly, noShowAt.UTC().Format(time.RFC3339))
+		}
+		waited = now.Sub(*ride.ArrivedAt)
+
+		fee.Add(pricing.ItemNoShowFee, s.noShowFee(ride))
+		fee = fee.WithCurrency(ride.Currency)
+		if err := s.repo.CancelRide(txCtx, rideID, noShowReason, fee); err != nil {
+			return err
+		}
+		if ridePool, err = s.leavePool(txCtx, ride, ""); err != nil {
+			return err
+		}
+		if ridePool != nil && len(ridePool.Plan) > 0 {
+			driverStatus = models.Busy
+		}
+		if err := s.repo.UpdateStatus(txCtx, driverID, driverStatus); err != nil {
+			return fmt.Errorf("failed to update driver status: %w", err)
+		}
+
+		if err := s.repo.AddRideEvent(txCtx, rideID, models.RideEventPassengerNoShow, map[string]any{
+			"driver_id":      driverID,
+			"waited_seconds": int(waited.Seconds()),
+			"no_show_fee":    fee.Total,
+		}); err != nil {
+			return fmt.Errorf("failed to record no-show: %w", err)
+		}
+
+		return s.creditNoShowFee(txCtx, driverID, fee.Total)
+	})
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	s.publishRideStatus(ctx, messages.RideStatusUpdate{
+		RideID:        rideID,
+		DriverID:      driverID,
+		Status:        models.RideStatusCancelled.String(),
+		Timestamp:     now,
+		FinalFare:     &fee.Total,
+		FareBreakdown: &fee,
+		Message:       noShowReason,
+	})
+
+	s.notifyPlan(driverID, ridePool)
+	if loc, err := s.coordinateRepo.GetCurrent(ctx, driverID, "driver"); err == nil {
+		s.syncAirportQueue(driverID, driverStatus, loc.Latitude, loc.Longitude)
+	}
+
+	return fee.Total, nil
+}
+
+// creditNoShowFee pays the driver's share of the no-show fee like ride earnings,
+// without counting a ride.
+func (s *DriverService) creditNoShowFee(ctx context.Context, driverID string, fee money.Money) error {
+	if fee.IsZero() {
+		return nil
+	}
+	earnings := fee.Allocate(driverShare, 100-driverShare)[0]
+
+	driver, err := s.repo.GetById(ctx, driverID)
+	if err != nil {
+		return fmt.Errorf("failed to get driver: %w", err)
+	}
+	driver.TotalEarnings = driver.TotalEarnings.Add(earnings)
+	if err := s.repo.Update(ctx, driver); err != nil {
+		return fmt.Errorf("failed to update driver totals: %w", err)
+	}
+
+	session, err := s.sessionRepo.GetActiveByDriverID(ctx, driverID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	session.TotalEarnings = session.TotalEarnings.Add(earnings)
+	return s.sessionRepo.Update(ctx, session)
+}
+
+// waitingCharge prices the waiting at pickup until now with the ride's tariff.
+func (s *DriverService) waitingCharge(ride *models.Ride, pickedUpAt time.Time) money.Money {
+	if ride.ArrivedAt == nil || s.catalog == nil {
+		return money.Zero(ride.Currency)
+	}
+	tariff, ok := s.catalog.TariffAt(ride.VehicleType, ride.CityID, ride.CreatedAt)
+	if !ok {
+		return money.Zero(ride.Currency)
+	}
+	return s.waiting.WaitingCharge(tariff, *ride.ArrivedAt, pickedUpAt).WithCurrency(ride.Currency)
+}
+
+// noShowFee returns the fee of the ride's tariff.
+func (s *DriverService) noShowFee(ride *models.Ride) money.Money {
+	if s.catalog == nil {
+		return money.Zero(ride.Currency)
+	}
+	tariff, ok := s.catalog.TariffAt(ride.VehicleType, ride.CityID, ride.CreatedAt)
+	if !ok {
+		return money.Zero(ride.Currency)
+	}
+	return tariff.NoShowFee.WithCurrency(ride.Currency)
+}
+
+// publishRideStatus tells the ride service about a status change of the ride.
+func (s *DriverService) publishRideStatus(ctx context.Context, update messages.RideStatusUpdate) {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return
+	}
+	_ = s.publish.Publish(ctx, messages.ExchangeRideTopic, messages.RideStatusRoutingKey(update.Status), data)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/pool"
+)
+
+// joinPool puts a pooled ride into the driver's active pool, or starts a pool when
+// the driver is available. The plan is worked out again from where the driver is
+// now, so an offer that no longer fits is refused.
+func (s *DriverService) joinPool(ctx context.Context, driver *models.Driver, ride *models.Ride, lat, lon float64) (*models.Pool, error) {
+	pickup, dropoff := poolStops(ride)
+
+	current, err := s.pools.GetActive(ctx, driver.ID)
+	switch {
+	case errors.Is(err, models.ErrPoolNotFound):
+		if driver.Status != models.Available {
+			return nil, fmt.Errorf("cannot accept ride: driver status is %s, must be AVAILABLE", driver.Status)
+		}
+		plan := pool.Plan{pickup, dropoff}
+		poolID, err := s.pools.Create(ctx, driver.ID, ride.ID, plan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pool: %w", err)
+		}
+		return &models.Pool{ID: poolID, DriverID: driver.ID, Plan: plan}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get pool: %w", err)
+	}
+
+	plan, err := pool.Insert(geo.Point{Lat: lat, Lng: lon}, current.Plan, pickup, dropoff, s.poolPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("cannot accept ride: %w", err)
+	}
+	if err := s.pools.AddRide(ctx, current.ID, ride.ID, plan); err != nil {
+		return nil, fmt.Errorf("failed to add ride to pool: %w", err)
+	}
+
+	current.Plan = plan
+	return current, nil
+}
+
+// leavePool removes stops of the ride from its pool: the pickup of the given kind
+// when the rider gets in, all of them when kind is empty. It returns the pool with
+// the stops left, or nil for rides outside a pool.
+func (s *DriverService) leavePool(ctx context.Context, ride *models.Ride, kind string) (*models.Pool, error) {
+	if ride.PoolID == "" {
+		return nil, nil
+	}
+
+	current, err := s.pools.GetActive(ctx, ride.DriverID)
+	if errors.Is(err, models.ErrPoolNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool: %w", err)
+	}
+	if current.ID != ride.PoolID {
+		return nil, nil
+	}
+
+	current.Plan = current.Plan.Without(ride.ID, kind)
+	if err := s.pools.UpdatePlan(ctx, current.ID, current.Plan); err != nil {
+		return nil, fmt.Errorf("failed to update pool: %w", err)
+	}
+	return current, nil
+}
+
+// RideCancelled frees the driver of a ride the passenger cancelled after it was
+// matched: its stops leave the driver's pool and the driver is available again
+// unless other riders of the pool are still ahead.
+func (s *DriverService) RideCancelled(ctx context.Context, driverID, rideID string) error {
+	var (
+		left         *models.Pool
+		freed        bool
+		driverStatus = models.Available
+	)
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		ride, err := s.repo.GetRideByID(txCtx, rideID)
+		if err != nil {
+			return fmt.Errorf("failed to get ride: %w", err)
+		}
+		if ride.DriverID != driverID || ride.Status != models.RideStatusCancelled {
+			return nil
+		}
+
+		if left, err = s.leavePool(txCtx, ride, ""); err != nil {
+			return err
+		}
+		if left != nil && len(left.Plan) > 0 {
+			driverStatus = models.Busy
+		}
+		if err := s.repo.UpdateStatus(txCtx, driverID, driverStatus); err != nil {
+			return fmt.Errorf("failed to update driver status: %w", err)
+		}
+		freed = true
+		return nil
+	})
+	if err != nil || !freed {
+		return err
+	}
+
+	s.notifyPlan(driverID, left)
+	if loc, err := s.coordinateRepo.GetCurrent(ctx, driverID, "driver"); err == nil {
+		s.syncAirportQueue(driverID, driverStatus, loc.Latitude, loc.Longitude)
+	}
+	return nil
+}
+
+// notifyPlan sends the driver the stops still ahead in their pool.
+func (s *DriverService) notifyPlan(driverID string, p *models.Pool) {
+	if p == nil || s.notifier == nil {
+		return
+	}
+	_ = s.notifier.NotifyDriver(driverID, poolPlanEvent(p))
+}
+
+func poolPlanEvent(p *models.Pool) map[string]any {
+	stops := p.Plan
+	if stops == nil {
+		stops = pool.Plan{}
+	}
+	return map[string]any{
+		"type":    "pool_plan",
+		"pool_id": p.ID,
+		"stops":   stops,
+	}
+}
+
+func poolStops(ride *models.Ride) (pool.Stop, pool.Stop) {
+	return pool.Stop{
+		RideID:   ride.ID,
+		Kind:     pool.StopPickup,
+		Location: geo.Point{Lat: ride.Pickup.Latitude, Lng: ride.Pickup.Longitude},
+	}, pool.Stop{
+		RideID:   ride.ID,
+		Kind:     pool.StopDropoff,
+		Location: geo.Point{Lat: ride.Destination.Latitude, Lng: ride.Destination.Longitude},
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/pool"
+)
+
+func TestRideCancelled(t *testing.T) {
+	sharedPool := func() *models.Pool {
+		return &models.Pool{ID: "pool-1", DriverID: "driver-1", Plan: pool.Plan{
+			{RideID: "ride-1", Kind: pool.StopPickup},
+			{RideID: "ride-2", Kind: pool.StopPickup},
+			{RideID: "ride-1", Kind: pool.StopDropoff},
+			{RideID: "ride-2", Kind: pool.StopDropoff},
+		}}
+	}
+
+	cases := []struct {
+		name       string
+		ride       models.Ride
+		pool       *models.Pool
+		wantStatus models.DriverStatus
+		wantStops  int
+	}{
+		{
+			name:       "single ride",
+			ride:       models.Ride{ID: "ride-1", DriverID: "driver-1", Status: models.RideStatusCancelled},
+			wantStatus: models.Available,
+		},
+		{
+			name:       "last rider of the pool",
+			ride:       models.Ride{ID: "ride-1", DriverID: "driver-1", Status: models.RideStatusCancelled, Pooled: true, PoolID: "pool-1"},
+			pool:       &models.Pool{ID: "pool-1", DriverID: "driver-1", Plan: pool.Plan{{RideID: "ride-1", Kind: pool.StopPickup}}},
+			wantStatus: models.Available,
+		},
+		{
+			name:       "riders left in the pool",
+			ride:       models.Ride{ID: "ride-1", DriverID: "driver-1", Status: models.RideStatusCancelled, Pooled: true, PoolID: "pool-1"},
+			pool:       sharedPool(),
+			wantStatus: models.Busy,
+			wantStops:  2,
+		},
+		{
+			name: "ride not cancelled",
+			ride: models.Ride{ID: "ride-1", DriverID: "driver-1", Status: models.RideStatusEnRoute},
+		},
+		{
+			name: "another driver's ride",
+			ride: models.Ride{ID: "ride-1", DriverID: "driver-2", Status: models.RideStatusCancelled},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ride := tc.ride
+			repo := &mockDriverRepo{
+				rides:    map[string]*models.Ride{ride.ID: &ride},
+				statuses: map[string]models.DriverStatus{},
+			}
+			pools := &mockPoolRepo{active: tc.pool}
+			svc := &DriverService{
+				repo:           repo,
+				pools:          pools,
+				coordinateRepo: mockCoordinateRepo{},
+				txManager:      mockTxManager{},
+			}
+
+			if err := svc.RideCancelled(context.Background(), "driver-1", ride.ID); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := repo.statuses["driver-1"]; got != tc.wantStatus {
+				t.Fatalf("expected driver status %q, got %q", tc.wantStatus, got)
+			}
+			if tc.pool != nil && len(pools.active.Plan) != tc.wantStops {
+				t.Fatalf("expected %d stops left, got %d", tc.wantStops, len(pools.active.Plan))
+			}
+		})
+	}
+}
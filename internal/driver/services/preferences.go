@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ride-hail/internal/driver/domain/models"
+)
+
+// Preferences returns what work the driver wants to be offered.
+func (s *DriverService) Preferences(ctx context.Context, driverID string) (models.Preferences, error) {
+	if driverID == "" {
+		return models.Preferences{}, errors.New("driverID cannot be empty")
+	}
+
+	prefs, err := s.repo.GetPreferences(ctx, driverID)
+	if err != nil {
+		return models.Preferences{}, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences replaces the driver's preferences. They apply from the next offer on.
+func (s *DriverService) UpdatePreferences(ctx context.Context, driverID string, prefs models.Preferences) (models.Preferences, error) {
+	if driverID == "" {
+		return models.Preferences{}, errors.New("driverID cannot be empty")
+	}
+	if err := prefs.Validate(); err != nil {
+		return models.Preferences{}, err
+	}
+
+	if err := s.repo.SavePreferences(ctx, driverID, prefs); err != nil {
+		return models.Preferences{}, fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return prefs, nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -45,3 +46,18 @@ func (rl *RateLimiter) Cleanup() {
 		}
 	}
 }
+
+// Run cleans up old entries every interval until ctx is cancelled.
+func (rl *RateLimiter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rl.Cleanup()
+		}
+	}
+}
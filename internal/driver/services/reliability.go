@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/domain/ports"
+	"ride-hail/internal/shared/reliability"
+)
+
+// ReliabilityService records what drivers do with their offers and keeps their
+// rolling acceptance and cancellation rates. Drivers past the thresholds are
+// warned or get no offers for a while, and matching ranks them lower.
+type ReliabilityService struct {
+	offers   ports.OfferRepository
+	notifier ports.Notifier
+	policy   reliability.Policy
+}
+
+func NewReliabilityService(offers ports.OfferRepository, notifier ports.Notifier, policy reliability.Policy) *ReliabilityService {
+	return &ReliabilityService{
+		offers:   offers,
+		notifier: notifier,
+		policy:   policy,
+	}
+}
+
+// Offered records a ride offered to the driver, pending until they answer or ttl passes.
+func (s *ReliabilityService) Offered(ctx context.Context, driverID, rideID string, ttl time.Duration) error {
+	return s.offers.Create(ctx, &models.RideOffer{
+		DriverID:  driverID,
+		RideID:    rideID,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+}
+
+// IsOffered reports whether the driver may still answer the offer of the ride.
+func (s *ReliabilityService) IsOffered(ctx context.Context, driverID, rideID string, now time.Time) (bool, error) {
+	return s.offers.IsOpen(ctx, driverID, rideID, now)
+}
+
+// Record records the outcome of the driver's offer of the ride and updates the
+// driver's rates. Outcomes of rides never offered through matching are ignored.
+func (s *ReliabilityService) Record(ctx context.Context, driverID, rideID, outcome string) error {
+	decided, err := s.offers.Decide(ctx, driverID, rideID, outcome)
+	if err != nil {
+		return fmt.Errorf("failed to record offer outcome: %w", err)
+	}
+	if !decided {
+		return nil
+	}
+	return s.update(ctx, driverID, outcome, time.Now())
+}
+
+// update re-evaluates the driver after an outcome. The driver is told when they
+// reach a warning or a cooldown starts.
+func (s *ReliabilityService) update(ctx context.Context, driverID, outcome string, now time.Time) error {
+	prev, err := s.offers.GetStanding(ctx, driverID)
+	if err != nil {
+		return fmt.Errorf("failed to get driver standing: %w", err)
+	}
+	counts, err := s.offers.Recent(ctx, driverID, s.policy.Window)
+	if err != nil {
+		return fmt.Errorf("failed to count offers: %w", err)
+	}
+
+	stats := s.policy.Evaluate(counts)
+	var cooldownUntil *time.Time
+	if s.policy.StartsCooldown(stats, outcome) && !prev.InCooldown(now) {
+		until := now.Add(s.policy.Cooldown)
+		cooldownUntil = &until
+	}
+	if err := s.offers.SaveStanding(ctx, driverID, stats, cooldownUntil); err != nil {
+		return fmt.Errorf("failed to save driver standing: %w", err)
+	}
+
+	switch {
+	case cooldownUntil != nil:
+		slog.Info("driver matching cooldown", "driver_id", driverID, "reason", stats.Reason, "until", *cooldownUntil)
+		s.notify(driverID, "matching_cooldown", stats, cooldownUntil)
+	case stats.Level == reliability.LevelWarning && prev.Level == reliability.LevelOK:
+		s.notify(driverID, "reliability_warning", stats, nil)
+	}
+	return nil
+}
+
+func (s *ReliabilityService) notify(driverID, eventType string, stats reliability.Stats, cooldownUntil *time.Time) {
+	if s.notifier == nil {
+		return
+	}
+	event := map[string]any{
+		"type":              eventType,
+		"reason":            stats.Reason,
+		"acceptance_rate":   stats.AcceptanceRate,
+		"cancellation_rate": stats.CancellationRate,
+	}
+	if cooldownUntil != nil {
+		event["cooldown_until"] = cooldownUntil.UTC().Format(time.RFC3339)
+	}
+	_ = s.notifier.NotifyDriver(driverID, event)
+}
+
+// Standing returns the driver's current rates, level and cooldown, if any.
+func (s *ReliabilityService) Standing(ctx context.Context, driverID string, now time.Time) (models.Reliability, error) {
+	counts, err := s.offers.Recent(ctx, driverID, s.policy.Window)
+	if err != nil {
+		return models.Reliability{}, fmt.Errorf("failed to count offers: %w", err)
+	}
+	saved, err := s.offers.GetStanding(ctx, driverID)
+	if err != nil {
+		return models.Reliability{}, fmt.Errorf("failed to get driver standing: %w", err)
+	}
+
+	rel := models.Reliability{Stats: s.policy.Evaluate(counts)}
+	if saved.InCooldown(now) {
+		rel.CooldownUntil = saved.CooldownUntil
+	}
+	return rel, nil
+}
+
+// Score ranks the driver for an offer, lower first.
+func (s *ReliabilityService) Score(d models.DriverWithDistance) float64 {
+	return s.policy.Score(d.DistanceKm, d.AcceptanceRate, d.CancellationRate)
+}
+
+// Between counts the outcomes of the offers made to the driver in [from, to).
+func (s *ReliabilityService) Between(ctx context.Context, driverID string, from, to time.Time) (reliability.Counts, error) {
+	return s.offers.Between(ctx, driverID, from, to)
+}
+
+// Run expires unanswered offers every interval until ctx is cancelled.
+func (s *ReliabilityService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.Expire(ctx, now); err != nil {
+				slog.Error("failed to expire offers", "error", err.Error())
+			}
+		}
+	}
+}
+
+// Expire records offers the driver did not answer in time.
+func (s *ReliabilityService) Expire(ctx context.Context, now time.Time) error {
+	expired, err := s.offers.ListExpired(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, o := range expired {
+		if err := s.Record(ctx, o.DriverID, o.RideID, reliability.OutcomeExpired); err != nil {
+			slog.Error("failed to expire offer", "driver_id", o.DriverID, "ride_id", o.RideID, "error", err.Error())
+		}
+	}
+	return nil
+}
+
+// Profile returns the driver with their offer rates.
+func (s *DriverService) Profile(ctx context.Context, driverID string) (*models.DriverProfile, error) {
+	if driverID == "" {
+		return nil, errors.New("driverID cannot be empty")
+	}
+
+	driver, err := s.repo.GetById(ctx, driverID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver: %w", err)
+	}
+
+	profile := &models.DriverProfile{
+		DriverID:      driver.ID,
+		Status:        driver.Status,
+		VehicleType:   driver.VehicleType,
+		Rating:        driver.Rating,
+		TotalRides:    driver.TotalRides,
+		TotalEarnings: driver.TotalEarnings,
+		IsVerified:    driver.IsVerifed,
+	}
+	if s.reliability != nil {
+		if profile.Reliability, err = s.reliability.Standing(ctx, driverID, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	return profile, nil
+}
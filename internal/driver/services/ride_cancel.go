@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/pricing"
+	"ride-hail/internal/shared/reliability"
+)
+
+// driverCancelReason is stored when the driver gives no reason.
+const driverCancelReason = "Driver cancelled"
+
+var ErrRideAlreadyStarted = errors.New("ride can only be cancelled before it starts")
+
+// CancelRide cancels a ride the driver accepted but has not started. The passenger
+// pays nothing and is told, the driver is available again unless other riders of
+// their pool are still ahead. The cancellation counts towards the driver's rates.
+func (s *DriverService) CancelRide(ctx context.Context, driverID, rideID, reason string) error {
+	if rideID == "" {
+		return errors.New("rideID cannot be empty")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		reason = driverCancelReason
+	}
+
+	var ridePool *models.Pool
+	driverStatus := models.Available
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		ride, err := s.repo.GetRideByID(txCtx, rideID)
+		if err != nil {
+			return fmt.Errorf("failed to get ride: %w", err)
+		}
+		if ride.DriverID != driverID {
+			return ErrRideNotAssigned
+		}
+		switch ride.Status {
+		case models.RideStatusMatched, models.RideStatusEnRoute, models.RideStatusArrived:
+		default:
+			return ErrRideAlreadyStarted
+		}
+
+		if err := s.repo.CancelRide(txCtx, rideID, reason, pricing.Breakdown{}.WithCurrency(ride.Currency)); err != nil {
+			return err
+		}
+		if ridePool, err = s.leavePool(txCtx, ride, ""); err != nil {
+			return err
+		}
+		if ridePool != nil && len(ridePool.Plan) > 0 {
+			driverStatus = models.Busy
+		}
+		if err := s.repo.UpdateStatus(txCtx, driverID, driverStatus); err != nil {
+			return fmt.Errorf("failed to update driver status: %w", err)
+		}
+
+		return s.repo.AddRideEvent(txCtx, rideID, models.RideEventRideCancelled, map[string]any{
+			"driver_id":    driverID,
+			"cancelled_by": "DRIVER",
+			"reason":       reason,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.recordOutcome(ctx, driverID, rideID, reliability.OutcomeCancelled)
+	s.publishRideStatus(ctx, messages.RideStatusUpdate{
+		RideID:    rideID,
+		DriverID:  driverID,
+		Status:    models.RideStatusCancelled.String(),
+		Timestamp: time.Now(),
+		Message:   "Your driver cancelled the ride, please request a new one",
+	})
+
+	s.notifyPlan(driverID, ridePool)
+	if loc, err := s.coordinateRepo.GetCurrent(ctx, driverID, "driver"); err == nil {
+		s.syncAirportQueue(driverID, driverStatus, loc.Latitude, loc.Longitude)
+	}
+	return nil
+}
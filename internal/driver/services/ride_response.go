@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/reliability"
+)
+
+var ErrNoOpenOffer = errors.New("ride is not offered to the driver or the offer expired")
+
+// RespondToOffer records the driver's answer to a ride offer. Accepting assigns the
+// ride to the driver with a fresh pickup PIN and makes the driver busy; the answer
+// is published to the ride service, which tells the passenger. Only a ride offered
+// to the driver through matching can be accepted, while the offer is open. A busy
+// driver may accept a pooled ride that still fits their pool and is sent the new
+// plan. Either answer counts towards the driver's offer rates.
+func (s *DriverService) RespondToOffer(ctx context.Context, driverID, rideID string, accepted bool, lat, lon float64) error {
+	if rideID == "" {
+		return errors.New("rideID cannot be empty")
+	}
+
+	response := messages.DriverMatchResponse{
+		RideID:   rideID,
+		DriverID: driverID,
+		Accepted: accepted,
+	}
+
+	if accepted {
+		if err := validateLatLon(lat, lon); err != nil {
+			return err
+		}
+		if err := s.checkFatigue(ctx, driverID); err != nil {
+			return err
+		}
+
+		pin, err := newPickupPIN()
+		if err != nil {
+			return fmt.Errorf("failed to generate pickup PIN: %w", err)
+		}
+
+		var driver *models.Driver
+		var ridePool *models.Pool
+		err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+			if s.reliability == nil {
+				return ErrNoOpenOffer
+			}
+			offered, err := s.reliability.IsOffered(txCtx, driverID, rideID, time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to check offer: %w", err)
+			}
+			if !offered {
+				return ErrNoOpenOffer
+			}
+
+			driver, err = s.repo.GetById(txCtx, driverID)
+			if err != nil {
+				return fmt.Errorf("failed to get driver: %w", err)
+			}
+			ride, err := s.repo.GetRideByID(txCtx, rideID)
+			if err != nil {
+				return fmt.Errorf("failed to get ride: %w", err)
+			}
+
+			if ride.Pooled {
+				if ridePool, err = s.joinPool(txCtx, driver, ride, lat, lon); err != nil {
+					return err
+				}
+			} else if driver.Status != models.Available {
+				return fmt.Errorf("cannot accept ride: driver status is %s, must be AVAILABLE", driver.Status)
+			}
+
+			if err := s.repo.AssignRide(txCtx, rideID, driverID, pin); err != nil {
+				return err
+			}
+			return s.repo.UpdateStatus(txCtx, driverID, models.Busy)
+		})
+		if err != nil {
+			return err
+		}
+
+		response.DriverLocation = &messages.Coordinate{Lat: lat, Lng: lon}
+		response.DriverInfo = &messages.DriverInfo{
+			DriverID: driver.ID,
+			Rating:   driver.Rating,
+			Vehicle: &messages.VehicleInfo{
+				Make:  driver.VehicleAttrs.VehicleMake,
+				Model: driver.VehicleAttrs.VehicleModel,
+				Color: driver.VehicleAttrs.VehicleColor,
+				Plate: driver.VehicleAttrs.VehiclePlate,
+			},
+		}
+
+		s.notifyPlan(driverID, ridePool)
+		s.syncAirportQueue(driverID, models.Busy, lat, lon)
+		s.recordOutcome(ctx, driverID, rideID, reliability.OutcomeAccepted)
+	} else {
+		if err := s.repo.AddRideEvent(ctx, rideID, models.RideEventDriverDeclined, map[string]any{
+			"driver_id": driverID,
+		}); err != nil {
+			// The decline still goes to the ride service, only the driver's stats miss it
+			slog.Error("failed to record declined offer", "ride_id", rideID, "driver_id", driverID, "error", err.Error())
+		}
+		s.recordOutcome(ctx, driverID, rideID, reliability.OutcomeDeclined)
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return s.publish.Publish(ctx, messages.ExchangeDriverTopic, messages.DriverResponseRoutingKey(rideID), data)
+}
+
+// recordOutcome counts the outcome towards the driver's offer rates. The ride
+// goes ahead either way, only the rates miss it on failure.
+func (s *DriverService) recordOutcome(ctx context.Context, driverID, rideID, outcome string) {
+	if s.reliability == nil {
+		return
+	}
+	if err := s.reliability.Record(ctx, driverID, rideID, outcome); err != nil {
+		slog.Error("failed to record offer outcome", "ride_id", rideID, "driver_id", driverID, "outcome", outcome, "error", err.Error())
+	}
+}
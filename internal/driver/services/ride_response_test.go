@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/shared/fatigue"
+	"ride-hail/internal/shared/reliability"
+)
+
+func TestRespondToOffer_Accept(t *testing.T) {
+	cases := []struct {
+		name     string
+		open     map[string]bool
+		wantErr  error
+		assigned bool
+	}{
+		{name: "open offer", open: map[string]bool{"driver-1/ride-1": true}, assigned: true},
+		{name: "offered to another driver", open: map[string]bool{"driver-2/ride-1": true}, wantErr: ErrNoOpenOffer},
+		{name: "never offered or expired", wantErr: ErrNoOpenOffer},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mockDriverRepo{
+				rides:    map[string]*models.Ride{"ride-1": {ID: "ride-1", Status: models.RideStatusRequested}},
+				statuses: map[string]models.DriverStatus{"driver-1": models.Available},
+			}
+			svc := &DriverService{
+				repo:        repo,
+				sessionRepo: &mockSessionRepo{},
+				publish:     mockPublisher{},
+				txManager:   mockTxManager{},
+				fatigue:     fatigue.DefaultPolicy,
+				reliability: NewReliabilityService(&mockOfferRepo{open: tc.open}, nil, reliability.DefaultPolicy),
+			}
+
+			err := svc.RespondToOffer(context.Background(), "driver-1", "ride-1", true, 43.238949, 76.889709)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if got := repo.rides["ride-1"].DriverID == "driver-1"; got != tc.assigned {
+				t.Fatalf("expected assigned=%v, got %v", tc.assigned, got)
+			}
+			wantStatus := models.Available
+			if tc.assigned {
+				wantStatus = models.Busy
+			}
+			if got := repo.statuses["driver-1"]; got != wantStatus {
+				t.Fatalf("expected driver status %q, got %q", wantStatus, got)
+			}
+		})
+	}
+}
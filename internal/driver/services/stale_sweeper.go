@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+	"ride-hail/internal/driver/domain/ports"
+)
+
+// StalePolicy sets how long a driver may go without being heard from.
+type StalePolicy struct {
+	// StaleAfter keeps the driver out of matching
+	StaleAfter time.Duration
+	// OfflineAfter takes an available driver offline, closing their session
+	OfflineAfter time.Duration
+}
+
+// DefaultStalePolicy is used when the service is not configured otherwise.
+var DefaultStalePolicy = StalePolicy{StaleAfter: 3 * time.Minute, OfflineAfter: 15 * time.Minute}
+
+// Presence reports whether the driver's app holds a WebSocket connection.
+type Presence interface {
+	IsConnected(driverID string) bool
+}
+
+// StaleSweeper finds online drivers whose app stopped reporting, e.g. after a
+// crash. A driver is heard from when they report a location and for as long as
+// their WebSocket is connected; a disconnect starts the clock.
+type StaleSweeper struct {
+	repo     ports.DriverRepository
+	drivers  *DriverService
+	presence Presence
+	policy   StalePolicy
+
+	mu             sync.Mutex
+	disconnectedAt map[string]time.Time
+}
+
+func NewStaleSweeper(repo ports.DriverRepository, drivers *DriverService, presence Presence, policy StalePolicy) *StaleSweeper {
+	return &StaleSweeper{
+		repo:           repo,
+		drivers:        drivers,
+		presence:       presence,
+		policy:         policy,
+		disconnectedAt: make(map[string]time.Time),
+	}
+}
+
+// Disconnected records that the driver's WebSocket went away.
+func (s *StaleSweeper) Disconnected(ctx context.Context, driverID string) {
+	s.mu.Lock()
+	s.disconnectedAt[driverID] = time.Now()
+	s.mu.Unlock()
+}
+
+// Run sweeps every interval until ctx is cancelled.
+func (s *StaleSweeper) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.Sweep(ctx, now); err != nil {
+				slog.Error("failed to sweep stale drivers", "error", err.Error())
+			}
+		}
+	}
+}
+
+// Sweep marks drivers not heard from for StaleAfter as stale and takes available
+// drivers and drivers on a break not heard from for OfflineAfter offline the way
+// GoOffline does. Drivers on a ride are only marked: the ride has to be finished first.
+func (s *StaleSweeper) Sweep(ctx context.Context, now time.Time) error {
+	activity, err := s.repo.ListActivity(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range activity {
+		idle := now.Sub(s.lastSeen(a, now))
+
+		switch {
+		case idle >= s.policy.OfflineAfter && (a.Status == models.Available || a.Status == models.OnBreak):
+			if _, err := s.drivers.GoOffline(ctx, a.DriverID); err != nil {
+				slog.Error("failed to take stale driver offline", "driver_id", a.DriverID, "error", err.Error())
+				continue
+			}
+			s.forget(a.DriverID)
+			slog.Info("stale driver taken offline", "driver_id", a.DriverID, "idle", idle.String())
+		case idle >= s.policy.StaleAfter && !a.IsStale:
+			if err := s.repo.SetStale(ctx, a.DriverID, true); err != nil {
+				slog.Error("failed to mark driver stale", "driver_id", a.DriverID, "error", err.Error())
+				continue
+			}
+			slog.Warn("driver marked stale", "driver_id", a.DriverID, "idle", idle.String())
+		case idle < s.policy.StaleAfter && a.IsStale:
+			if err := s.repo.SetStale(ctx, a.DriverID, false); err != nil {
+				slog.Error("failed to clear stale driver", "driver_id", a.DriverID, "error", err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// lastSeen returns when the driver was last heard from.
+func (s *StaleSweeper) lastSeen(a models.DriverActivity, now time.Time) time.Time {
+	if s.presence != nil && s.presence.IsConnected(a.DriverID) {
+		s.forget(a.DriverID)
+		return now
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if at, ok := s.disconnectedAt[a.DriverID]; ok && at.After(a.LastLocationAt) {
+		return at
+	}
+	return a.LastLocationAt
+}
+
+func (s *StaleSweeper) forget(driverID string) {
+	s.mu.Lock()
+	delete(s.disconnectedAt, driverID)
+	s.mu.Unlock()
+}
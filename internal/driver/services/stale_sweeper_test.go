@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ride-hail/internal/driver/domain/models"
+)
+
+func TestStaleSweeper_Sweep(t *testing.T) {
+	now := time.Now()
+	policy := StalePolicy{StaleAfter: 3 * time.Minute, OfflineAfter: 15 * time.Minute}
+
+	cases := []struct {
+		name       string
+		status     models.DriverStatus
+		idle       time.Duration
+		wantStatus models.DriverStatus
+		wantClosed bool
+		wantMarked bool
+	}{
+		{name: "available driver gone", status: models.Available, idle: 20 * time.Minute, wantStatus: models.Offline, wantClosed: true},
+		{name: "driver on a break gone", status: models.OnBreak, idle: 20 * time.Minute, wantStatus: models.Offline, wantClosed: true},
+		{name: "busy driver gone", status: models.Busy, idle: 20 * time.Minute, wantStatus: models.Busy, wantMarked: true},
+		{name: "driver on a break quiet", status: models.OnBreak, idle: 5 * time.Minute, wantStatus: models.OnBreak, wantMarked: true},
+		{name: "driver heard from", status: models.OnBreak, idle: time.Minute, wantStatus: models.OnBreak},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mockDriverRepo{
+				statuses: map[string]models.DriverStatus{"driver-1": tc.status},
+				activity: []models.DriverActivity{{DriverID: "driver-1", Status: tc.status, LastLocationAt: now.Add(-tc.idle)}},
+				stale:    map[string]bool{},
+			}
+			sessions := &mockSessionRepo{}
+			drivers := &DriverService{
+				repo:        repo,
+				sessionRepo: sessions,
+				publish:     mockPublisher{},
+				txManager:   mockTxManager{},
+			}
+			sweeper := NewStaleSweeper(repo, drivers, nil, policy)
+
+			if err := sweeper.Sweep(context.Background(), now); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := repo.statuses["driver-1"]; got != tc.wantStatus {
+				t.Fatalf("expected driver status %q, got %q", tc.wantStatus, got)
+			}
+			if closed := len(sessions.closed) > 0; closed != tc.wantClosed {
+				t.Fatalf("expected session closed=%v, got %v", tc.wantClosed, closed)
+			}
+			if repo.stale["driver-1"] != tc.wantMarked {
+				t.Fatalf("expected stale=%v, got %v", tc.wantMarked, repo.stale["driver-1"])
+			}
+		})
+	}
+}
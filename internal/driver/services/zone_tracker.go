@@ -0,0 +1,56 @@
+package services
+
+import (
+	"sync"
+
+	"ride-hail/internal/shared/geo"
+)
+
+// ZoneTracker remembers which zones each driver is currently in, so that
+// location updates can be turned into zone enter/leave events.
+type ZoneTracker struct {
+	zones *geo.ZoneCache
+
+	mu      sync.Mutex
+	current map[string]map[string]geo.Zone
+}
+
+func NewZoneTracker(zones *geo.ZoneCache) *ZoneTracker {
+	return &ZoneTracker{
+		zones:   zones,
+		current: make(map[string]map[string]geo.Zone),
+	}
+}
+
+// Update records the driver position and returns the zones entered and left since the last update.
+func (t *ZoneTracker) Update(driverID string, pt geo.Point) (entered, exited []geo.Zone) {
+	now := make(map[string]geo.Zone)
+	for _, z := range t.zones.ZonesAt(pt) {
+		now[z.ID] = z
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev := t.current[driverID]
+	for id, z := range now {
+		if _, ok := prev[id]; !ok {
+			entered = append(entered, z)
+		}
+	}
+	for id, z := range prev {
+		if _, ok := now[id]; !ok {
+			exited = append(exited, z)
+		}
+	}
+
+	t.current[driverID] = now
+	return entered, exited
+}
+
+// Forget drops the driver state, e.g. when the driver goes offline.
+func (t *ZoneTracker) Forget(driverID string) {
+	t.mu.Lock()
+	delete(t.current, driverID)
+	t.mu.Unlock()
+}
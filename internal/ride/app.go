@@ -2,16 +2,25 @@ package ride
 
 import (
 	"context"
+	"time"
 
 	"ride-hail/internal/ride/domain/ports"
 	"ride-hail/internal/ride/handlers"
 	"ride-hail/internal/ride/repository"
 	"ride-hail/internal/ride/service"
+	"ride-hail/internal/shared/broker/messages"
 	"ride-hail/internal/shared/broker/rabbitmq"
+	"ride-hail/internal/shared/chat"
+	"ride-hail/internal/shared/cities"
+	"ride-hail/internal/shared/geo"
 	"ride-hail/internal/shared/logger"
 	"ride-hail/internal/shared/postgres"
+	"ride-hail/internal/shared/pricing"
 )
 
+// zoneRefreshInterval controls how often admin zone, tariff and city changes are picked up.
+const zoneRefreshInterval = 30 * time.Second
+
 type App struct {
 	config    *handlers.ServerConfig
 	db        *postgres.Database
@@ -37,10 +46,49 @@ func NewApp(config *handlers.ServerConfig, db *postgres.Database, rmq *rabbitmq.
 func (a *App) Start(ctx context.Context) error {
 	repo := repository.NewRideRepo(a.db)
 
-	svc := service.NewRideService(repo, a.publisher, a.logger, a.secretKey)
+	zones := geo.NewZoneCache(postgres.NewZoneLoader(a.db))
+	go zones.Run(ctx, zoneRefreshInterval)
+
+	catalog := pricing.NewCatalog(postgres.NewCatalogLoader(a.db))
+	go catalog.Run(ctx, zoneRefreshInterval)
+
+	cityRegistry := cities.NewRegistry(repository.NewCityRepo(a.db))
+	go cityRegistry.Run(ctx, zoneRefreshInterval)
+
+	svc := service.NewRideService(repo, zones, catalog, cityRegistry, a.publisher, a.logger, a.secretKey)
 	handler := handlers.NewRideHandler(svc)
 
-	a.server = handlers.NewServer(handler, a.config, a.secretKey)
+	forwarder := service.NewLocationForwarder(repo, a.rmq, handlers.PassengerNotifier{}, a.logger)
+	if err := forwarder.Start(ctx); err != nil {
+		return err
+	}
+
+	// Accepted offers: the passenger gets the driver and the pickup PIN
+	responses := service.NewDriverResponseConsumer(repo, a.rmq, handlers.PassengerNotifier{}, a.logger)
+	if err := responses.Start(ctx); err != nil {
+		return err
+	}
+
+	// Arrival, start, completion and cancellations reported by the driver service;
+	// finished rides are charged to the passengers sharing the fare
+	statuses := service.NewRideStatusConsumer(repo, a.rmq, handlers.PassengerNotifier{}, repository.NewPaymentsRepo(a.db), a.logger)
+	if err := statuses.Start(ctx); err != nil {
+		return err
+	}
+
+	// Chat with the driver: passenger messages go to the driver service over ride_topic
+	chatService := chat.NewService(
+		postgres.NewChatStore(a.db),
+		chat.NewBrokerRelay(a.rmq, messages.ExchangeRideTopic, messages.RideChatRoutingKey),
+		handlers.PassengerNotifier{},
+	)
+	chatCh, err := a.rmq.Consume(ctx, messages.QueuePassengerChat, "")
+	if err != nil {
+		return err
+	}
+	go chatService.Consume(ctx, chatCh)
+
+	a.server = handlers.NewServer(handler, chatService, a.config, a.secretKey)
 
 	if a.logger != nil {
 		a.logger.Info(ctx, "server_starting", "Ride service starting")
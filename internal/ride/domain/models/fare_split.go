@@ -0,0 +1,48 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+var (
+	// ErrSplitNotFound is returned when the passenger has no pending invitation to split the ride's fare.
+	ErrSplitNotFound = errors.New("fare split invitation not found")
+	// ErrPassengerNotFound is returned when an invited passenger is not registered.
+	ErrPassengerNotFound = errors.New("passenger not found")
+)
+
+// Fare split statuses
+const (
+	SplitInvited  = "INVITED"
+	SplitAccepted = "ACCEPTED"
+	SplitDeclined = "DECLINED"
+)
+
+// FareSplit is a passenger the ride owner invited to pay part of the fare. SharePercent
+// is set when the owner chose custom shares; otherwise the fare is divided evenly.
+type FareSplit struct {
+	RideID       string    `json:"ride_id"`
+	PassengerID  string    `json:"passenger_id"`
+	Status       string    `json:"status"`
+	SharePercent int       `json:"share_percent,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SplitInvite names a registered passenger by ID or email, with a custom share if the
+// owner does not split evenly.
+type SplitInvite struct {
+	PassengerID  string
+	Email        string
+	SharePercent int
+}
+
+// FareShare is the part of the fare one passenger is charged.
+type FareShare struct {
+	PassengerID string      `json:"passenger_id"`
+	Amount      money.Money `json:"amount"`
+	Owner       bool        `json:"owner,omitempty"`
+}
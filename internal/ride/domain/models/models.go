@@ -1,6 +1,22 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pool"
+	"ride-hail/internal/shared/pricing"
+)
+
+// ErrRideNotFound is returned when the ride does not exist or is not active.
+var ErrRideNotFound = errors.New("ride not found")
+
+// Payment methods a ride can be paid with
+const (
+	PaymentCard = "CARD"
+	PaymentCash = "CASH"
+)
 
 // PickupCoordinateID, DestinationCoordinateID — это инфраструктура
 // EstimatedFare — бизнес
@@ -30,16 +46,44 @@ type Ride struct {
 	CancellationReason string     `json:"cancellation_reason,omitempty"`
 
 	// Financial & Estimates
-	EstimatedFare            *float64 `json:"estimated_fare,omitempty"`
-	FinalFare                *float64 `json:"final_fare,omitempty"`
-	EstimatedDurationMinutes int      `json:"estimated_duration_minutes,omitempty"`
-	EstimatedDistanceKm      float64  `json:"estimated_distance_km,omitempty"`
+	EstimatedFare            *money.Money       `json:"estimated_fare,omitempty"`
+	FinalFare                *money.Money       `json:"final_fare,omitempty"`
+	FareBreakdown            *pricing.Breakdown `json:"fare_breakdown,omitempty"`
+	FinalFareBreakdown       *pricing.Breakdown `json:"final_fare_breakdown,omitempty"`
+	EstimatedDurationMinutes int                `json:"estimated_duration_minutes,omitempty"`
+	EstimatedDistanceKm      float64            `json:"estimated_distance_km,omitempty"`
+	ZoneSurcharge            money.Money        `json:"zone_surcharge"`
+	PickupAdjusted           bool               `json:"pickup_adjusted,omitempty"`
+	CityID                   string             `json:"city_id,omitempty"`
+	Currency                 string             `json:"currency,omitempty"`
+	PaymentMethod            string             `json:"payment_method,omitempty"`
+
+	// PickupPIN is shown to the passenger only, through the ride_status_update message
+	PickupPIN string `json:"-"`
+
+	// PromoCode applied when the ride was requested. Its redemption is reserved with
+	// the ride, consumed when the ride is completed and released when it is cancelled.
+	PromoCode       string `json:"promo_code,omitempty"`
+	PromoCampaignID string `json:"-"`
+
+	// Pooled rides are requested as POOL and served by an economy car the passenger
+	// may share with other riders, for a discounted upfront fare.
+	Pooled bool `json:"pooled,omitempty"`
 
 	// Metadata
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// RideType returns the type the passenger requested: POOL for pooled rides,
+// the vehicle type otherwise.
+func (r Ride) RideType() string {
+	if r.Pooled {
+		return pool.RideType
+	}
+	return string(r.VehicleType)
+}
+
 // VehicleType - тип транспортного средства
 type VehicleType string
 
@@ -58,16 +102,16 @@ type Location struct {
 
 // Coordinates - модель координат для хранения в БД
 type Coordinates struct {
-	ID              string    `json:"id"`
-	EntityID        string    `json:"entity_id"`
-	EntityType      string    `json:"entity_type"`
-	Location        Location  `json:"location"`
-	FareAmount      *float64  `json:"fare_amount,omitempty"`
-	DistanceKm      *float64  `json:"distance_km,omitempty"`
-	DurationMinutes *int      `json:"duration_minutes,omitempty"`
-	IsCurrent       bool      `json:"is_current"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              string       `json:"id"`
+	EntityID        string       `json:"entity_id"`
+	EntityType      string       `json:"entity_type"`
+	Location        Location     `json:"location"`
+	FareAmount      *money.Money `json:"fare_amount,omitempty"`
+	DistanceKm      *float64     `json:"distance_km,omitempty"`
+	DurationMinutes *int         `json:"duration_minutes,omitempty"`
+	IsCurrent       bool         `json:"is_current"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
 }
 
 // RideStatus - статусы поездки
@@ -83,34 +127,30 @@ const (
 	RideStatusCancelled  RideStatus = "CANCELLED"
 )
 
-// PricingInfo - информация о тарифе
-type PricingInfo struct {
-	BaseFare   float64 `json:"base_fare"`
-	RatePerKm  float64 `json:"rate_per_km"`
-	RatePerMin float64 `json:"rate_per_min"`
-}
-
-// PricingTable - таблица тарифов
-var PricingTable = map[VehicleType]PricingInfo{
-	VehicleTypeEconomy: {BaseFare: 500, RatePerKm: 100, RatePerMin: 50},
-	VehicleTypePremium: {BaseFare: 800, RatePerKm: 120, RatePerMin: 60},
-	VehicleTypeXL:      {BaseFare: 1000, RatePerKm: 150, RatePerMin: 75},
-}
-
 type CreateRideCommand struct {
 	PassengerID string
 	VehicleType VehicleType
 	Pickup      Location
 	Destination Location
+	PromoCode   string
+	// PaymentMethod is CASH or CARD, CARD when empty
+	PaymentMethod string
 }
 
-func (v VehicleType) IsValid() bool {
-	switch v {
-	case VehicleTypeEconomy,
-		VehicleTypePremium,
-		VehicleTypeXL:
-		return true
-	default:
-		return false
-	}
+// Receipt is what the passenger paid for a finished ride. For a split fare it lists
+// every passenger's share and the share of the passenger it was issued to.
+type Receipt struct {
+	RideID      string
+	RideNumber  string
+	Status      RideStatus
+	VehicleType VehicleType
+	Pickup      Location
+	Destination Location
+	StartedAt   *time.Time
+	EndedAt     time.Time
+	Reason      string // cancellation reason of rides cancelled with a fee
+	Fare        pricing.Breakdown
+	Shares      []FareShare
+	Share       *money.Money
+	IssuedAt    time.Time
 }
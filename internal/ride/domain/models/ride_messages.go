@@ -1,12 +1,19 @@
 package models
 
+import (
+	"time"
+
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/money"
+)
+
 type RideMessage struct {
 	RideId         string         `json:"ride_id"`
 	RideNumber     string         `json:"ride_number"`
 	PickupLocation []PickupLatLon `json:"pickup_location"`
 	DestLocation   []DestLatLon   `json:"destination_location"`
 	RideType       string         `json:"ride_type"`
-	EstimatedFare  float64        `json:"estimated_fare"`
+	EstimatedFare  money.Money    `json:"estimated_fare"`
 	MaxDestKm      float64        `json:"max_distance_km"`
 	TimeoutSeconds int64          `json:"timeout_seconds"`
 	CorrelationId  string         `json:"correlation_id"`
@@ -21,3 +28,23 @@ type DestLatLon struct {
 	Lon     float64 `json:"lon"`
 	Address string  `json:"address"`
 }
+
+// DriverLocation is the driver position forwarded to the passenger together with
+// the distance and arrival estimate to the next point of the ride.
+type DriverLocation struct {
+	RideID              string
+	Latitude            float64
+	Longitude           float64
+	DistanceToPickupKm  float64
+	DistanceRemainingKm float64
+	EstimatedArrival    time.Time
+}
+
+// RideMatch tells the passenger which driver accepted the ride and the PIN
+// to give the driver at pickup.
+type RideMatch struct {
+	RideID     string
+	RideNumber string
+	PickupPIN  string
+	Driver     *messages.DriverInfo
+}
@@ -3,7 +3,7 @@ package ports
 import (
 	"context"
 
-	"github.com/rabbitmq/amqp091-go"
+	"ride-hail/internal/shared/broker/rabbitmq"
 )
 
 type Publish interface {
@@ -11,5 +11,5 @@ type Publish interface {
 }
 
 type Consume interface {
-	Consume(ctx context.Context, queueName string) (<-chan amqp091.Delivery, error)
+	Consume(ctx context.Context, queueName, queueKey string) (<-chan rabbitmq.Message, error)
 }
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/broker/messages"
+)
+
+// PassengerNotifier pushes real-time updates to passengers connected over WebSocket.
+type PassengerNotifier interface {
+	IsConnected(passengerID string) bool
+	SendDriverLocation(passengerID string, update models.DriverLocation) error
+	SendRideMatched(passengerID string, match models.RideMatch) error
+	SendRideStatus(passengerID string, update messages.RideStatusUpdate) error
+}
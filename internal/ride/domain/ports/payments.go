@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"ride-hail/internal/ride/domain/models"
+)
+
+// Payments charges passengers for finished rides.
+type Payments interface {
+	// Charge records one charge per share of the ride's fare. A ride that was
+	// already charged is left as is, so a redelivered status update does not
+	// charge anybody twice.
+	Charge(ctx context.Context, rideID string, shares []models.FareShare) error
+}
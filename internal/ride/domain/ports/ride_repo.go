@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/promo"
 )
 
 type RideRepository interface {
@@ -12,5 +13,31 @@ type RideRepository interface {
 	ListByStatus(ctx context.Context, passengerID string, status string) ([]models.Ride, error)
 	GetRide(ctx context.Context, id string) (models.Ride, error)
 	UpdateStatus(ctx context.Context, rideID string, status string) error
-	CloseRide(ctx context.Context, id string, reason string) error
+	// CloseRide cancels a ride that has not started yet and returns the driver assigned
+	// to it, empty when there is none. It returns models.ErrRideNotFound when the ride
+	// is gone or can no longer be cancelled.
+	CloseRide(ctx context.Context, id string, reason string) (string, error)
+	// ListActiveRidesByDriver returns the driver's rides that are matched or in progress:
+	// one for a regular trip, one per rider on a shared trip.
+	ListActiveRidesByDriver(ctx context.Context, driverID string) ([]models.Ride, error)
+	UpdatePickup(ctx context.Context, ride *models.Ride) error
+	SavePassengerLocation(ctx context.Context, passengerID string, loc models.Location) error
+	UpdateDestination(ctx context.Context, ride *models.Ride, previous models.Location) error
+	GetDriverLocation(ctx context.Context, driverID string) (models.Location, error)
+	// GetPromoCampaign finds a campaign by code with its redemption count.
+	// It returns promo.ErrCodeNotFound for unknown codes.
+	GetPromoCampaign(ctx context.Context, code string) (promo.Campaign, error)
+	// CountPromoRedemptions counts the passenger's reserved and consumed redemptions of the campaign.
+	CountPromoRedemptions(ctx context.Context, campaignID, passengerID string) (int, error)
+	// FindPassengerByEmail returns the ID of the registered passenger with the email,
+	// or models.ErrPassengerNotFound.
+	FindPassengerByEmail(ctx context.Context, email string) (string, error)
+	// ListFareSplits returns the passengers invited to split the ride's fare.
+	ListFareSplits(ctx context.Context, rideID string) ([]models.FareSplit, error)
+	// SaveFareSplits invites the passengers, inviting again those who declined, and
+	// returns all the ride's splits.
+	SaveFareSplits(ctx context.Context, rideID string, splits []models.FareSplit) ([]models.FareSplit, error)
+	// RespondToFareSplit accepts or declines a pending invitation. It returns
+	// models.ErrSplitNotFound when the passenger has none.
+	RespondToFareSplit(ctx context.Context, rideID, passengerID, status string) (models.FareSplit, error)
 }
@@ -0,0 +1,22 @@
+package dto
+
+import "ride-hail/internal/ride/domain/models"
+
+// SplitFareRequest invites registered passengers, by ID or email, to split the fare.
+// Either every participant has share_percent or the fare is divided evenly.
+type SplitFareRequest struct {
+	Participants []SplitParticipant `json:"participants"`
+}
+
+type SplitParticipant struct {
+	PassengerID  string `json:"passenger_id,omitempty"`
+	Email        string `json:"email,omitempty"`
+	SharePercent int    `json:"share_percent,omitempty"`
+}
+
+type FareSplitResponse struct {
+	RideID       string             `json:"ride_id"`
+	RideNumber   string             `json:"ride_number"`
+	OwnerID      string             `json:"owner_id"`
+	Participants []models.FareSplit `json:"participants"`
+}
@@ -9,8 +9,16 @@ type CreateRideRequest struct {
 	DestinationLongitude float64 `json:"destination_longitude"`
 	DestinationAddress   string  `json:"destination_address"`
 	RideType             string  `json:"ride_type"`
+	PromoCode            string  `json:"promo_code,omitempty"`
+	PaymentMethod        string  `json:"payment_method,omitempty"`
 }
 
 type CancelRideRequest struct {
 	Reason string `json:"reason"`
 }
+
+type ChangeDestinationRequest struct {
+	DestinationLatitude  float64 `json:"destination_latitude"`
+	DestinationLongitude float64 `json:"destination_longitude"`
+	DestinationAddress   string  `json:"destination_address"`
+}
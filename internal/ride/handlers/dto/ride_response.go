@@ -1,12 +1,39 @@
 package dto
 
+import (
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
+)
+
 type RideResponse struct {
-	RideID                   string  `json:"ride_id"`
-	RideNumber               string  `json:"ride_number"`
-	Status                   string  `json:"status"`
-	EstimatedFare            float64 `json:"estimated_fare"`
-	EstimatedDurationMinutes int     `json:"estimated_duration_minutes"`
-	EstimatedDistanceKm      float64 `json:"estimated_distance_km"`
+	RideID                   string             `json:"ride_id"`
+	RideNumber               string             `json:"ride_number"`
+	Status                   string             `json:"status"`
+	EstimatedFare            money.Money        `json:"estimated_fare"`
+	FareBreakdown            *pricing.Breakdown `json:"fare_breakdown,omitempty"`
+	EstimatedDurationMinutes int                `json:"estimated_duration_minutes"`
+	EstimatedDistanceKm      float64            `json:"estimated_distance_km"`
+	Currency                 string             `json:"currency,omitempty"`
+	ZoneSurcharge            money.Money        `json:"zone_surcharge"`
+	PickupAdjusted           bool               `json:"pickup_adjusted,omitempty"`
+	PickupLatitude           float64            `json:"pickup_latitude,omitempty"`
+	PickupLongitude          float64            `json:"pickup_longitude,omitempty"`
+	PromoCode                string             `json:"promo_code,omitempty"`
+}
+
+// QuoteResponse is the price of a ride that has not been requested yet
+type QuoteResponse struct {
+	RideType                 string             `json:"ride_type"`
+	EstimatedFare            money.Money        `json:"estimated_fare"`
+	FareBreakdown            *pricing.Breakdown `json:"fare_breakdown,omitempty"`
+	EstimatedDurationMinutes int                `json:"estimated_duration_minutes"`
+	EstimatedDistanceKm      float64            `json:"estimated_distance_km"`
+	Currency                 string             `json:"currency,omitempty"`
+	ZoneSurcharge            money.Money        `json:"zone_surcharge"`
+	PickupAdjusted           bool               `json:"pickup_adjusted,omitempty"`
+	PickupLatitude           float64            `json:"pickup_latitude,omitempty"`
+	PickupLongitude          float64            `json:"pickup_longitude,omitempty"`
+	PromoCode                string             `json:"promo_code,omitempty"`
 }
 
 type CancelRideResponse struct {
@@ -15,3 +42,17 @@ type CancelRideResponse struct {
 	CancelledAt string `json:"cancelled_at"`
 	Message     string `json:"message"`
 }
+
+type ChangeDestinationResponse struct {
+	RideID                   string             `json:"ride_id"`
+	Status                   string             `json:"status"`
+	DestinationLatitude      float64            `json:"destination_latitude"`
+	DestinationLongitude     float64            `json:"destination_longitude"`
+	DestinationAddress       string             `json:"destination_address"`
+	EstimatedFare            money.Money        `json:"estimated_fare"`
+	FareBreakdown            *pricing.Breakdown `json:"fare_breakdown,omitempty"`
+	EstimatedDurationMinutes int                `json:"estimated_duration_minutes"`
+	EstimatedDistanceKm      float64            `json:"estimated_distance_km"`
+	EstimatedArrival         string             `json:"estimated_arrival"`
+	Currency                 string             `json:"currency,omitempty"`
+}
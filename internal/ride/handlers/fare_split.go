@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/ride/handlers/dto"
+	"ride-hail/internal/ride/handlers/middleware"
+	"ride-hail/internal/ride/service"
+)
+
+// SplitFare invites passengers to split the fare of the caller's ride.
+// The invited passengers are told over their WebSocket.
+func (h *RideHandler) SplitFare(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	rideID := r.PathValue("ride_id")
+	if rideID == "" {
+		http.Error(w, "ride_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.SplitFareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	invites := make([]models.SplitInvite, 0, len(req.Participants))
+	for _, p := range req.Participants {
+		invites = append(invites, models.SplitInvite{PassengerID: p.PassengerID, Email: p.Email, SharePercent: p.SharePercent})
+	}
+
+	ride, splits, err := h.service.InviteToSplit(r.Context(), middleware.UserID(r.Context()), rideID, invites)
+	if err != nil {
+		writeSplitError(w, err)
+		return
+	}
+	notifySplitInvites(ride, splits)
+
+	writeFareSplit(w, ride, splits)
+}
+
+// GetFareSplit shows who was invited to split the fare and who accepted.
+func (h *RideHandler) GetFareSplit(w http.ResponseWriter, r *http.Request) {
+	rideID := r.PathValue("ride_id")
+	if rideID == "" {
+		http.Error(w, "ride_id is required", http.StatusBadRequest)
+		return
+	}
+
+	ride, splits, err := h.service.FareSplit(r.Context(), middleware.UserID(r.Context()), rideID)
+	if err != nil {
+		writeSplitError(w, err)
+		return
+	}
+
+	writeFareSplit(w, ride, splits)
+}
+
+// AcceptFareSplit and DeclineFareSplit answer the caller's invitation to split the fare.
+func (h *RideHandler) AcceptFareSplit(w http.ResponseWriter, r *http.Request) {
+	h.respondToSplit(w, r, true)
+}
+
+func (h *RideHandler) DeclineFareSplit(w http.ResponseWriter, r *http.Request) {
+	h.respondToSplit(w, r, false)
+}
+
+func (h *RideHandler) respondToSplit(w http.ResponseWriter, r *http.Request, accept bool) {
+	rideID := r.PathValue("ride_id")
+	if rideID == "" {
+		http.Error(w, "ride_id is required", http.StatusBadRequest)
+		return
+	}
+
+	ride, split, err := h.service.RespondToSplit(r.Context(), middleware.UserID(r.Context()), rideID, accept)
+	if err != nil {
+		writeSplitError(w, err)
+		return
+	}
+	notifySplitResponse(ride, split)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(split)
+}
+
+func writeFareSplit(w http.ResponseWriter, ride models.Ride, splits []models.FareSplit) {
+	if splits == nil {
+		splits = []models.FareSplit{}
+	}
+	resp := dto.FareSplitResponse{
+		RideID:       ride.ID,
+		RideNumber:   ride.RideNumber,
+		OwnerID:      ride.PassengerID,
+		Participants: splits,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writeSplitError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrRideNotFound), errors.Is(err, models.ErrSplitNotFound), errors.Is(err, models.ErrPassengerNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrRideNotOwned):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrRideNotActive), errors.Is(err, service.ErrSplitNotAllowed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, service.ErrInvalidSplit):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// notifySplitInvites tells the invited passengers who are connected about the invitation.
+func notifySplitInvites(ride models.Ride, splits []models.FareSplit) {
+	for _, split := range splits {
+		if split.Status != models.SplitInvited {
+			continue
+		}
+		_ = PassengerHub.SendJSONToUser(split.PassengerID, map[string]any{
+			"type":          "fare_split_invite",
+			"ride_id":       ride.ID,
+			"ride_number":   ride.RideNumber,
+			"owner_id":      ride.PassengerID,
+			"share_percent": split.SharePercent,
+		})
+	}
+}
+
+// notifySplitResponse tells the ride owner that an invited passenger answered.
+func notifySplitResponse(ride models.Ride, split models.FareSplit) {
+	_ = PassengerHub.SendJSONToUser(ride.PassengerID, map[string]any{
+		"type":         "fare_split_response",
+		"ride_id":      ride.ID,
+		"passenger_id": split.PassengerID,
+		"status":       split.Status,
+	})
+}
@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
 	"ride-hail/internal/ride/domain/models"
 	"ride-hail/internal/ride/handlers/dto"
+	"ride-hail/internal/ride/handlers/middleware"
 	"ride-hail/internal/ride/service"
+	"ride-hail/internal/shared/money"
 )
 
 type RideHandler struct {
@@ -29,31 +32,13 @@ func (h *RideHandler) CreateRide(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Default to ECONOMY if not specified
-	vehicleType := models.VehicleType(req.RideType)
-	if !vehicleType.IsValid() {
-		vehicleType = models.VehicleTypeEconomy
-	}
-
-	// Create the ride command
-	cmd := models.CreateRideCommand{
-		PassengerID: req.PassengerID,
-		VehicleType: vehicleType,
-		Pickup: models.Location{
-			Latitude:  req.PickupLatitude,
-			Longitude: req.PickupLongitude,
-			Address:   req.PickupAddress,
-		},
-		Destination: models.Location{
-			Latitude:  req.DestinationLatitude,
-			Longitude: req.DestinationLongitude,
-			Address:   req.DestinationAddress,
-		},
-	}
-
 	// Call the service to create the ride
-	ride, err := h.service.CreateRide(r.Context(), cmd)
+	ride, err := h.service.CreateRide(r.Context(), createRideCommand(req))
 	if err != nil {
+		if errors.Is(err, service.ErrTariffsNotLoaded) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -63,9 +48,20 @@ func (h *RideHandler) CreateRide(w http.ResponseWriter, r *http.Request) {
 		RideID:                   ride.ID,
 		RideNumber:               ride.RideNumber,
 		Status:                   string(ride.Status),
-		EstimatedFare:            getFloat(ride.EstimatedFare),
+		EstimatedFare:            getMoney(ride.EstimatedFare),
+		FareBreakdown:            ride.FareBreakdown,
 		EstimatedDurationMinutes: ride.EstimatedDurationMinutes,
 		EstimatedDistanceKm:      ride.EstimatedDistanceKm,
+		Currency:                 ride.Currency,
+		ZoneSurcharge:            ride.ZoneSurcharge,
+		PickupAdjusted:           ride.PickupAdjusted,
+		PromoCode:                ride.PromoCode,
+	}
+
+	// Pickup was moved out of a no-pickup zone: tell the client where to go
+	if ride.PickupAdjusted {
+		resp.PickupLatitude = ride.PickupLocation.Latitude
+		resp.PickupLongitude = ride.PickupLocation.Longitude
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -73,6 +69,68 @@ func (h *RideHandler) CreateRide(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// QuoteRide prices a ride, with an optional promo code, without requesting it
+func (h *RideHandler) QuoteRide(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	var req dto.CreateRideRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	ride, err := h.service.Quote(r.Context(), createRideCommand(req))
+	if err != nil {
+		if errors.Is(err, service.ErrTariffsNotLoaded) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := dto.QuoteResponse{
+		RideType:                 ride.RideType(),
+		EstimatedFare:            getMoney(ride.EstimatedFare),
+		FareBreakdown:            ride.FareBreakdown,
+		EstimatedDurationMinutes: ride.EstimatedDurationMinutes,
+		EstimatedDistanceKm:      ride.EstimatedDistanceKm,
+		Currency:                 ride.Currency,
+		ZoneSurcharge:            ride.ZoneSurcharge,
+		PickupAdjusted:           ride.PickupAdjusted,
+		PromoCode:                ride.PromoCode,
+	}
+	if ride.PickupAdjusted {
+		resp.PickupLatitude = ride.PickupLocation.Latitude
+		resp.PickupLongitude = ride.PickupLocation.Longitude
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// createRideCommand maps the body of POST /rides and POST /rides/quote.
+// The service defaults an empty ride type to ECONOMY and checks it against the vehicle classes.
+func createRideCommand(req dto.CreateRideRequest) models.CreateRideCommand {
+	return models.CreateRideCommand{
+		PassengerID: req.PassengerID,
+		VehicleType: models.VehicleType(req.RideType),
+		Pickup: models.Location{
+			Latitude:  req.PickupLatitude,
+			Longitude: req.PickupLongitude,
+			Address:   req.PickupAddress,
+		},
+		Destination: models.Location{
+			Latitude:  req.DestinationLatitude,
+			Longitude: req.DestinationLongitude,
+			Address:   req.DestinationAddress,
+		},
+		PromoCode:     req.PromoCode,
+		PaymentMethod: req.PaymentMethod,
+	}
+}
+
 // CloseRide handles the cancellation of a ride
 func (h *RideHandler) CloseRide(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
@@ -92,6 +150,10 @@ func (h *RideHandler) CloseRide(w http.ResponseWriter, r *http.Request) {
 
 	// Call the service to close the ride
 	if err := h.service.CloseRide(r.Context(), rideID, req.Reason); err != nil {
+		if errors.Is(err, service.ErrRideNotCancellable) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -109,9 +171,64 @@ func (h *RideHandler) CloseRide(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
-func getFloat(f *float64) float64 {
-	if f == nil {
-		return 0
+// ChangeDestination handles a passenger changing the destination of an active ride
+func (h *RideHandler) ChangeDestination(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	rideID := r.PathValue("ride_id")
+	if rideID == "" {
+		http.Error(w, "ride_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.ChangeDestinationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	destination := models.Location{
+		Latitude:  req.DestinationLatitude,
+		Longitude: req.DestinationLongitude,
+		Address:   req.DestinationAddress,
+	}
+	ride, eta, err := h.service.ChangeDestination(r.Context(), middleware.UserID(r.Context()), rideID, destination)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRideNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, service.ErrRideNotOwned):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, service.ErrRideNotActive), errors.Is(err, service.ErrSharedRideRoute):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	resp := dto.ChangeDestinationResponse{
+		RideID:                   ride.ID,
+		Status:                   string(ride.Status),
+		DestinationLatitude:      ride.DestinationLocation.Latitude,
+		DestinationLongitude:     ride.DestinationLocation.Longitude,
+		DestinationAddress:       ride.DestinationLocation.Address,
+		EstimatedFare:            getMoney(ride.EstimatedFare),
+		FareBreakdown:            ride.FareBreakdown,
+		EstimatedDurationMinutes: ride.EstimatedDurationMinutes,
+		EstimatedDistanceKm:      ride.EstimatedDistanceKm,
+		EstimatedArrival:         eta.UTC().Format(time.RFC3339),
+		Currency:                 ride.Currency,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func getMoney(m *money.Money) money.Money {
+	if m == nil {
+		return money.Money{}
 	}
-	return *f
+	return *m
 }
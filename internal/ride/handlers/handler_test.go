@@ -7,9 +7,13 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"ride-hail/internal/ride/domain/models"
 	"ride-hail/internal/ride/service"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
+	"ride-hail/internal/shared/promo"
 )
 
 // Mock repository for testing
@@ -18,7 +22,7 @@ type mockRideRepo struct {
 	getRideFunc      func(ctx context.Context, id string) (models.Ride, error)
 	listByStatusFunc func(ctx context.Context, passengerID, status string) ([]models.Ride, error)
 	updateStatusFunc func(ctx context.Context, rideID, status string) error
-	closeRideFunc    func(ctx context.Context, id, reason string) error
+	closeRideFunc    func(ctx context.Context, id, reason string) (string, error)
 }
 
 func (m *mockRideRepo) CreateRide(ctx context.Context, ride *models.Ride) error {
@@ -54,15 +58,59 @@ func (m *mockRideRepo) UpdateStatus(ctx context.Context, rideID, status string)
 	return nil
 }
 
-func (m *mockRideRepo) CloseRide(ctx context.Context, id, reason string) error {
+func (m *mockRideRepo) CloseRide(ctx context.Context, id, reason string) (string, error) {
 	if m.closeRideFunc != nil {
 		return m.closeRideFunc(ctx, id, reason)
 	}
+	return "", nil
+}
+
+func (m *mockRideRepo) ListActiveRidesByDriver(ctx context.Context, driverID string) ([]models.Ride, error) {
+	return nil, nil
+}
+
+func (m *mockRideRepo) UpdatePickup(ctx context.Context, ride *models.Ride) error {
+	return nil
+}
+
+func (m *mockRideRepo) UpdateDestination(ctx context.Context, ride *models.Ride, previous models.Location) error {
+	return nil
+}
+
+func (m *mockRideRepo) GetDriverLocation(ctx context.Context, driverID string) (models.Location, error) {
+	return models.Location{}, models.ErrRideNotFound
+}
+
+func (m *mockRideRepo) GetPromoCampaign(ctx context.Context, code string) (promo.Campaign, error) {
+	return promo.Campaign{}, promo.ErrCodeNotFound
+}
+
+func (m *mockRideRepo) CountPromoRedemptions(ctx context.Context, campaignID, passengerID string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockRideRepo) FindPassengerByEmail(ctx context.Context, email string) (string, error) {
+	return "", models.ErrPassengerNotFound
+}
+
+func (m *mockRideRepo) ListFareSplits(ctx context.Context, rideID string) ([]models.FareSplit, error) {
+	return nil, nil
+}
+
+func (m *mockRideRepo) SaveFareSplits(ctx context.Context, rideID string, splits []models.FareSplit) ([]models.FareSplit, error) {
+	return splits, nil
+}
+
+func (m *mockRideRepo) RespondToFareSplit(ctx context.Context, rideID, passengerID, status string) (models.FareSplit, error) {
+	return models.FareSplit{}, models.ErrSplitNotFound
+}
+
+func (m *mockRideRepo) SavePassengerLocation(ctx context.Context, passengerID string, loc models.Location) error {
 	return nil
 }
 
 func TestNewRideHandler(t *testing.T) {
-	svc := service.NewRideService(&mockRideRepo{}, nil, nil, []byte("secret"))
+	svc := service.NewRideService(&mockRideRepo{}, nil, nil, nil, nil, nil, []byte("secret"))
 	h := NewRideHandler(svc)
 	if h == nil {
 		t.Fatal("expected non-nil handler")
@@ -81,9 +129,24 @@ func TestCreateRide_InvalidJSON(t *testing.T) {
 	}
 }
 
+func newCatalog() *pricing.Catalog {
+	catalog := pricing.NewCatalog(nil)
+	catalog.Set(
+		[]pricing.VehicleClass{{Code: "ECONOMY", Capacity: 4, IsActive: true}},
+		[]pricing.Tariff{{
+			VehicleType:   "ECONOMY",
+			BaseFare:      money.New(50000, ""),
+			RatePerKm:     money.New(10000, ""),
+			RatePerMin:    money.New(5000, ""),
+			EffectiveFrom: time.Unix(0, 0),
+		}},
+	)
+	return catalog
+}
+
 func TestCreateRide_Success(t *testing.T) {
 	repo := &mockRideRepo{}
-	svc := service.NewRideService(repo, nil, nil, []byte("secret"))
+	svc := service.NewRideService(repo, nil, newCatalog(), nil, nil, nil, []byte("secret"))
 	h := NewRideHandler(svc)
 
 	body := `{
@@ -109,7 +172,7 @@ func TestCreateRide_Success(t *testing.T) {
 
 func TestCreateRide_InvalidCoordinates(t *testing.T) {
 	repo := &mockRideRepo{}
-	svc := service.NewRideService(repo, nil, nil, []byte("secret"))
+	svc := service.NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 	h := NewRideHandler(svc)
 
 	body := `{
@@ -136,7 +199,7 @@ func TestCreateRide_ServiceError(t *testing.T) {
 			return errors.New("db error")
 		},
 	}
-	svc := service.NewRideService(repo, nil, nil, []byte("secret"))
+	svc := service.NewRideService(repo, nil, newCatalog(), nil, nil, nil, []byte("secret"))
 	h := NewRideHandler(svc)
 
 	body := `{
@@ -183,7 +246,7 @@ func TestCloseRide_InvalidJSON(t *testing.T) {
 
 func TestCloseRide_Success(t *testing.T) {
 	repo := &mockRideRepo{}
-	svc := service.NewRideService(repo, nil, nil, []byte("secret"))
+	svc := service.NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 	h := NewRideHandler(svc)
 
 	body := `{"reason": "changed my mind"}`
@@ -200,11 +263,11 @@ func TestCloseRide_Success(t *testing.T) {
 
 func TestCloseRide_ServiceError(t *testing.T) {
 	repo := &mockRideRepo{
-		closeRideFunc: func(ctx context.Context, id, reason string) error {
-			return errors.New("db error")
+		closeRideFunc: func(ctx context.Context, id, reason string) (string, error) {
+			return "", errors.New("db error")
 		},
 	}
-	svc := service.NewRideService(repo, nil, nil, []byte("secret"))
+	svc := service.NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 	h := NewRideHandler(svc)
 
 	body := `{"reason": "changed my mind"}`
@@ -218,3 +281,65 @@ func TestCloseRide_ServiceError(t *testing.T) {
 		t.Fatalf("expected status %d, got %d", http.StatusInternalServerError, rr.Code)
 	}
 }
+
+func TestRenderReceipt(t *testing.T) {
+	var fare pricing.Breakdown
+	fare.Add(pricing.ItemBase, money.New(50000, "KZT"))
+	fare.Add(pricing.ItemDistance, money.New(127350, "KZT"))
+	fare.Add(pricing.ItemDiscount, money.New(-10000, "KZT"))
+
+	receipt := models.Receipt{
+		RideNumber:  "RIDE-1",
+		VehicleType: models.VehicleTypeEconomy,
+		Pickup:      models.Location{Address: "Almaty Central Park"},
+		Destination: models.Location{Address: "Kok-Tobe <Hill>"},
+		EndedAt:     time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		Fare:        fare.WithCurrency("KZT"),
+	}
+
+	t.Run("text", func(t *testing.T) {
+		body, contentType, err := renderReceipt(receipt, "text")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(contentType, "text/plain") {
+			t.Fatalf("unexpected content type %s", contentType)
+		}
+		for _, want := range []string{
+			"Base fare" + strings.Repeat(" ", 25) + "500.00\n",
+			"Discount" + strings.Repeat(" ", 25) + "-100.00\n",
+			"Total" + strings.Repeat(" ", 24) + "1673.50 KZT\n",
+		} {
+			if !strings.Contains(string(body), want) {
+				t.Fatalf("expected %q in\n%s", want, body)
+			}
+		}
+	})
+
+	t.Run("html", func(t *testing.T) {
+		body, contentType, err := renderReceipt(receipt, "html")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(contentType, "text/html") {
+			t.Fatalf("unexpected content type %s", contentType)
+		}
+		if !strings.Contains(string(body), "Kok-Tobe &lt;Hill&gt;") || !strings.Contains(string(body), "1673.50 KZT") {
+			t.Fatalf("expected escaped address and total in\n%s", body)
+		}
+	})
+	t.Run("split fare", func(t *testing.T) {
+		split := receipt
+		share := money.New(83675, "KZT")
+		split.Shares = []models.FareShare{{PassengerID: "passenger-1", Amount: share, Owner: true}, {PassengerID: "passenger-2", Amount: share}}
+		split.Share = &share
+
+		body, _, err := renderReceipt(split, "text")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "Your share (2 passengers)" + strings.Repeat(" ", 5) + "836.75 KZT\n"; !strings.Contains(string(body), want) {
+			t.Fatalf("expected %q in\n%s", want, body)
+		}
+	})
+}
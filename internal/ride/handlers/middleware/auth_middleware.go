@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -18,6 +19,16 @@ type UserClaims struct {
 	jwt.RegisteredClaims
 }
 
+type contextKey string
+
+const userIDKey contextKey = "user_id"
+
+// UserID returns the ID of the user authenticated by PassengerAuthMiddleware.
+func UserID(ctx context.Context) string {
+	id, _ := ctx.Value(userIDKey).(string)
+	return id
+}
+
 type errorMessage struct {
 	StatusCode int    `json:"status_code"`
 	Message    string `json:"message"`
@@ -164,6 +175,6 @@ func PassengerAuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userIDKey, claims.UserId)))
 	}
 }
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"net/http"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/ride/handlers/middleware"
+	"ride-hail/internal/ride/service"
+)
+
+const receiptHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Receipt {{.RideNumber}}</title>
+<style>
+body { font-family: sans-serif; max-width: 480px; margin: 2em auto; color: #222; }
+table { width: 100%; border-collapse: collapse; }
+td { padding: 4px 0; }
+td.amount { text-align: right; }
+tr.total td { border-top: 1px solid #222; font-weight: bold; }
+.muted { color: #777; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>Receipt</h1>
+<p>Ride {{.RideNumber}} &middot; {{.VehicleType}}{{if .Reason}}<br>Cancelled: {{.Reason}}{{end}}</p>
+<p>From: {{.Pickup.Address}}<br>To: {{.Destination.Address}}</p>
+<p class="muted">{{if .StartedAt}}{{date .StartedAt}} &ndash; {{end}}{{date .EndedAt}}</p>
+<table>
+{{range .Fare.Items}}<tr><td>{{.Label}}</td><td class="amount">{{.Amount.Decimal}}</td></tr>
+{{end}}<tr class="total"><td>Total</td><td class="amount">{{.Fare.Total.Decimal}} {{.Fare.Currency}}</td></tr>
+{{if .Share}}<tr class="total"><td>Your share ({{len .Shares}} passengers)</td><td class="amount">{{.Share.Decimal}} {{.Fare.Currency}}</td></tr>
+{{end}}</table>
+<p class="muted">Issued {{date .IssuedAt}}</p>
+</body>
+</html>
+`
+
+const receiptText = `RECEIPT
+Ride {{.RideNumber}} ({{.VehicleType}}){{if .Reason}}
+Cancelled: {{.Reason}}{{end}}
+From: {{.Pickup.Address}}
+To:   {{.Destination.Address}}
+{{if .StartedAt}}{{date .StartedAt}} - {{end}}{{date .EndedAt}}
+
+{{range .Fare.Items}}{{line .Label .Amount.Decimal}}
+{{end}}{{rule}}
+{{line "Total" (print .Fare.Total.Decimal " " .Fare.Currency)}}{{if .Share}}
+{{line (print "Your share (" (len .Shares) " passengers)") (print .Share.Decimal " " .Fare.Currency)}}{{end}}
+
+Issued {{date .IssuedAt}}
+`
+
+// receiptWidth is the width of the plain text receipt in characters.
+const receiptWidth = 40
+
+var receiptFuncs = map[string]any{
+	"date": func(v any) string {
+		switch t := v.(type) {
+		case time.Time:
+			return t.UTC().Format("2006-01-02 15:04 MST")
+		case *time.Time:
+			if t == nil {
+				return ""
+			}
+			return t.UTC().Format("2006-01-02 15:04 MST")
+		default:
+			return ""
+		}
+	},
+	"line": func(label, amount string) string {
+		return fmt.Sprintf("%-*s%s", receiptWidth-len(amount), label, amount)
+	},
+	"rule": func() string {
+		return strings.Repeat("-", receiptWidth)
+	},
+}
+
+var (
+	receiptHTMLTemplate = htmltemplate.Must(htmltemplate.New("receipt").Funcs(receiptFuncs).Parse(receiptHTML))
+	receiptTextTemplate = texttemplate.Must(texttemplate.New("receipt").Funcs(receiptFuncs).Parse(receiptText))
+)
+
+// renderReceipt renders the receipt as "html" or "text" and returns it with its content type.
+func renderReceipt(receipt models.Receipt, format string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	switch format {
+	case "text":
+		if err := receiptTextTemplate.Execute(&buf, receipt); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "text/plain; charset=utf-8", nil
+	default:
+		if err := receiptHTMLTemplate.Execute(&buf, receipt); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "text/html; charset=utf-8", nil
+	}
+}
+
+// Receipt serves the receipt of a finished ride as a download. The format is picked
+// with ?format=html|text, falling back to the Accept header and then to HTML.
+func (h *RideHandler) Receipt(w http.ResponseWriter, r *http.Request) {
+	rideID := r.PathValue("ride_id")
+	if rideID == "" {
+		http.Error(w, "ride_id is required", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" && strings.HasPrefix(r.Header.Get("Accept"), "text/plain") {
+		format = "text"
+	}
+	if format != "" && format != "html" && format != "text" {
+		http.Error(w, "format must be html or text", http.StatusBadRequest)
+		return
+	}
+
+	receipt, err := h.service.Receipt(r.Context(), middleware.UserID(r.Context()), rideID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRideNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, service.ErrRideNotOwned):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, service.ErrReceiptNotReady):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	body, contentType, err := renderReceipt(receipt, format)
+	if err != nil {
+		http.Error(w, "failed to render receipt", http.StatusInternalServerError)
+		return
+	}
+
+	ext := "html"
+	if format == "text" {
+		ext = "txt"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="receipt-%s.%s"`, receipt.RideNumber, ext))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
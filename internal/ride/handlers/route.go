@@ -4,17 +4,25 @@ import (
 	"net/http"
 
 	"ride-hail/internal/ride/handlers/middleware"
+	"ride-hail/internal/shared/chat"
 )
 
-func RegisterRoutes(handler *RideHandler, secretKey []byte) http.Handler {
+func RegisterRoutes(handler *RideHandler, chatSvc *chat.Service, secretKey []byte) http.Handler {
 	mux := http.NewServeMux()
 
 	// REST API routes with passenger authentication
 	mux.Handle("POST /rides", middleware.JsonMiddleware(middleware.PassengerAuthMiddleware(handler.CreateRide)))
+	mux.Handle("POST /rides/quote", middleware.JsonMiddleware(middleware.PassengerAuthMiddleware(handler.QuoteRide)))
 	mux.Handle("POST /rides/{ride_id}/cancel", middleware.JsonMiddleware(middleware.PassengerAuthMiddleware(handler.CloseRide)))
+	mux.Handle("PATCH /rides/{ride_id}/destination", middleware.JsonMiddleware(middleware.PassengerAuthMiddleware(handler.ChangeDestination)))
+	mux.Handle("GET /rides/{ride_id}/receipt", middleware.PassengerAuthMiddleware(handler.Receipt))
+	mux.Handle("POST /rides/{ride_id}/split", middleware.JsonMiddleware(middleware.PassengerAuthMiddleware(handler.SplitFare)))
+	mux.Handle("GET /rides/{ride_id}/split", middleware.PassengerAuthMiddleware(handler.GetFareSplit))
+	mux.Handle("POST /rides/{ride_id}/split/accept", middleware.PassengerAuthMiddleware(handler.AcceptFareSplit))
+	mux.Handle("POST /rides/{ride_id}/split/decline", middleware.PassengerAuthMiddleware(handler.DeclineFareSplit))
 
 	// WebSocket route for passengers
-	mux.HandleFunc("GET /ws/passengers/{passenger_id}", PassengerWSHandler(secretKey))
+	mux.HandleFunc("GET /ws/passengers/{passenger_id}", PassengerWSHandler(handler.service, chatSvc, secretKey))
 
 	return mux
 }
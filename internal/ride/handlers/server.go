@@ -5,10 +5,13 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+
+	"ride-hail/internal/shared/chat"
 )
 
 type Server struct {
 	rideHandler  *RideHandler
+	chatService  *chat.Service
 	serverConfig *ServerConfig
 	secretKey    []byte
 
@@ -18,9 +21,10 @@ type Server struct {
 	cancel context.CancelFunc
 }
 
-func NewServer(rideHandler *RideHandler, serverConfig *ServerConfig, secretKey []byte) *Server {
+func NewServer(rideHandler *RideHandler, chatService *chat.Service, serverConfig *ServerConfig, secretKey []byte) *Server {
 	return &Server{
 		rideHandler:  rideHandler,
+		chatService:  chatService,
 		serverConfig: serverConfig,
 		secretKey:    secretKey,
 	}
@@ -31,7 +35,7 @@ func (s *Server) Start(ctx context.Context) error {
 
 	s.server = &http.Server{
 		Addr:    s.serverConfig.GetAddr(),
-		Handler: RegisterRoutes(s.rideHandler, s.secretKey),
+		Handler: RegisterRoutes(s.rideHandler, s.chatService, s.secretKey),
 		BaseContext: func(l net.Listener) context.Context {
 			return s.ctx
 		},
@@ -1,12 +1,21 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/ride/service"
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/chat"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
 	"ride-hail/internal/shared/websocket"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -38,6 +47,12 @@ type RideStatusUpdate struct {
 	Status     string      `json:"status"`
 	Message    string      `json:"message,omitempty"`
 	DriverInfo *DriverInfo `json:"driver_info,omitempty"`
+	// PickupPIN is sent with the MATCHED update; the passenger tells it to the driver at pickup
+	PickupPIN string `json:"pickup_pin,omitempty"`
+	// WaitingFreeUntil is sent with the ARRIVED update; waiting is charged after it
+	WaitingFreeUntil string             `json:"waiting_free_until,omitempty"`
+	FinalFare        *money.Money       `json:"final_fare,omitempty"`
+	FareBreakdown    *pricing.Breakdown `json:"fare_breakdown,omitempty"`
 }
 
 // DriverInfo represents driver information sent to passengers.
@@ -72,8 +87,30 @@ type Location struct {
 	Longitude float64 `json:"lng"`
 }
 
+// PassengerCommand is a typed request sent by an authenticated passenger.
+type PassengerCommand struct {
+	Type      string  `json:"type"`
+	RequestID string  `json:"request_id"`
+	RideID    string  `json:"ride_id"`
+	Reason    string  `json:"reason,omitempty"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Address   string  `json:"address,omitempty"`
+	Body      string  `json:"body,omitempty"`
+}
+
+// CommandReply acknowledges or rejects a passenger command.
+type CommandReply struct {
+	Type      string `json:"type"`
+	RequestID string `json:"request_id"`
+	Command   string `json:"command"`
+	RideID    string `json:"ride_id,omitempty"`
+	Data      any    `json:"data,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
 // PassengerWSHandler handles WebSocket connections for passengers.
-func PassengerWSHandler(secretKey []byte) http.HandlerFunc {
+func PassengerWSHandler(svc *service.RideService, chatSvc *chat.Service, secretKey []byte) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		passengerID := r.PathValue("passenger_id")
 		if passengerID == "" {
@@ -106,7 +143,7 @@ func PassengerWSHandler(secretKey []byte) http.HandlerFunc {
 		}()
 
 		// Handle authentication in the first message
-		messageHandler := createPassengerMessageHandler(client, secretKey, authDone)
+		messageHandler := createPassengerMessageHandler(r.Context(), svc, chatSvc, secretKey, authDone)
 
 		PassengerHub.Register(client)
 
@@ -115,7 +152,7 @@ func PassengerWSHandler(secretKey []byte) http.HandlerFunc {
 	}
 }
 
-func createPassengerMessageHandler(client *websocket.Client, secretKey []byte, authDone chan bool) websocket.MessageHandler {
+func createPassengerMessageHandler(ctx context.Context, svc *service.RideService, chatSvc *chat.Service, secretKey []byte, authDone chan bool) websocket.MessageHandler {
 	return func(c *websocket.Client, message []byte) {
 		var msg map[string]any
 		if err := json.Unmarshal(message, &msg); err != nil {
@@ -131,6 +168,11 @@ func createPassengerMessageHandler(client *websocket.Client, secretKey []byte, a
 		switch msgType {
 		case "auth":
 			handlePassengerAuth(c, msg, secretKey, authDone)
+			if c.IsAuthenticated() && chatSvc != nil {
+				if err := chatSvc.Replay(ctx, c.UserID); err != nil {
+					slog.Error("failed to replay chat", "passenger_id", c.UserID, "error", err.Error())
+				}
+			}
 		case "ping":
 			_ = c.SendJSON(map[string]any{"type": "pong"})
 		default:
@@ -141,11 +183,114 @@ func createPassengerMessageHandler(client *websocket.Client, secretKey []byte, a
 				})
 				return
 			}
-			// Handle other message types if needed
+			handlePassengerCommand(ctx, c, svc, chatSvc, message)
 		}
 	}
 }
 
+// handlePassengerCommand runs a passenger command and replies with command_ack or command_error
+// carrying the request ID, generated when the client did not send one.
+func handlePassengerCommand(ctx context.Context, c *websocket.Client, svc *service.RideService, chatSvc *chat.Service, message []byte) {
+	var cmd PassengerCommand
+	_ = json.Unmarshal(message, &cmd)
+	if cmd.RequestID == "" {
+		cmd.RequestID = fmt.Sprintf("req-%d", time.Now().UnixNano())
+	}
+
+	reply := CommandReply{
+		Type:      "command_ack",
+		RequestID: cmd.RequestID,
+		Command:   cmd.Type,
+		RideID:    cmd.RideID,
+	}
+
+	var err error
+	switch {
+	case svc == nil:
+		err = errors.New("commands are not available")
+	case chatSvc == nil && strings.HasPrefix(cmd.Type, "chat_"):
+		err = errors.New("chat is not available")
+	case cmd.RideID == "":
+		err = errors.New("ride_id is required")
+	default:
+		reply.Data, err = runPassengerCommand(ctx, svc, chatSvc, c.UserID, cmd)
+	}
+
+	if err != nil {
+		reply.Type = "command_error"
+		reply.Message = err.Error()
+	}
+	_ = c.SendJSON(reply)
+}
+
+func runPassengerCommand(ctx context.Context, svc *service.RideService, chatSvc *chat.Service, passengerID string, cmd PassengerCommand) (any, error) {
+	location := models.Location{
+		Latitude:  cmd.Latitude,
+		Longitude: cmd.Longitude,
+		Address:   cmd.Address,
+	}
+
+	switch cmd.Type {
+	case "cancel_ride":
+		ride, err := svc.CancelRide(ctx, passengerID, cmd.RideID, cmd.Reason)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"status":       ride.Status,
+			"cancelled_at": time.Now().Format(time.RFC3339),
+		}, nil
+
+	case "update_pickup":
+		ride, err := svc.UpdatePickup(ctx, passengerID, cmd.RideID, location)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"pickup_location":            ride.PickupLocation,
+			"pickup_adjusted":            ride.PickupAdjusted,
+			"estimated_fare":             getMoney(ride.EstimatedFare),
+			"currency":                   ride.Currency,
+			"estimated_distance_km":      ride.EstimatedDistanceKm,
+			"estimated_duration_minutes": ride.EstimatedDurationMinutes,
+		}, nil
+
+	case "update_destination":
+		ride, eta, err := svc.ChangeDestination(ctx, passengerID, cmd.RideID, location)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"destination_location":       ride.DestinationLocation,
+			"estimated_fare":             getMoney(ride.EstimatedFare),
+			"currency":                   ride.Currency,
+			"estimated_distance_km":      ride.EstimatedDistanceKm,
+			"estimated_duration_minutes": ride.EstimatedDurationMinutes,
+			"estimated_arrival":          eta.UTC().Format(time.RFC3339),
+		}, nil
+
+	case "passenger_location":
+		return nil, svc.ShareLocation(ctx, passengerID, cmd.RideID, location)
+
+	case "split_accept", "split_decline":
+		ride, split, err := svc.RespondToSplit(ctx, passengerID, cmd.RideID, cmd.Type == "split_accept")
+		if err != nil {
+			return nil, err
+		}
+		notifySplitResponse(ride, split)
+		return split, nil
+
+	case "chat_message":
+		return chatSvc.Send(ctx, passengerID, cmd.RideID, cmd.Body)
+
+	case "chat_read":
+		return nil, chatSvc.MarkRead(ctx, passengerID, cmd.RideID)
+
+	default:
+		return nil, fmt.Errorf("unknown command %q", cmd.Type)
+	}
+}
+
 func handlePassengerAuth(client *websocket.Client, msg map[string]any, secretKey []byte, authDone chan bool) {
 	tokenStr, _ := msg["token"].(string)
 	tokenStr = strings.TrimPrefix(tokenStr, "Bearer ")
@@ -215,14 +360,75 @@ func SendDriverLocationToPassenger(passengerID string, update DriverLocationUpda
 	return PassengerHub.SendJSONToUser(passengerID, update)
 }
 
+// PassengerNotifier delivers service events to passengers through PassengerHub.
+type PassengerNotifier struct{}
+
+// IsConnected reports whether the passenger has an authenticated WebSocket connection.
+func (PassengerNotifier) IsConnected(passengerID string) bool {
+	client, ok := PassengerHub.GetClient(passengerID)
+	return ok && client.IsAuthenticated()
+}
+
+// Send pushes any event to the passenger.
+func (PassengerNotifier) Send(passengerID string, event any) error {
+	return PassengerHub.SendJSONToUser(passengerID, event)
+}
+
+// SendDriverLocation pushes the driver position with the distance and ETA.
+func (PassengerNotifier) SendDriverLocation(passengerID string, loc models.DriverLocation) error {
+	return SendDriverLocationToPassenger(passengerID, DriverLocationUpdate{
+		RideID: loc.RideID,
+		DriverLocation: Location{
+			Latitude:  loc.Latitude,
+			Longitude: loc.Longitude,
+		},
+		EstimatedArrival:    loc.EstimatedArrival.UTC().Format(time.RFC3339),
+		DistanceToPickupKm:  loc.DistanceToPickupKm,
+		DistanceRemainingKm: loc.DistanceRemainingKm,
+	})
+}
+
+// SendRideMatched pushes the matched driver and the pickup PIN.
+func (PassengerNotifier) SendRideMatched(passengerID string, match models.RideMatch) error {
+	var driver *DriverInfo
+	if match.Driver != nil {
+		driver = &DriverInfo{
+			DriverID: match.Driver.DriverID,
+			Name:     match.Driver.Name,
+			Rating:   match.Driver.Rating,
+		}
+		if v := match.Driver.Vehicle; v != nil {
+			driver.Vehicle = &VehicleInfo{Make: v.Make, Model: v.Model, Color: v.Color, Plate: v.Plate}
+		}
+	}
+
+	return NotifyPassengerRideMatched(passengerID, match.RideID, match.RideNumber, driver, match.PickupPIN)
+}
+
+// SendRideStatus pushes a status change reported by the driver service.
+func (PassengerNotifier) SendRideStatus(passengerID string, update messages.RideStatusUpdate) error {
+	msg := RideStatusUpdate{
+		RideID:        update.RideID,
+		Status:        update.Status,
+		Message:       update.Message,
+		FinalFare:     update.FinalFare,
+		FareBreakdown: update.FareBreakdown,
+	}
+	if update.WaitingFreeUntil != nil {
+		msg.WaitingFreeUntil = update.WaitingFreeUntil.UTC().Format(time.RFC3339)
+	}
+	return SendRideStatusToPassenger(passengerID, msg)
+}
+
 // NotifyPassengerRideMatched notifies a passenger that their ride was matched.
-func NotifyPassengerRideMatched(passengerID, rideID, rideNumber string, driver *DriverInfo) error {
+func NotifyPassengerRideMatched(passengerID, rideID, rideNumber string, driver *DriverInfo, pickupPIN string) error {
 	return SendRideStatusToPassenger(passengerID, RideStatusUpdate{
 		Type:       "ride_status_update",
 		RideID:     rideID,
 		RideNumber: rideNumber,
 		Status:     "MATCHED",
 		DriverInfo: driver,
+		PickupPIN:  pickupPIN,
 	})
 }
 
@@ -246,12 +452,13 @@ func NotifyPassengerRideStarted(passengerID, rideID string) error {
 }
 
 // NotifyPassengerRideCompleted notifies a passenger that their ride completed.
-func NotifyPassengerRideCompleted(passengerID, rideID string, finalFare float64) error {
+func NotifyPassengerRideCompleted(passengerID, rideID string, finalFare money.Money) error {
 	return PassengerHub.SendJSONToUser(passengerID, map[string]any{
 		"type":       "ride_status_update",
 		"ride_id":    rideID,
 		"status":     "COMPLETED",
 		"final_fare": finalFare,
+		"currency":   finalFare.Currency,
 		"message":    "Your ride has been completed. Thank you!",
 	})
 }
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"ride-hail/internal/shared/cities"
+	"ride-hail/internal/shared/postgres"
+)
+
+type CityRepo struct {
+	db *postgres.Database
+}
+
+func NewCityRepo(db *postgres.Database) *CityRepo {
+	return &CityRepo{db: db}
+}
+
+// ListCities implements [cities.Loader].
+func (r *CityRepo) ListCities(ctx context.Context) ([]cities.City, error) {
+	query := `SELECT id, code, name, currency, timezone, service_area, is_default, is_active, created_at, updated_at
+	FROM cities`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []cities.City
+	for rows.Next() {
+		var city cities.City
+		var areaJSON []byte
+		if err := rows.Scan(
+			&city.ID,
+			&city.Code,
+			&city.Name,
+			&city.Currency,
+			&city.Timezone,
+			&areaJSON,
+			&city.IsDefault,
+			&city.IsActive,
+			&city.CreatedAt,
+			&city.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if len(areaJSON) > 0 {
+			if err := json.Unmarshal(areaJSON, &city.ServiceArea); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, city)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ride-hail/internal/ride/domain/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// FindPassengerByEmail returns the ID of the registered passenger with the email
+func (r *RideRepo) FindPassengerByEmail(ctx context.Context, email string) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx,
+		`SELECT id FROM users WHERE lower(email) = lower($1) AND role = 'PASSENGER'`,
+		email,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", models.ErrPassengerNotFound
+	}
+	return id, err
+}
+
+// ListFareSplits returns the passengers invited to split the ride's fare in the order they were invited
+func (r *RideRepo) ListFareSplits(ctx context.Context, rideID string) ([]models.FareSplit, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT ride_id, passenger_id, status, COALESCE(share_percent, 0), created_at, updated_at
+		FROM ride_fare_splits
+		WHERE ride_id = $1
+		ORDER BY created_at, passenger_id`,
+		rideID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var splits []models.FareSplit
+	for rows.Next() {
+		var s models.FareSplit
+		if err := rows.Scan(&s.RideID, &s.PassengerID, &s.Status, &s.SharePercent, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		splits = append(splits, s)
+	}
+	return splits, rows.Err()
+}
+
+// SaveFareSplits invites the passengers in one transaction. Only registered passengers
+// can be invited; a passenger who declined is invited again.
+func (r *RideRepo) SaveFareSplits(ctx context.Context, rideID string, splits []models.FareSplit) ([]models.FareSplit, error) {
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, s := range splits {
+		result, err := tx.Exec(ctx,
+			`INSERT INTO ride_fare_splits (ride_id, passenger_id, share_percent)
+			SELECT $1, u.id, NULLIF($3, 0)
+			FROM users u
+			WHERE u.id::text = $2 AND u.role = 'PASSENGER'
+			ON CONFLICT (ride_id, passenger_id) DO UPDATE
+			SET status = 'INVITED', share_percent = EXCLUDED.share_percent, updated_at = NOW()`,
+			rideID,
+			s.PassengerID,
+			s.SharePercent,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if result.RowsAffected() == 0 {
+			return nil, models.ErrPassengerNotFound
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return r.ListFareSplits(ctx, rideID)
+}
+
+// RespondToFareSplit accepts or declines the passenger's pending invitation
+func (r *RideRepo) RespondToFareSplit(ctx context.Context, rideID, passengerID, status string) (models.FareSplit, error) {
+	var s models.FareSplit
+	err := r.db.QueryRow(ctx,
+		`UPDATE ride_fare_splits SET status = $3, updated_at = NOW()
+		WHERE ride_id = $1 AND passenger_id::text = $2 AND status = 'INVITED'
+		RETURNING ride_id, passenger_id, status, COALESCE(share_percent, 0), created_at, updated_at`,
+		rideID,
+		passengerID,
+		status,
+	).Scan(&s.RideID, &s.PassengerID, &s.Status, &s.SharePercent, &s.CreatedAt, &s.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.FareSplit{}, models.ErrSplitNotFound
+	}
+	return s, err
+}
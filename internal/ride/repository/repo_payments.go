@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/ride/domain/ports"
+	"ride-hail/internal/shared/postgres"
+)
+
+// PaymentsRepo records ride charges for the payment provider to settle.
+type PaymentsRepo struct {
+	db *postgres.Database
+}
+
+func NewPaymentsRepo(db *postgres.Database) ports.Payments {
+	return &PaymentsRepo{db: db}
+}
+
+// Charge implements [ports.Payments]. The shares are stored as pending charges in
+// one transaction; passengers already charged for the ride are skipped.
+func (r *PaymentsRepo) Charge(ctx context.Context, rideID string, shares []models.FareShare) error {
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, share := range shares {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO ride_charges (ride_id, passenger_id, amount, currency)
+			VALUES ($1, $2, $3, NULLIF($4, ''))
+			ON CONFLICT (ride_id, passenger_id) DO NOTHING`,
+			rideID,
+			share.PassengerID,
+			share.Amount,
+			share.Amount.Currency,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
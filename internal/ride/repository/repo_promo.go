@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/postgres"
+	"ride-hail/internal/shared/pricing"
+	"ride-hail/internal/shared/promo"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetPromoCampaign finds a promo campaign by code
+func (r *RideRepo) GetPromoCampaign(ctx context.Context, code string) (promo.Campaign, error) {
+	query := `SELECT c.id, c.code, c.name, c.discount_type, COALESCE(c.percent_off, 0), c.amount_off, c.max_discount,
+		COALESCE(c.currency, ''), c.valid_from, c.valid_until, COALESCE(c.max_redemptions, 0), COALESCE(c.max_per_user, 0),
+		c.vehicle_types, c.city_ids::text[], c.is_active,
+		(SELECT count(*) FROM promo_redemptions pr WHERE pr.campaign_id = c.id AND pr.status <> 'RELEASED'),
+		c.created_at, c.updated_at
+	FROM promo_campaigns c
+	WHERE c.code = $1`
+
+	var c promo.Campaign
+	err := r.db.QueryRow(ctx, query, code).Scan(
+		&c.ID,
+		&c.Code,
+		&c.Name,
+		&c.DiscountType,
+		&c.PercentOff,
+		&c.AmountOff,
+		&c.MaxDiscount,
+		&c.Currency,
+		&c.ValidFrom,
+		&c.ValidUntil,
+		&c.MaxRedemptions,
+		&c.MaxPerUser,
+		&c.VehicleTypes,
+		&c.CityIDs,
+		&c.IsActive,
+		&c.Redeemed,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return promo.Campaign{}, promo.ErrCodeNotFound
+		}
+		return promo.Campaign{}, err
+	}
+
+	return c, nil
+}
+
+// CountPromoRedemptions counts the passenger's redemptions of the campaign that are not released
+func (r *RideRepo) CountPromoRedemptions(ctx context.Context, campaignID, passengerID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx,
+		`SELECT count(*) FROM promo_redemptions
+		WHERE campaign_id = $1 AND passenger_id = $2 AND status <> 'RELEASED'`,
+		campaignID,
+		passengerID,
+	).Scan(&count)
+	return count, err
+}
+
+// reservePromo reserves the ride's promo discount. The campaign row is locked so that
+// concurrent requests cannot exceed the redemption limits.
+func reservePromo(ctx context.Context, tx *postgres.Tx, ride *models.Ride) error {
+	var campaign promo.Campaign
+	err := tx.QueryRow(ctx,
+		`SELECT COALESCE(max_redemptions, 0), COALESCE(max_per_user, 0)
+		FROM promo_campaigns WHERE id = $1 FOR UPDATE`,
+		ride.PromoCampaignID,
+	).Scan(&campaign.MaxRedemptions, &campaign.MaxPerUser)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return promo.ErrCodeNotFound
+		}
+		return err
+	}
+
+	var redeemed, redeemedByUser int
+	err = tx.QueryRow(ctx,
+		`SELECT count(*), count(*) FILTER (WHERE passenger_id = $2)
+		FROM promo_redemptions
+		WHERE campaign_id = $1 AND status <> 'RELEASED'`,
+		ride.PromoCampaignID,
+		ride.PassengerID,
+	).Scan(&redeemed, &redeemedByUser)
+	if err != nil {
+		return err
+	}
+	if err := campaign.CheckLimits(redeemed, redeemedByUser); err != nil {
+		return err
+	}
+
+	var discount pricing.Breakdown
+	if ride.FareBreakdown != nil {
+		discount = *ride.FareBreakdown
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO promo_redemptions (campaign_id, passenger_id, ride_id, discount)
+		VALUES ($1, $2, $3, $4)`,
+		ride.PromoCampaignID,
+		ride.PassengerID,
+		ride.ID,
+		discount.Amount(pricing.ItemDiscount).Neg(),
+	)
+	return err
+}
+
+// releasePromo gives the reserved redemption of a cancelled ride back to the campaign
+func releasePromo(ctx context.Context, tx *postgres.Tx, rideID string) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE promo_redemptions SET status = 'RELEASED', updated_at = NOW()
+		WHERE ride_id = $1 AND status = 'RESERVED'`,
+		rideID,
+	)
+	return err
+}
@@ -3,16 +3,20 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 
 	"ride-hail/internal/ride/domain/models"
 	"ride-hail/internal/ride/domain/ports"
+	"ride-hail/internal/shared/money"
 	"ride-hail/internal/shared/postgres"
+
+	"github.com/jackc/pgx/v5"
 )
 
 var (
 	ErrDBNoConnection = errors.New("DB no connection")
-	ErrNotFound       = errors.New("ride not found")
+	ErrNotFound       = models.ErrRideNotFound
 )
 
 type RideRepo struct {
@@ -78,8 +82,13 @@ func (r *RideRepo) CreateRide(ctx context.Context, ride *models.Ride) error {
 			pickup_coordinate_id,
 			destination_coordinate_id,
 			requested_at,
-			estimated_fare
-		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+			estimated_fare,
+			zone_surcharge,
+			city_id,
+			estimated_fare_breakdown,
+			is_pooled,
+			payment_method
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,NULLIF($10, '')::uuid,$11,$12,$13)
 		RETURNING id, created_at, updated_at`,
 		ride.PassengerID,
 		ride.VehicleType,
@@ -89,37 +98,97 @@ func (r *RideRepo) CreateRide(ctx context.Context, ride *models.Ride) error {
 		destinationID,
 		ride.RequestedAt,
 		ride.EstimatedFare,
+		ride.ZoneSurcharge,
+		ride.CityID,
+		ride.FareBreakdown,
+		ride.Pooled,
+		ride.PaymentMethod,
 	).Scan(&ride.ID, &ride.CreatedAt, &ride.UpdatedAt)
 	if err != nil {
 		return err
 	}
 
+	// --- 4. Promo code ---
+	if ride.PromoCampaignID != "" {
+		if err := reservePromo(ctx, tx, ride); err != nil {
+			return err
+		}
+	}
+
 	return tx.Commit(ctx)
 }
 
-// GetRide fetches a ride by its ID
+// GetRide fetches a ride by its ID with pickup and destination coordinates
 func (r *RideRepo) GetRide(ctx context.Context, id string) (models.Ride, error) {
-	query := `SELECT id, passenger_id, status, pickup_location, destination_location, requested_at, created_at, updated_at
-	FROM rides WHERE id = $1`
+	query := `SELECT r.id, r.ride_number, r.passenger_id, COALESCE(r.driver_id::text, ''), r.vehicle_type, r.status,
+		p.latitude, p.longitude, p.address,
+		d.latitude, d.longitude, d.address,
+		r.pickup_coordinate_id, r.destination_coordinate_id,
+		r.estimated_fare, r.zone_surcharge, COALESCE(r.city_id::text, ''), COALESCE(c.currency, ''),
+		COALESCE(r.pickup_pin, ''), r.final_fare, r.estimated_fare_breakdown, r.final_fare_breakdown,
+		r.requested_at, r.matched_at, r.arrived_at, r.started_at, r.completed_at, r.cancelled_at,
+		COALESCE(r.cancellation_reason, ''), COALESCE(pc.code, ''), COALESCE(pc.id::text, ''),
+		r.is_pooled, r.created_at, r.updated_at
+	FROM rides r
+	JOIN coordinates p ON p.id = r.pickup_coordinate_id
+	JOIN coordinates d ON d.id = r.destination_coordinate_id
+	LEFT JOIN cities c ON c.id = r.city_id
+	LEFT JOIN promo_redemptions pr ON pr.ride_id = r.id
+	LEFT JOIN promo_campaigns pc ON pc.id = pr.campaign_id
+	WHERE r.id = $1`
 
 	var ride models.Ride
+	var estimatedFare money.Money
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&ride.ID,
+		&ride.RideNumber,
 		&ride.PassengerID,
+		&ride.DriverID,
+		&ride.VehicleType,
 		&ride.Status,
-		&ride.PickupLocation,
-		&ride.DestinationLocation,
+		&ride.PickupLocation.Latitude,
+		&ride.PickupLocation.Longitude,
+		&ride.PickupLocation.Address,
+		&ride.DestinationLocation.Latitude,
+		&ride.DestinationLocation.Longitude,
+		&ride.DestinationLocation.Address,
+		&ride.PickupCoordinateID,
+		&ride.DestinationCoordinateID,
+		&estimatedFare,
+		&ride.ZoneSurcharge,
+		&ride.CityID,
+		&ride.Currency,
+		&ride.PickupPIN,
+		&ride.FinalFare,
+		&ride.FareBreakdown,
+		&ride.FinalFareBreakdown,
 		&ride.RequestedAt,
+		&ride.MatchedAt,
+		&ride.ArrivedAt,
+		&ride.StartedAt,
+		&ride.CompletedAt,
+		&ride.CancelledAt,
+		&ride.CancellationReason,
+		&ride.PromoCode,
+		&ride.PromoCampaignID,
+		&ride.Pooled,
 		&ride.CreatedAt,
 		&ride.UpdatedAt,
 	)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return models.Ride{}, ErrNotFound
 		}
 		return models.Ride{}, err
 	}
 
+	estimatedFare.Currency = ride.Currency
+	ride.EstimatedFare = &estimatedFare
+	ride.ZoneSurcharge.Currency = ride.Currency
+	if ride.FinalFare != nil {
+		ride.FinalFare.Currency = ride.Currency
+	}
+
 	return ride, nil
 }
 
@@ -227,21 +296,32 @@ func (r *RideRepo) UpdateRide(ctx context.Context, ride models.Ride) error {
 	return nil
 }
 
-// CloseRide marks a ride as closed in the database (not implemented yet)
-func (r *RideRepo) CloseRide(ctx context.Context, id string, reason string) error {
-	query := `UPDATE rides SET status = 'CANCELLED', cancellation_reason = $1, cancelled_at = NOW(), updated_at = NOW() WHERE id = $2`
-
-	result, err := r.db.Exec(ctx, query, reason, id)
+// CloseRide cancels a ride that has not started yet and returns the driver
+// assigned to it at that moment, empty when there is none.
+func (r *RideRepo) CloseRide(ctx context.Context, id string, reason string) (string, error) {
+	tx, err := r.db.BeginTx(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
+	defer tx.Rollback(ctx)
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return ErrNotFound
+	query := `UPDATE rides SET status = 'CANCELLED', cancellation_reason = $1, cancelled_at = NOW(), updated_at = NOW()
+	WHERE id = $2 AND status IN ('REQUESTED', 'MATCHED', 'EN_ROUTE', 'ARRIVED')
+	RETURNING COALESCE(driver_id::text, '')`
+
+	var driverID string
+	if err := tx.QueryRow(ctx, query, reason, id).Scan(&driverID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", err
 	}
 
-	return nil
+	if err := releasePromo(ctx, tx, id); err != nil {
+		return "", err
+	}
+
+	return driverID, tx.Commit(ctx)
 }
 
 func (r *RideRepo) UpdateStatus(ctx context.Context, rideID string, status string) error {
@@ -260,6 +340,216 @@ func (r *RideRepo) UpdateStatus(ctx context.Context, rideID string, status strin
 	return nil
 }
 
+// ListActiveRidesByDriver fetches the rides the driver is currently heading to or driving,
+// with pickup and destination coordinates. A driver has several of them on a shared trip.
+func (r *RideRepo) ListActiveRidesByDriver(ctx context.Context, driverID string) ([]models.Ride, error) {
+	query := `SELECT r.id, r.passenger_id, r.driver_id, r.status, r.is_pooled,
+		p.latitude, p.longitude, p.address,
+		d.latitude, d.longitude, d.address
+	FROM rides r
+	JOIN coordinates p ON p.id = r.pickup_coordinate_id
+	JOIN coordinates d ON d.id = r.destination_coordinate_id
+	WHERE r.driver_id = $1 AND r.status IN ('MATCHED', 'EN_ROUTE', 'ARRIVED', 'IN_PROGRESS')
+	ORDER BY r.matched_at DESC NULLS LAST`
+
+	rows, err := r.db.Query(ctx, query, driverID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rides []models.Ride
+	for rows.Next() {
+		var ride models.Ride
+		if err := rows.Scan(
+			&ride.ID,
+			&ride.PassengerID,
+			&ride.DriverID,
+			&ride.Status,
+			&ride.Pooled,
+			&ride.PickupLocation.Latitude,
+			&ride.PickupLocation.Longitude,
+			&ride.PickupLocation.Address,
+			&ride.DestinationLocation.Latitude,
+			&ride.DestinationLocation.Longitude,
+			&ride.DestinationLocation.Address,
+		); err != nil {
+			return nil, err
+		}
+		rides = append(rides, ride)
+	}
+
+	return rides, rows.Err()
+}
+
+// UpdatePickup stores the new pickup point with the re-quoted fare. Only rides the driver
+// has not arrived for yet can be changed; other rides are reported as not found.
+func (r *RideRepo) UpdatePickup(ctx context.Context, ride *models.Ride) error {
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var pickupID string
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO coordinates (
+			entity_id, entity_type, latitude, longitude, address
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		ride.PassengerID,
+		"passenger",
+		ride.PickupLocation.Latitude,
+		ride.PickupLocation.Longitude,
+		ride.PickupLocation.Address,
+	).Scan(&pickupID)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(
+		ctx,
+		`UPDATE rides
+		SET pickup_coordinate_id = $1, estimated_fare = $2, zone_surcharge = $3, estimated_fare_breakdown = $4, updated_at = NOW()
+		WHERE id = $5 AND status IN ('REQUESTED', 'MATCHED', 'EN_ROUTE')`,
+		pickupID,
+		ride.EstimatedFare,
+		ride.ZoneSurcharge,
+		ride.FareBreakdown,
+		ride.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	ride.PickupCoordinateID = pickupID
+	return tx.Commit(ctx)
+}
+
+// UpdateDestination points an active ride to a new destination coordinate with the
+// re-estimated fare and records the change as a ride event.
+func (r *RideRepo) UpdateDestination(ctx context.Context, ride *models.Ride, previous models.Location) error {
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var destinationID string
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO coordinates (
+			entity_id, entity_type, latitude, longitude, address, fare_amount, distance_km, duration_minutes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		ride.PassengerID,
+		"passenger",
+		ride.DestinationLocation.Latitude,
+		ride.DestinationLocation.Longitude,
+		ride.DestinationLocation.Address,
+		ride.EstimatedFare,
+		ride.EstimatedDistanceKm,
+		ride.EstimatedDurationMinutes,
+	).Scan(&destinationID)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(
+		ctx,
+		`UPDATE rides
+		SET destination_coordinate_id = $1, estimated_fare = $2, zone_surcharge = $3, estimated_fare_breakdown = $4, updated_at = NOW()
+		WHERE id = $5 AND status IN ('REQUESTED', 'MATCHED', 'EN_ROUTE', 'ARRIVED', 'IN_PROGRESS')`,
+		destinationID,
+		ride.EstimatedFare,
+		ride.ZoneSurcharge,
+		ride.FareBreakdown,
+		ride.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	eventData, err := json.Marshal(map[string]any{
+		"previous_destination":       previous,
+		"destination":                ride.DestinationLocation,
+		"estimated_fare":             ride.EstimatedFare,
+		"estimated_distance_km":      ride.EstimatedDistanceKm,
+		"estimated_duration_minutes": ride.EstimatedDurationMinutes,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO ride_events (ride_id, event_type, event_data) VALUES ($1, 'DESTINATION_CHANGED', $2)`,
+		ride.ID,
+		eventData,
+	)
+	if err != nil {
+		return err
+	}
+
+	ride.DestinationCoordinateID = destinationID
+	return tx.Commit(ctx)
+}
+
+// GetDriverLocation returns the current position of the driver.
+func (r *RideRepo) GetDriverLocation(ctx context.Context, driverID string) (models.Location, error) {
+	var loc models.Location
+	err := r.db.QueryRow(ctx, `SELECT latitude, longitude, address
+		FROM coordinates
+		WHERE entity_id = $1 AND entity_type = 'driver' AND is_current = true
+		ORDER BY updated_at DESC
+		LIMIT 1`,
+		driverID,
+	).Scan(&loc.Latitude, &loc.Longitude, &loc.Address)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Location{}, ErrNotFound
+		}
+		return models.Location{}, err
+	}
+	return loc, nil
+}
+
+// SavePassengerLocation records the live position shared by the passenger
+// as the current passenger coordinate.
+func (r *RideRepo) SavePassengerLocation(ctx context.Context, passengerID string, loc models.Location) error {
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `UPDATE coordinates
+		SET is_current = false, updated_at = NOW()
+		WHERE entity_id = $1 AND entity_type = 'passenger' AND is_current = true`,
+		passengerID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO coordinates (entity_id, entity_type, latitude, longitude, address, is_current)
+		VALUES ($1, 'passenger', $2, $3, $4, true)`,
+		passengerID,
+		loc.Latitude,
+		loc.Longitude,
+		loc.Address,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
 type DB struct {
 	db *sql.DB
 }
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/ride/domain/ports"
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/broker/rabbitmq"
+	"ride-hail/internal/shared/logger"
+)
+
+// DriverResponseConsumer consumes driver answers to ride offers and tells the
+// passenger about the match together with the pickup PIN.
+type DriverResponseConsumer struct {
+	repo     ports.RideRepository
+	consume  ports.Consume
+	notifier ports.PassengerNotifier
+	logger   *logger.Logger
+}
+
+func NewDriverResponseConsumer(
+	repo ports.RideRepository,
+	consume ports.Consume,
+	notifier ports.PassengerNotifier,
+	log *logger.Logger,
+) *DriverResponseConsumer {
+	return &DriverResponseConsumer{
+		repo:     repo,
+		consume:  consume,
+		notifier: notifier,
+		logger:   log,
+	}
+}
+
+func (c *DriverResponseConsumer) Start(ctx context.Context) error {
+	ch, err := c.consume.Consume(ctx, messages.QueueDriverResponses, "")
+	if err != nil {
+		return err
+	}
+
+	go c.processMessages(ctx, ch)
+	return nil
+}
+
+func (c *DriverResponseConsumer) processMessages(ctx context.Context, ch <-chan rabbitmq.Message) {
+	for msg := range ch {
+		var resp messages.DriverMatchResponse
+		if err := json.Unmarshal(msg.Body(), &resp); err != nil {
+			c.logError(ctx, "driver_response_failed", "invalid driver response", err)
+		} else if err := c.Handle(ctx, resp); err != nil {
+			c.logError(ctx, "driver_response_failed", "failed to handle driver response", err)
+		}
+		_ = msg.Ack(false)
+	}
+}
+
+// Handle notifies the passenger of an accepted ride. The driver service has already
+// assigned the driver and generated the PIN; responses for rides since taken by
+// another driver are ignored.
+func (c *DriverResponseConsumer) Handle(ctx context.Context, resp messages.DriverMatchResponse) error {
+	ctx = logger.WithRideID(ctx, resp.RideID)
+	if !resp.Accepted {
+		c.logInfo(ctx, "ride_offer_declined", "driver declined the ride", map[string]any{"driver_id": resp.DriverID})
+		return nil
+	}
+
+	ride, err := c.repo.GetRide(ctx, resp.RideID)
+	if err != nil {
+		return err
+	}
+	if ride.DriverID != resp.DriverID || ride.Status != models.RideStatusMatched {
+		return nil
+	}
+
+	c.logInfo(ctx, "ride_matched", "driver accepted the ride", map[string]any{"driver_id": resp.DriverID})
+
+	return c.notifier.SendRideMatched(ride.PassengerID, models.RideMatch{
+		RideID:     ride.ID,
+		RideNumber: ride.RideNumber,
+		PickupPIN:  ride.PickupPIN,
+		Driver:     resp.DriverInfo,
+	})
+}
+
+func (c *DriverResponseConsumer) logInfo(ctx context.Context, action, message string, extra interface{}) {
+	if c.logger != nil {
+		c.logger.InfoWithFields(ctx, action, message, extra)
+	}
+}
+
+func (c *DriverResponseConsumer) logError(ctx context.Context, action, message string, err error) {
+	if c.logger != nil {
+		c.logger.Error(ctx, action, message, err)
+	}
+}
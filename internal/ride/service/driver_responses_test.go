@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/broker/messages"
+)
+
+func TestDriverResponseConsumer_Handle(t *testing.T) {
+	matched := testRide(models.RideStatusMatched, "driver-1")
+	matched.PickupPIN = "0427"
+
+	cases := []struct {
+		name      string
+		ride      models.Ride
+		resp      messages.DriverMatchResponse
+		wantMatch bool
+	}{
+		{name: "accepted", ride: matched, resp: messages.DriverMatchResponse{RideID: "ride-1", DriverID: "driver-1", Accepted: true}, wantMatch: true},
+		{name: "declined", ride: matched, resp: messages.DriverMatchResponse{RideID: "ride-1", DriverID: "driver-1"}},
+		{name: "taken by another driver", ride: matched, resp: messages.DriverMatchResponse{RideID: "ride-1", DriverID: "driver-2", Accepted: true}},
+		{name: "cancelled meanwhile", ride: testRide(models.RideStatusCancelled, "driver-1"), resp: messages.DriverMatchResponse{RideID: "ride-1", DriverID: "driver-1", Accepted: true}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mockRideRepo{
+				getRideFunc: func(ctx context.Context, id string) (models.Ride, error) {
+					return tc.ride, nil
+				},
+			}
+			notifier := &mockPassengerNotifier{}
+			c := NewDriverResponseConsumer(repo, nil, notifier, nil)
+
+			if err := c.Handle(context.Background(), tc.resp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := len(notifier.matches) == 1; got != tc.wantMatch {
+				t.Fatalf("expected match sent %v, got %d", tc.wantMatch, len(notifier.matches))
+			}
+			if tc.wantMatch && notifier.matches[0].PickupPIN != "0427" {
+				t.Fatalf("expected pickup PIN for the passenger, got %+v", notifier.matches[0])
+			}
+		})
+	}
+}
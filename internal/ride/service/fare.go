@@ -1,6 +1,10 @@
 package service
 
-import "errors"
+import (
+	"errors"
+
+	"ride-hail/internal/shared/money"
+)
 
 var (
 	ErrInvalidDistance = errors.New("invalid distance")
@@ -8,17 +12,17 @@ var (
 )
 
 type FareCalculator struct {
-	BaseFare       float64
-	PricePerKM     float64
-	PricePerMinute float64
-	MinFare        float64
+	BaseFare       money.Money
+	PricePerKM     money.Money
+	PricePerMinute money.Money
+	MinFare        money.Money
 }
 
 func NewFareCalculator(
-	baseFare float64,
-	pricePerKM float64,
-	pricePerMinute float64,
-	minFare float64,
+	baseFare money.Money,
+	pricePerKM money.Money,
+	pricePerMinute money.Money,
+	minFare money.Money,
 ) *FareCalculator {
 	return &FareCalculator{
 		BaseFare:       baseFare,
@@ -28,22 +32,18 @@ func NewFareCalculator(
 	}
 }
 
-func (f *FareCalculator) Calculate(distanceKM float64, durationMin float64) (float64, error) {
+func (f *FareCalculator) Calculate(distanceKM float64, durationMin float64) (money.Money, error) {
 	if distanceKM < 0 {
-		return 0, ErrInvalidDistance
+		return money.Money{}, ErrInvalidDistance
 	}
 
 	if durationMin < 0 {
-		return 0, ErrInvalidDuration
+		return money.Money{}, ErrInvalidDuration
 	}
 
-	total := f.BaseFare +
-		distanceKM*f.PricePerKM +
-		durationMin*f.PricePerMinute
-
-	if total < f.MinFare {
-		return f.MinFare, nil
-	}
+	total := f.BaseFare.
+		Add(f.PricePerKM.Mul(distanceKM, money.HalfUp)).
+		Add(f.PricePerMinute.Mul(durationMin, money.HalfUp))
 
-	return total, nil
+	return total.Max(f.MinFare), nil
 }
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/logger"
+	"ride-hail/internal/shared/money"
+)
+
+// maxSplitParticipants is the number of passengers the owner may split the fare with.
+const maxSplitParticipants = 5
+
+var (
+	ErrInvalidSplit    = errors.New("invalid fare split")
+	ErrSplitNotAllowed = errors.New("fare of a shared ride cannot be split")
+)
+
+// InviteToSplit invites registered passengers to split the fare of the owner's ride.
+// Either every participant gets a custom share and the owner pays the rest, or none
+// does and the fare is divided evenly. Passengers who declined may be invited again.
+func (s *RideService) InviteToSplit(ctx context.Context, ownerID, rideID string, invites []models.SplitInvite) (models.Ride, []models.FareSplit, error) {
+	ride, err := s.passengerRide(ctx, ownerID, rideID)
+	if err != nil {
+		return models.Ride{}, nil, err
+	}
+	if !activeStatuses[ride.Status] {
+		return models.Ride{}, nil, ErrRideNotActive
+	}
+	if ride.Pooled {
+		return models.Ride{}, nil, ErrSplitNotAllowed
+	}
+	if len(invites) == 0 {
+		return models.Ride{}, nil, fmt.Errorf("%w: no passengers invited", ErrInvalidSplit)
+	}
+
+	existing, err := s.repo.ListFareSplits(ctx, ride.ID)
+	if err != nil {
+		return models.Ride{}, nil, err
+	}
+
+	splits := make([]models.FareSplit, 0, len(invites))
+	for _, invite := range invites {
+		passengerID, err := s.invitedPassenger(ctx, invite)
+		if err != nil {
+			return models.Ride{}, nil, err
+		}
+		splits = append(splits, models.FareSplit{
+			RideID:       ride.ID,
+			PassengerID:  passengerID,
+			Status:       models.SplitInvited,
+			SharePercent: invite.SharePercent,
+		})
+	}
+	if err := validateSplits(ride.PassengerID, existing, splits); err != nil {
+		return models.Ride{}, nil, err
+	}
+
+	all, err := s.repo.SaveFareSplits(ctx, ride.ID, splits)
+	if err != nil {
+		if !errors.Is(err, models.ErrPassengerNotFound) {
+			s.logError(ctx, "db_error", "failed to save fare splits", err)
+		}
+		return models.Ride{}, nil, err
+	}
+
+	ctx = logger.WithRideID(ctx, ride.ID)
+	s.logInfo(ctx, "fare_split_invited", "passengers invited to split the fare", map[string]any{
+		"passenger_id": ownerID,
+		"invited":      len(splits),
+	})
+
+	return ride, all, nil
+}
+
+// RespondToSplit accepts or declines the passenger's invitation to split the fare.
+// Invitations can be answered until the ride is finished.
+func (s *RideService) RespondToSplit(ctx context.Context, passengerID, rideID string, accept bool) (models.Ride, models.FareSplit, error) {
+	ride, err := s.repo.GetRide(ctx, rideID)
+	if err != nil {
+		return models.Ride{}, models.FareSplit{}, err
+	}
+	if !activeStatuses[ride.Status] {
+		return models.Ride{}, models.FareSplit{}, ErrRideNotActive
+	}
+
+	status := models.SplitDeclined
+	if accept {
+		status = models.SplitAccepted
+	}
+	split, err := s.repo.RespondToFareSplit(ctx, ride.ID, passengerID, status)
+	if err != nil {
+		return models.Ride{}, models.FareSplit{}, err
+	}
+
+	ctx = logger.WithRideID(ctx, ride.ID)
+	s.logInfo(ctx, "fare_split_answered", "passenger answered the fare split invitation", map[string]any{
+		"passenger_id": passengerID,
+		"status":       status,
+	})
+
+	return ride, split, nil
+}
+
+// FareSplit returns the ride with the passengers invited to split its fare. The owner
+// and every invited passenger may see it.
+func (s *RideService) FareSplit(ctx context.Context, passengerID, rideID string) (models.Ride, []models.FareSplit, error) {
+	ride, err := s.repo.GetRide(ctx, rideID)
+	if err != nil {
+		return models.Ride{}, nil, err
+	}
+	splits, err := s.repo.ListFareSplits(ctx, ride.ID)
+	if err != nil {
+		return models.Ride{}, nil, err
+	}
+	if ride.PassengerID != passengerID && findSplit(splits, passengerID) == nil {
+		return models.Ride{}, nil, ErrRideNotOwned
+	}
+	return ride, splits, nil
+}
+
+func (s *RideService) invitedPassenger(ctx context.Context, invite models.SplitInvite) (string, error) {
+	if invite.PassengerID != "" {
+		return invite.PassengerID, nil
+	}
+	email := strings.TrimSpace(invite.Email)
+	if email == "" {
+		return "", fmt.Errorf("%w: passenger_id or email is required", ErrInvalidSplit)
+	}
+	return s.repo.FindPassengerByEmail(ctx, email)
+}
+
+// validateSplits checks new invitations against the ones the ride already has.
+func validateSplits(ownerID string, existing, invited []models.FareSplit) error {
+	active := make(map[string]models.FareSplit)
+	for _, split := range existing {
+		if split.Status != models.SplitDeclined {
+			active[split.PassengerID] = split
+		}
+	}
+	for _, split := range invited {
+		if split.PassengerID == ownerID {
+			return fmt.Errorf("%w: the owner cannot be invited", ErrInvalidSplit)
+		}
+		if _, ok := active[split.PassengerID]; ok {
+			return fmt.Errorf("%w: passenger %s is already invited", ErrInvalidSplit, split.PassengerID)
+		}
+		if split.SharePercent < 0 || split.SharePercent > 99 {
+			return fmt.Errorf("%w: share_percent must be between 1 and 99", ErrInvalidSplit)
+		}
+		active[split.PassengerID] = split
+	}
+	if len(active) > maxSplitParticipants {
+		return fmt.Errorf("%w: at most %d passengers can split a fare", ErrInvalidSplit, maxSplitParticipants)
+	}
+
+	custom, total := 0, 0
+	for _, split := range active {
+		if split.SharePercent > 0 {
+			custom++
+			total += split.SharePercent
+		}
+	}
+	if custom != 0 && custom != len(active) {
+		return fmt.Errorf("%w: either every passenger gets a share or the fare is split evenly", ErrInvalidSplit)
+	}
+	if total >= 100 {
+		return fmt.Errorf("%w: shares must leave part of the fare to the owner", ErrInvalidSplit)
+	}
+	return nil
+}
+
+// fareShares divides the fare between the owner and the passengers who accepted:
+// by their custom shares with the rest left to the owner, or evenly. The shares
+// always add up to the fare.
+func fareShares(ownerID string, splits []models.FareSplit, fare money.Money) []models.FareShare {
+	shares := []models.FareShare{{PassengerID: ownerID, Owner: true}}
+	ratios := []int64{100}
+	custom := false
+	for _, split := range splits {
+		if split.Status != models.SplitAccepted {
+			continue
+		}
+		shares = append(shares, models.FareShare{PassengerID: split.PassengerID})
+		ratios = append(ratios, int64(split.SharePercent))
+		ratios[0] -= int64(split.SharePercent)
+		custom = custom || split.SharePercent > 0
+	}
+
+	if !custom {
+		for i := range ratios {
+			ratios[i] = 1
+		}
+	}
+	for i, part := range fare.Allocate(ratios...) {
+		shares[i].Amount = part
+	}
+	return shares
+}
+
+func findSplit(splits []models.FareSplit, passengerID string) *models.FareSplit {
+	for i := range splits {
+		if splits[i].PassengerID == passengerID {
+			return &splits[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/money"
+)
+
+type mockPayments struct {
+	charged map[string][]models.FareShare
+}
+
+func (m *mockPayments) Charge(ctx context.Context, rideID string, shares []models.FareShare) error {
+	if m.charged == nil {
+		m.charged = make(map[string][]models.FareShare)
+	}
+	m.charged[rideID] = shares
+	return nil
+}
+
+func TestInviteToSplit(t *testing.T) {
+	cases := []struct {
+		name     string
+		ride     func(r *models.Ride)
+		existing []models.FareSplit
+		invites  []models.SplitInvite
+		wantErr  error
+		wantLen  int
+	}{
+		{name: "even", invites: []models.SplitInvite{{PassengerID: "passenger-2"}, {Email: "friend@example.com"}}, wantLen: 2},
+		{name: "custom shares", invites: []models.SplitInvite{{PassengerID: "passenger-2", SharePercent: 30}, {PassengerID: "passenger-3", SharePercent: 20}}, wantLen: 2},
+		{name: "declined passenger invited again", existing: []models.FareSplit{{PassengerID: "passenger-2", Status: models.SplitDeclined}}, invites: []models.SplitInvite{{PassengerID: "passenger-2"}}, wantLen: 1},
+		{name: "already invited", existing: []models.FareSplit{{PassengerID: "passenger-2", Status: models.SplitAccepted}}, invites: []models.SplitInvite{{PassengerID: "passenger-2"}}, wantErr: ErrInvalidSplit},
+		{name: "owner invited", invites: []models.SplitInvite{{PassengerID: "passenger-1"}}, wantErr: ErrInvalidSplit},
+		{name: "mixed shares", invites: []models.SplitInvite{{PassengerID: "passenger-2", SharePercent: 30}, {PassengerID: "passenger-3"}}, wantErr: ErrInvalidSplit},
+		{name: "nothing left to the owner", invites: []models.SplitInvite{{PassengerID: "passenger-2", SharePercent: 60}, {PassengerID: "passenger-3", SharePercent: 40}}, wantErr: ErrInvalidSplit},
+		{name: "unknown email", invites: []models.SplitInvite{{Email: "stranger@example.com"}}, wantErr: models.ErrPassengerNotFound},
+		{name: "pooled ride", ride: func(r *models.Ride) { r.Pooled = true }, invites: []models.SplitInvite{{PassengerID: "passenger-2"}}, wantErr: ErrSplitNotAllowed},
+		{name: "finished ride", ride: func(r *models.Ride) { r.Status = models.RideStatusCompleted }, invites: []models.SplitInvite{{PassengerID: "passenger-2"}}, wantErr: ErrRideNotActive},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ride := testRide(models.RideStatusMatched, "driver-1")
+			ride.VehicleType = models.VehicleTypeXL
+			if tc.ride != nil {
+				tc.ride(&ride)
+			}
+			repo := &mockRideRepo{
+				emails: map[string]string{"friend@example.com": "passenger-4"},
+				splits: tc.existing,
+			}
+			svc := newCommandService(ride, repo, &mockPublisher{})
+
+			_, splits, err := svc.InviteToSplit(context.Background(), "passenger-1", "ride-1", tc.invites)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(splits) != tc.wantLen {
+				t.Fatalf("expected %d splits, got %+v", tc.wantLen, splits)
+			}
+			for _, split := range splits {
+				if split.Status != models.SplitInvited {
+					t.Fatalf("expected every passenger invited, got %+v", splits)
+				}
+			}
+		})
+	}
+}
+
+func TestRespondToSplit(t *testing.T) {
+	repo := &mockRideRepo{splits: []models.FareSplit{{RideID: "ride-1", PassengerID: "passenger-2", Status: models.SplitInvited}}}
+	svc := newCommandService(testRide(models.RideStatusInProgress, "driver-1"), repo, &mockPublisher{})
+
+	_, split, err := svc.RespondToSplit(context.Background(), "passenger-2", "ride-1", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if split.Status != models.SplitAccepted {
+		t.Fatalf("expected accepted, got %s", split.Status)
+	}
+
+	if _, _, err := svc.RespondToSplit(context.Background(), "passenger-2", "ride-1", false); !errors.Is(err, models.ErrSplitNotFound) {
+		t.Fatalf("expected %v once answered, got %v", models.ErrSplitNotFound, err)
+	}
+	if _, _, err := svc.RespondToSplit(context.Background(), "passenger-3", "ride-1", true); !errors.Is(err, models.ErrSplitNotFound) {
+		t.Fatalf("expected %v for a passenger who was not invited, got %v", models.ErrSplitNotFound, err)
+	}
+}
+
+func TestFareShares(t *testing.T) {
+	fare := money.New(100000, "KZT")
+
+	cases := []struct {
+		name   string
+		splits []models.FareSplit
+		want   []int64
+	}{
+		{name: "no split", want: []int64{100000}},
+		{name: "even with leftover", splits: []models.FareSplit{
+			{PassengerID: "p2", Status: models.SplitAccepted},
+			{PassengerID: "p3", Status: models.SplitAccepted},
+		}, want: []int64{33334, 33333, 33333}},
+		{name: "custom", splits: []models.FareSplit{
+			{PassengerID: "p2", Status: models.SplitAccepted, SharePercent: 30},
+			{PassengerID: "p3", Status: models.SplitAccepted, SharePercent: 25},
+		}, want: []int64{45000, 30000, 25000}},
+		{name: "declined and pending pay nothing", splits: []models.FareSplit{
+			{PassengerID: "p2", Status: models.SplitAccepted, SharePercent: 40},
+			{PassengerID: "p3", Status: models.SplitDeclined, SharePercent: 30},
+			{PassengerID: "p4", Status: models.SplitInvited, SharePercent: 20},
+		}, want: []int64{60000, 40000}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			shares := fareShares("p1", tc.splits, fare)
+			if len(shares) != len(tc.want) || !shares[0].Owner || shares[0].PassengerID != "p1" {
+				t.Fatalf("expected %d shares with the owner first, got %+v", len(tc.want), shares)
+			}
+			for i, share := range shares {
+				if share.Amount.Amount != tc.want[i] || share.Amount.Currency != "KZT" {
+					t.Fatalf("expected share %d to be %d, got %v", i, tc.want[i], share.Amount)
+				}
+			}
+		})
+	}
+}
+
+func TestReceipt_SplitFare(t *testing.T) {
+	completedAt := time.Now().Add(-time.Minute)
+	fare := money.New(90000, "KZT")
+	ride := testRide(models.RideStatusCompleted, "driver-1")
+	ride.FinalFare = &fare
+	ride.CompletedAt = &completedAt
+
+	repo := &mockRideRepo{splits: []models.FareSplit{
+		{RideID: "ride-1", PassengerID: "passenger-2", Status: models.SplitAccepted},
+		{RideID: "ride-1", PassengerID: "passenger-3", Status: models.SplitInvited},
+	}}
+	svc := newCommandService(ride, repo, &mockPublisher{})
+
+	for _, passengerID := range []string{"passenger-1", "passenger-2"} {
+		receipt, err := svc.Receipt(context.Background(), passengerID, "ride-1")
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", passengerID, err)
+		}
+		if len(receipt.Shares) != 2 || receipt.Share == nil || receipt.Share.Amount != 45000 {
+			t.Fatalf("expected half of the fare for %s, got %+v", passengerID, receipt)
+		}
+	}
+
+	// The invitation was never accepted
+	if _, err := svc.Receipt(context.Background(), "passenger-3", "ride-1"); !errors.Is(err, ErrRideNotOwned) {
+		t.Fatalf("expected %v, got %v", ErrRideNotOwned, err)
+	}
+}
+
+func TestRideStatusConsumer_ChargesShares(t *testing.T) {
+	fare := money.New(90000, "KZT")
+	repo := &mockRideRepo{
+		getRideFunc: func(ctx context.Context, id string) (models.Ride, error) {
+			return testRide(models.RideStatusInProgress, "driver-1"), nil
+		},
+		splits: []models.FareSplit{{RideID: "ride-1", PassengerID: "passenger-2", Status: models.SplitAccepted}},
+	}
+	notifier := &mockPassengerNotifier{connected: map[string]bool{"passenger-1": true, "passenger-2": true}}
+	payments := &mockPayments{}
+	c := NewRideStatusConsumer(repo, nil, notifier, payments, nil)
+
+	update := messages.RideStatusUpdate{RideID: "ride-1", Status: string(models.RideStatusCompleted), FinalFare: &fare}
+	if err := c.Handle(context.Background(), update); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	shares := payments.charged["ride-1"]
+	if len(shares) != 2 || shares[0].Amount.Amount != 45000 || shares[1].PassengerID != "passenger-2" {
+		t.Fatalf("expected the fare charged in two shares, got %+v", shares)
+	}
+	if len(notifier.statuses) != 2 {
+		t.Fatalf("expected both passengers told about the completion, got %d", len(notifier.statuses))
+	}
+}
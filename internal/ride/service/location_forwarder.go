@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/ride/domain/ports"
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/broker/rabbitmq"
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/logger"
+)
+
+const (
+	// locationThrottle is the minimum interval between two location pushes to one passenger.
+	locationThrottle = 3 * time.Second
+	// defaultSpeedKmh is assumed for the ETA when the driver is standing or reports no speed.
+	defaultSpeedKmh = 30.0
+	// minSpeedKmh is the reported speed below which defaultSpeedKmh is used instead.
+	minSpeedKmh = 5.0
+)
+
+// LocationForwarder consumes driver positions from location_fanout and pushes them
+// to the passengers of the driver's active rides, each with the distance and ETA of
+// their own ride.
+type LocationForwarder struct {
+	repo     ports.RideRepository
+	consume  ports.Consume
+	notifier ports.PassengerNotifier
+	logger   *logger.Logger
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time // by passenger ID
+	now      func() time.Time
+}
+
+func NewLocationForwarder(
+	repo ports.RideRepository,
+	consume ports.Consume,
+	notifier ports.PassengerNotifier,
+	log *logger.Logger,
+) *LocationForwarder {
+	return &LocationForwarder{
+		repo:     repo,
+		consume:  consume,
+		notifier: notifier,
+		logger:   log,
+		lastSent: make(map[string]time.Time),
+		now:      time.Now,
+	}
+}
+
+func (f *LocationForwarder) Start(ctx context.Context) error {
+	ch, err := f.consume.Consume(ctx, messages.QueueLocationUpdatesRide, "")
+	if err != nil {
+		return err
+	}
+
+	go f.processMessages(ctx, ch)
+	return nil
+}
+
+// processMessages acks every update: a position is stale by the time it could be redelivered.
+func (f *LocationForwarder) processMessages(ctx context.Context, ch <-chan rabbitmq.Message) {
+	for msg := range ch {
+		var update messages.LocationUpdate
+		if err := json.Unmarshal(msg.Body(), &update); err != nil {
+			f.logError(ctx, "location_forward_failed", "invalid location update", err)
+		} else if err := f.Forward(ctx, update); err != nil {
+			f.logError(ctx, "location_forward_failed", "failed to forward driver location", err)
+		}
+		_ = msg.Ack(false)
+	}
+}
+
+// Forward sends the driver position to the passengers of the active rides.
+// Drivers without a ride, updates for a ride the driver no longer has, passengers
+// without a connection and updates arriving faster than locationThrottle are
+// skipped silently.
+func (f *LocationForwarder) Forward(ctx context.Context, update messages.LocationUpdate) error {
+	if update.DriverID == "" {
+		return nil
+	}
+
+	rides, err := f.repo.ListActiveRidesByDriver(ctx, update.DriverID)
+	if err != nil {
+		if errors.Is(err, models.ErrRideNotFound) {
+			return nil
+		}
+		return err
+	}
+	if update.RideID != "" && !hasRide(rides, update.RideID) {
+		return nil
+	}
+
+	var firstErr error
+	for _, ride := range rides {
+		if !f.notifier.IsConnected(ride.PassengerID) || !f.allow(ride.PassengerID) {
+			continue
+		}
+		if err := f.notifier.SendDriverLocation(ride.PassengerID, driverLocation(ride, update, f.now())); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func hasRide(rides []models.Ride, rideID string) bool {
+	for _, ride := range rides {
+		if ride.ID == rideID {
+			return true
+		}
+	}
+	return false
+}
+
+// allow reports whether the passenger may receive another update now and records the send.
+func (f *LocationForwarder) allow(passengerID string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	if last, ok := f.lastSent[passengerID]; ok && now.Sub(last) < locationThrottle {
+		return false
+	}
+	f.lastSent[passengerID] = now
+
+	// Drop passengers that stopped receiving updates so the map does not grow forever.
+	for id, last := range f.lastSent {
+		if now.Sub(last) > time.Minute {
+			delete(f.lastSent, id)
+		}
+	}
+	return true
+}
+
+// driverLocation measures the way to the pickup before the ride starts
+// and to the destination while it is in progress.
+func driverLocation(ride models.Ride, update messages.LocationUpdate, now time.Time) models.DriverLocation {
+	driver := geo.Point{Lat: update.Location.Lat, Lng: update.Location.Lng}
+
+	loc := models.DriverLocation{
+		RideID:    ride.ID,
+		Latitude:  update.Location.Lat,
+		Longitude: update.Location.Lng,
+	}
+
+	var distance float64
+	if ride.Status == models.RideStatusInProgress {
+		distance = geo.DistanceKm(driver, geo.Point{Lat: ride.DestinationLocation.Latitude, Lng: ride.DestinationLocation.Longitude})
+		loc.DistanceRemainingKm = distance
+	} else {
+		distance = geo.DistanceKm(driver, geo.Point{Lat: ride.PickupLocation.Latitude, Lng: ride.PickupLocation.Longitude})
+		loc.DistanceToPickupKm = distance
+	}
+
+	speed := update.SpeedKmh
+	if speed < minSpeedKmh {
+		speed = defaultSpeedKmh
+	}
+	loc.EstimatedArrival = now.Add(time.Duration(distance / speed * float64(time.Hour)))
+
+	return loc
+}
+
+func (f *LocationForwarder) logError(ctx context.Context, action, message string, err error) {
+	if f.logger != nil {
+		f.logger.Error(ctx, action, message, err)
+	}
+}
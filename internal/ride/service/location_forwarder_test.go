@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/broker/messages"
+)
+
+type mockPassengerNotifier struct {
+	connected map[string]bool
+	sent      []models.DriverLocation
+	matches   []models.RideMatch
+	statuses  []messages.RideStatusUpdate
+}
+
+func (m *mockPassengerNotifier) IsConnected(passengerID string) bool {
+	return m.connected[passengerID]
+}
+
+func (m *mockPassengerNotifier) SendDriverLocation(passengerID string, update models.DriverLocation) error {
+	m.sent = append(m.sent, update)
+	return nil
+}
+
+func (m *mockPassengerNotifier) SendRideMatched(passengerID string, match models.RideMatch) error {
+	m.matches = append(m.matches, match)
+	return nil
+}
+
+func (m *mockPassengerNotifier) SendRideStatus(passengerID string, update messages.RideStatusUpdate) error {
+	m.statuses = append(m.statuses, update)
+	return nil
+}
+
+func newTestForwarder(rides []models.Ride, rideErr error, notifier *mockPassengerNotifier, now *time.Time) *LocationForwarder {
+	repo := &mockRideRepo{
+		activeRidesFunc: func(ctx context.Context, driverID string) ([]models.Ride, error) {
+			return rides, rideErr
+		},
+	}
+	f := NewLocationForwarder(repo, nil, notifier, nil)
+	f.now = func() time.Time { return *now }
+	return f
+}
+
+func TestLocationForwarder_Forward(t *testing.T) {
+	ride := models.Ride{
+		ID:                  "ride-1",
+		PassengerID:         "passenger-1",
+		Status:              models.RideStatusEnRoute,
+		PickupLocation:      models.Location{Latitude: 43.2389, Longitude: 76.8897},
+		DestinationLocation: models.Location{Latitude: 43.2567, Longitude: 76.9286},
+	}
+	update := messages.LocationUpdate{
+		DriverID: "driver-1",
+		Location: messages.Coordinate{Lat: 43.2389, Lng: 76.8797},
+		SpeedKmh: 40,
+	}
+
+	cases := []struct {
+		name      string
+		rides     []models.Ride
+		rideErr   error
+		rideID    string
+		connected bool
+		wantSent  bool
+	}{
+		{name: "connected passenger", rides: []models.Ride{ride}, connected: true, wantSent: true},
+		{name: "passenger not connected", rides: []models.Ride{ride}, connected: false, wantSent: false},
+		{name: "no active ride", connected: true, wantSent: false},
+		{name: "no active ride error", rideErr: models.ErrRideNotFound, connected: true, wantSent: false},
+		{name: "update for another ride", rides: []models.Ride{ride}, rideID: "ride-2", connected: true, wantSent: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+			notifier := &mockPassengerNotifier{connected: map[string]bool{"passenger-1": tc.connected}}
+			f := newTestForwarder(tc.rides, tc.rideErr, notifier, &now)
+
+			upd := update
+			upd.RideID = tc.rideID
+			if err := f.Forward(context.Background(), upd); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := len(notifier.sent) == 1; got != tc.wantSent {
+				t.Fatalf("expected sent=%v, got %d updates", tc.wantSent, len(notifier.sent))
+			}
+		})
+	}
+}
+
+func TestLocationForwarder_Throttle(t *testing.T) {
+	ride := models.Ride{ID: "ride-1", PassengerID: "passenger-1", Status: models.RideStatusEnRoute}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	notifier := &mockPassengerNotifier{connected: map[string]bool{"passenger-1": true}}
+	f := newTestForwarder([]models.Ride{ride}, nil, notifier, &now)
+
+	start := now
+	update := messages.LocationUpdate{DriverID: "driver-1"}
+	for _, offset := range []time.Duration{0, time.Second, 2500 * time.Millisecond, 3500 * time.Millisecond} {
+		now = start.Add(offset)
+		if err := f.Forward(context.Background(), update); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// 0s and 3.5s pass, 1s and 2.5s fall inside the throttle window.
+	if len(notifier.sent) != 2 {
+		t.Fatalf("expected 2 updates, got %d", len(notifier.sent))
+	}
+}
+
+func TestLocationForwarder_SharedTrip(t *testing.T) {
+	rides := []models.Ride{
+		{ID: "ride-1", PassengerID: "passenger-1", Pooled: true, Status: models.RideStatusInProgress},
+		{ID: "ride-2", PassengerID: "passenger-2", Pooled: true, Status: models.RideStatusEnRoute},
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	notifier := &mockPassengerNotifier{connected: map[string]bool{"passenger-1": true, "passenger-2": true}}
+	f := newTestForwarder(rides, nil, notifier, &now)
+
+	if err := f.Forward(context.Background(), messages.LocationUpdate{DriverID: "driver-1", RideID: "ride-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Each passenger gets the position measured against their own ride.
+	if len(notifier.sent) != 2 || notifier.sent[0].RideID != "ride-1" || notifier.sent[1].RideID != "ride-2" {
+		t.Fatalf("expected an update for each ride, got %+v", notifier.sent)
+	}
+}
+
+func TestDriverLocation_Target(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ride := models.Ride{
+		ID:                  "ride-1",
+		PickupLocation:      models.Location{Latitude: 43.2389, Longitude: 76.8897},
+		DestinationLocation: models.Location{Latitude: 43.2567, Longitude: 76.9286},
+	}
+	// Driver stands on the pickup point.
+	update := messages.LocationUpdate{Location: messages.Coordinate{Lat: 43.2389, Lng: 76.8897}}
+
+	ride.Status = models.RideStatusMatched
+	toPickup := driverLocation(ride, update, now)
+	if toPickup.DistanceToPickupKm != 0 || toPickup.DistanceRemainingKm != 0 {
+		t.Fatalf("expected zero distance to pickup, got %+v", toPickup)
+	}
+	if !toPickup.EstimatedArrival.Equal(now) {
+		t.Fatalf("expected arrival now, got %v", toPickup.EstimatedArrival)
+	}
+
+	ride.Status = models.RideStatusInProgress
+	toDest := driverLocation(ride, update, now)
+	if toDest.DistanceRemainingKm < 3 || toDest.DistanceRemainingKm > 4 {
+		t.Fatalf("expected about 3.6 km to destination, got %v", toDest.DistanceRemainingKm)
+	}
+	// No reported speed: the default 30 km/h is used.
+	wantETA := now.Add(time.Duration(toDest.DistanceRemainingKm / defaultSpeedKmh * float64(time.Hour)))
+	if !toDest.EstimatedArrival.Equal(wantETA) {
+		t.Fatalf("expected arrival %v, got %v", wantETA, toDest.EstimatedArrival)
+	}
+}
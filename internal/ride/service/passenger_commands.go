@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/logger"
+)
+
+// defaultCancelReason is stored when the passenger gives no reason.
+const defaultCancelReason = "Cancelled by passenger"
+
+var (
+	ErrRideNotOwned       = errors.New("ride does not belong to the passenger")
+	ErrRideNotCancellable = errors.New("ride can no longer be cancelled")
+	ErrPickupLocked       = errors.New("pickup can only be changed before the driver arrives")
+	ErrRideNotActive      = errors.New("ride is not active")
+)
+
+// Ride statuses in which each passenger command is accepted.
+var (
+	cancellableStatuses = map[models.RideStatus]bool{
+		models.RideStatusRequested: true,
+		models.RideStatusMatched:   true,
+		models.RideStatusEnRoute:   true,
+		models.RideStatusArrived:   true,
+	}
+	pickupEditableStatuses = map[models.RideStatus]bool{
+		models.RideStatusRequested: true,
+		models.RideStatusMatched:   true,
+		models.RideStatusEnRoute:   true,
+	}
+	activeStatuses = map[models.RideStatus]bool{
+		models.RideStatusRequested:  true,
+		models.RideStatusMatched:    true,
+		models.RideStatusEnRoute:    true,
+		models.RideStatusArrived:    true,
+		models.RideStatusInProgress: true,
+	}
+)
+
+// CancelRide cancels the passenger's ride before it starts and tells the assigned driver.
+func (s *RideService) CancelRide(ctx context.Context, passengerID, rideID, reason string) (models.Ride, error) {
+	ride, err := s.passengerRide(ctx, passengerID, rideID)
+	if err != nil {
+		return models.Ride{}, err
+	}
+	if !cancellableStatuses[ride.Status] {
+		return models.Ride{}, ErrRideNotCancellable
+	}
+	if reason == "" {
+		reason = defaultCancelReason
+	}
+
+	// The driver comes from the cancelled row: one may have been assigned since the read
+	driverID, err := s.repo.CloseRide(ctx, ride.ID, reason)
+	if err != nil {
+		if errors.Is(err, models.ErrRideNotFound) {
+			// The ride started or finished between the read and the update
+			return models.Ride{}, ErrRideNotCancellable
+		}
+		s.logError(ctx, "db_error", "failed to cancel ride", err)
+		return models.Ride{}, err
+	}
+	ride.Status = models.RideStatusCancelled
+	ride.DriverID = driverID
+
+	ctx = logger.WithRideID(ctx, ride.ID)
+	s.logInfo(ctx, "ride_cancelled", "ride cancelled by passenger", map[string]any{
+		"passenger_id": passengerID,
+		"reason":       reason,
+	})
+
+	if err := s.publishRideStatusUpdate(ctx, &ride); err != nil {
+		s.logError(ctx, "publish_error", "failed to publish ride cancellation", err)
+	}
+	if ride.DriverID != "" {
+		s.publishPassengerUpdate(ctx, messages.PassengerUpdate{
+			Type:        messages.PassengerUpdateCancelled,
+			RideID:      ride.ID,
+			PassengerID: ride.PassengerID,
+			DriverID:    ride.DriverID,
+			Reason:      reason,
+		})
+	}
+
+	return ride, nil
+}
+
+// UpdatePickup moves the pickup point of a ride the driver has not arrived for yet
+// and re-quotes the fare with the tariff the ride was requested under. A pooled ride
+// keeps its pickup once it is matched into a shared trip.
+func (s *RideService) UpdatePickup(ctx context.Context, passengerID, rideID string, pickup models.Location) (models.Ride, error) {
+	if err := validateLanLon(pickup.Latitude, pickup.Longitude); err != nil {
+		return models.Ride{}, err
+	}
+
+	ride, err := s.passengerRide(ctx, passengerID, rideID)
+	if err != nil {
+		return models.Ride{}, err
+	}
+	if !pickupEditableStatuses[ride.Status] {
+		return models.Ride{}, ErrPickupLocked
+	}
+	if ride.Pooled && ride.DriverID != "" {
+		return models.Ride{}, ErrSharedRideRoute
+	}
+
+	zoneRes, err := applyZones(s.zones, pickup, ride.DestinationLocation)
+	if err != nil {
+		return models.Ride{}, err
+	}
+	tariff, err := resolveTariff(s.catalog, ride.VehicleType, ride.CityID, ride.RequestedAt)
+	if err != nil {
+		return models.Ride{}, err
+	}
+
+	breakdown, distanceKm, durationMin := estimate(tariff, zoneRes.Pickup, ride.DestinationLocation, zoneRes.Surcharge, ride.Currency)
+	applyPoolDiscount(&breakdown, ride)
+	if err := s.reapplyPromo(ctx, ride, &breakdown); err != nil {
+		return models.Ride{}, err
+	}
+	fare := breakdown.Total
+	ride.PickupLocation = zoneRes.Pickup
+	ride.PickupAdjusted = zoneRes.PickupAdjusted
+	ride.ZoneSurcharge = zoneRes.Surcharge
+	ride.EstimatedFare = &fare
+	ride.FareBreakdown = &breakdown
+	ride.EstimatedDistanceKm = distanceKm
+	ride.EstimatedDurationMinutes = durationMin
+
+	if err := s.repo.UpdatePickup(ctx, &ride); err != nil {
+		if errors.Is(err, models.ErrRideNotFound) {
+			// The driver arrived between the read and the update
+			return models.Ride{}, ErrPickupLocked
+		}
+		s.logError(ctx, "db_error", "failed to update pickup", err)
+		return models.Ride{}, err
+	}
+
+	ctx = logger.WithRideID(ctx, ride.ID)
+	s.logInfo(ctx, "pickup_updated", "pickup moved by passenger", map[string]any{
+		"passenger_id":   passengerID,
+		"estimated_fare": fare,
+	})
+
+	if ride.DriverID != "" {
+		s.publishPassengerUpdate(ctx, messages.PassengerUpdate{
+			Type:        messages.PassengerUpdatePickup,
+			RideID:      ride.ID,
+			PassengerID: ride.PassengerID,
+			DriverID:    ride.DriverID,
+			Location: &messages.Coordinate{
+				Lat:     ride.PickupLocation.Latitude,
+				Lng:     ride.PickupLocation.Longitude,
+				Address: ride.PickupLocation.Address,
+			},
+			EstimatedFare: &fare,
+		})
+	}
+
+	return ride, nil
+}
+
+// ChangeDestination points an active ride to a new destination. The fare is re-estimated
+// with the tariff the ride was requested under and the ETA is counted from the driver's
+// current position; the driver gets the new route. Pooled rides keep their destination:
+// the other riders were matched to the route.
+func (s *RideService) ChangeDestination(ctx context.Context, passengerID, rideID string, destination models.Location) (models.Ride, time.Time, error) {
+	if err := validateLanLon(destination.Latitude, destination.Longitude); err != nil {
+		return models.Ride{}, time.Time{}, err
+	}
+
+	ride, err := s.passengerRide(ctx, passengerID, rideID)
+	if err != nil {
+		return models.Ride{}, time.Time{}, err
+	}
+	if !activeStatuses[ride.Status] {
+		return models.Ride{}, time.Time{}, ErrRideNotActive
+	}
+	if ride.Pooled {
+		return models.Ride{}, time.Time{}, ErrSharedRideRoute
+	}
+
+	zoneRes, err := applyZones(s.zones, ride.PickupLocation, destination)
+	if err != nil {
+		return models.Ride{}, time.Time{}, err
+	}
+	tariff, err := resolveTariff(s.catalog, ride.VehicleType, ride.CityID, ride.RequestedAt)
+	if err != nil {
+		return models.Ride{}, time.Time{}, err
+	}
+
+	var driverAt *models.Location
+	if ride.DriverID != "" {
+		if loc, err := s.repo.GetDriverLocation(ctx, ride.DriverID); err == nil {
+			driverAt = &loc
+		} else if !errors.Is(err, models.ErrRideNotFound) {
+			s.logError(ctx, "db_error", "failed to get driver location", err)
+		}
+	}
+
+	tripKm, remainingKm := routeEstimate(ride, driverAt, destination)
+	durationMin := estimateDuration(tripKm)
+	breakdown := fareBreakdown(tariff, tripKm, durationMin, zoneRes.Surcharge, ride.Currency)
+	if err := s.reapplyPromo(ctx, ride, &breakdown); err != nil {
+		return models.Ride{}, time.Time{}, err
+	}
+	fare := breakdown.Total
+	eta := time.Now().Add(time.Duration(estimateDuration(remainingKm)) * time.Minute)
+
+	previous := ride.DestinationLocation
+	ride.DestinationLocation = destination
+	ride.ZoneSurcharge = zoneRes.Surcharge
+	ride.EstimatedFare = &fare
+	ride.FareBreakdown = &breakdown
+	ride.EstimatedDistanceKm = tripKm
+	ride.EstimatedDurationMinutes = durationMin
+
+	if err := s.repo.UpdateDestination(ctx, &ride, previous); err != nil {
+		if errors.Is(err, models.ErrRideNotFound) {
+			// The ride finished between the read and the update
+			return models.Ride{}, time.Time{}, ErrRideNotActive
+		}
+		s.logError(ctx, "db_error", "failed to update destination", err)
+		return models.Ride{}, time.Time{}, err
+	}
+
+	ctx = logger.WithRideID(ctx, ride.ID)
+	s.logInfo(ctx, "destination_changed", "destination changed by passenger", map[string]any{
+		"passenger_id":   passengerID,
+		"estimated_fare": fare,
+	})
+
+	if ride.DriverID != "" {
+		s.publishPassengerUpdate(ctx, messages.PassengerUpdate{
+			Type:        messages.PassengerUpdateDestination,
+			RideID:      ride.ID,
+			PassengerID: ride.PassengerID,
+			DriverID:    ride.DriverID,
+			Location: &messages.Coordinate{
+				Lat:     destination.Latitude,
+				Lng:     destination.Longitude,
+				Address: destination.Address,
+			},
+			EstimatedFare:    &fare,
+			DistanceKm:       remainingKm,
+			DurationMinutes:  estimateDuration(remainingKm),
+			EstimatedArrival: &eta,
+		})
+	}
+
+	return ride, eta, nil
+}
+
+// routeEstimate returns the trip length to bill and the distance the driver still
+// has to cover to the destination. Once the ride is in progress the trip is the way
+// already driven from the pickup plus the rest from the driver's position; before
+// that the driver first has to reach the pickup.
+func routeEstimate(ride models.Ride, driverAt *models.Location, destination models.Location) (tripKm, remainingKm float64) {
+	direct := distanceBetween(ride.PickupLocation, destination)
+	if driverAt == nil {
+		return direct, direct
+	}
+	if ride.Status == models.RideStatusInProgress {
+		rest := distanceBetween(*driverAt, destination)
+		return distanceBetween(ride.PickupLocation, *driverAt) + rest, rest
+	}
+	return direct, distanceBetween(*driverAt, ride.PickupLocation) + direct
+}
+
+// ShareLocation stores the passenger's live position and relays it to the assigned driver.
+func (s *RideService) ShareLocation(ctx context.Context, passengerID, rideID string, loc models.Location) error {
+	if err := validateLanLon(loc.Latitude, loc.Longitude); err != nil {
+		return err
+	}
+
+	ride, err := s.passengerRide(ctx, passengerID, rideID)
+	if err != nil {
+		return err
+	}
+	if !activeStatuses[ride.Status] {
+		return ErrRideNotActive
+	}
+
+	if err := s.repo.SavePassengerLocation(ctx, passengerID, loc); err != nil {
+		s.logError(ctx, "db_error", "failed to save passenger location", err)
+		return err
+	}
+
+	if ride.DriverID != "" {
+		s.publishPassengerUpdate(ctx, messages.PassengerUpdate{
+			Type:        messages.PassengerUpdateLocation,
+			RideID:      ride.ID,
+			PassengerID: passengerID,
+			DriverID:    ride.DriverID,
+			Location: &messages.Coordinate{
+				Lat:     loc.Latitude,
+				Lng:     loc.Longitude,
+				Address: loc.Address,
+			},
+		})
+	}
+
+	return nil
+}
+
+// passengerRide loads the ride and checks that it belongs to the passenger.
+func (s *RideService) passengerRide(ctx context.Context, passengerID, rideID string) (models.Ride, error) {
+	ride, err := s.repo.GetRide(ctx, rideID)
+	if err != nil {
+		return models.Ride{}, err
+	}
+	if ride.PassengerID != passengerID {
+		return models.Ride{}, ErrRideNotOwned
+	}
+	return ride, nil
+}
+
+// publishPassengerUpdate relays a passenger event to the driver service. Failures are
+// only logged: the passenger action has already been applied.
+func (s *RideService) publishPassengerUpdate(ctx context.Context, update messages.PassengerUpdate) {
+	if s.publisher == nil {
+		return
+	}
+	update.Timestamp = time.Now()
+
+	body, err := json.Marshal(update)
+	if err != nil {
+		s.logError(ctx, "publish_error", "failed to encode passenger update", err)
+		return
+	}
+
+	routingKey := messages.PassengerUpdateRoutingKey(update.RideID)
+	if err := s.publisher.Publish(ctx, messages.ExchangeRideTopic, routingKey, body); err != nil {
+		s.logError(ctx, "publish_error", "failed to publish passenger update", err)
+	}
+}
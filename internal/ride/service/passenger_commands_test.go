@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/broker/messages"
+)
+
+type mockPublisher struct {
+	published []published
+}
+
+type published struct {
+	exchange, routingKey string
+	body                 []byte
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, exchangeName, routingKey string, body []byte) error {
+	m.published = append(m.published, published{exchange: exchangeName, routingKey: routingKey, body: body})
+	return nil
+}
+
+// passengerUpdates decodes the passenger updates sent to the driver service.
+func (m *mockPublisher) passengerUpdates(t *testing.T) []messages.PassengerUpdate {
+	t.Helper()
+	var result []messages.PassengerUpdate
+	for _, p := range m.published {
+		if p.routingKey != messages.PassengerUpdateRoutingKey("ride-1") {
+			continue
+		}
+		var update messages.PassengerUpdate
+		if err := json.Unmarshal(p.body, &update); err != nil {
+			t.Fatalf("invalid passenger update: %v", err)
+		}
+		result = append(result, update)
+	}
+	return result
+}
+
+func testRide(status models.RideStatus, driverID string) models.Ride {
+	fare := major(1500)
+	return models.Ride{
+		ID:                  "ride-1",
+		PassengerID:         "passenger-1",
+		DriverID:            driverID,
+		VehicleType:         models.VehicleTypeEconomy,
+		Status:              status,
+		PickupLocation:      models.Location{Latitude: 43.238949, Longitude: 76.889709},
+		DestinationLocation: models.Location{Latitude: 43.222015, Longitude: 76.851511},
+		EstimatedFare:       &fare,
+		Currency:            "KZT",
+		RequestedAt:         time.Now(),
+	}
+}
+
+func newCommandService(ride models.Ride, repo *mockRideRepo, pub *mockPublisher) *RideService {
+	repo.getRideFunc = func(ctx context.Context, id string) (models.Ride, error) {
+		if id != ride.ID {
+			return models.Ride{}, models.ErrRideNotFound
+		}
+		return ride, nil
+	}
+	return NewRideService(repo, nil, newCatalog(), nil, pub, nil, []byte("secret"))
+}
+
+func TestCancelRide(t *testing.T) {
+	cases := []struct {
+		name        string
+		status      models.RideStatus
+		passengerID string
+		driverID    string
+		// closedDriver is the driver of the ride when it is cancelled
+		closedDriver string
+		closeErr     error
+		wantErr      error
+		wantRelay    bool
+	}{
+		{name: "requested ride", status: models.RideStatusRequested, passengerID: "passenger-1"},
+		{name: "driver on the way", status: models.RideStatusEnRoute, passengerID: "passenger-1", driverID: "driver-1", closedDriver: "driver-1", wantRelay: true},
+		{name: "driver assigned after the read", status: models.RideStatusRequested, passengerID: "passenger-1", closedDriver: "driver-1", wantRelay: true},
+		{name: "started after the read", status: models.RideStatusArrived, passengerID: "passenger-1", driverID: "driver-1", closeErr: models.ErrRideNotFound, wantErr: ErrRideNotCancellable},
+		{name: "ride in progress", status: models.RideStatusInProgress, passengerID: "passenger-1", wantErr: ErrRideNotCancellable},
+		{name: "someone else's ride", status: models.RideStatusRequested, passengerID: "passenger-2", wantErr: ErrRideNotOwned},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var closedReason string
+			repo := &mockRideRepo{
+				closeRideFunc: func(ctx context.Context, id, reason string) (string, error) {
+					closedReason = reason
+					return tc.closedDriver, tc.closeErr
+				},
+			}
+			pub := &mockPublisher{}
+			svc := newCommandService(testRide(tc.status, tc.driverID), repo, pub)
+
+			ride, err := svc.CancelRide(context.Background(), tc.passengerID, "ride-1", "")
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ride.Status != models.RideStatusCancelled || closedReason != defaultCancelReason {
+				t.Fatalf("expected cancelled ride with default reason, got %s %q", ride.Status, closedReason)
+			}
+			if got := len(pub.passengerUpdates(t)) == 1; got != tc.wantRelay {
+				t.Fatalf("expected relay to driver %v, got %v", tc.wantRelay, got)
+			}
+		})
+	}
+}
+
+func TestUpdatePickup(t *testing.T) {
+	newPickup := models.Location{Latitude: 43.25, Longitude: 76.92, Address: "New pickup"}
+
+	t.Run("re-quotes the fare", func(t *testing.T) {
+		var stored *models.Ride
+		repo := &mockRideRepo{
+			updatePickupFunc: func(ctx context.Context, ride *models.Ride) error {
+				stored = ride
+				return nil
+			},
+		}
+		pub := &mockPublisher{}
+		svc := newCommandService(testRide(models.RideStatusMatched, "driver-1"), repo, pub)
+
+		ride, err := svc.UpdatePickup(context.Background(), "passenger-1", "ride-1", newPickup)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stored == nil || stored.PickupLocation != newPickup {
+			t.Fatalf("expected new pickup to be stored, got %+v", stored)
+		}
+
+		tariff, _ := resolveTariff(svc.catalog, models.VehicleTypeEconomy, "", ride.RequestedAt)
+		wantFare, _, _ := quote(tariff, newPickup, ride.DestinationLocation, ride.ZoneSurcharge, "KZT")
+		if *ride.EstimatedFare != wantFare {
+			t.Fatalf("expected fare %v, got %v", wantFare, *ride.EstimatedFare)
+		}
+
+		updates := pub.passengerUpdates(t)
+		if len(updates) != 1 || updates[0].Type != messages.PassengerUpdatePickup {
+			t.Fatalf("expected pickup update for the driver, got %+v", updates)
+		}
+	})
+
+	t.Run("driver already arrived", func(t *testing.T) {
+		svc := newCommandService(testRide(models.RideStatusArrived, "driver-1"), &mockRideRepo{}, &mockPublisher{})
+
+		_, err := svc.UpdatePickup(context.Background(), "passenger-1", "ride-1", newPickup)
+		if !errors.Is(err, ErrPickupLocked) {
+			t.Fatalf("expected %v, got %v", ErrPickupLocked, err)
+		}
+	})
+
+	t.Run("driver arrived during update", func(t *testing.T) {
+		repo := &mockRideRepo{
+			updatePickupFunc: func(ctx context.Context, ride *models.Ride) error {
+				return models.ErrRideNotFound
+			},
+		}
+		svc := newCommandService(testRide(models.RideStatusEnRoute, "driver-1"), repo, &mockPublisher{})
+
+		_, err := svc.UpdatePickup(context.Background(), "passenger-1", "ride-1", newPickup)
+		if !errors.Is(err, ErrPickupLocked) {
+			t.Fatalf("expected %v, got %v", ErrPickupLocked, err)
+		}
+	})
+}
+
+func TestShareLocation(t *testing.T) {
+	loc := models.Location{Latitude: 43.24, Longitude: 76.89}
+
+	cases := []struct {
+		name      string
+		status    models.RideStatus
+		driverID  string
+		wantErr   error
+		wantSaved bool
+		wantRelay bool
+	}{
+		{name: "waiting for a driver", status: models.RideStatusRequested, wantSaved: true},
+		{name: "driver assigned", status: models.RideStatusEnRoute, driverID: "driver-1", wantSaved: true, wantRelay: true},
+		{name: "finished ride", status: models.RideStatusCompleted, driverID: "driver-1", wantErr: ErrRideNotActive},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mockRideRepo{}
+			pub := &mockPublisher{}
+			svc := newCommandService(testRide(tc.status, tc.driverID), repo, pub)
+
+			err := svc.ShareLocation(context.Background(), "passenger-1", "ride-1", loc)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if got := len(repo.savedLocations) == 1; got != tc.wantSaved {
+				t.Fatalf("expected saved %v, got %v", tc.wantSaved, got)
+			}
+			if got := len(pub.passengerUpdates(t)) == 1; got != tc.wantRelay {
+				t.Fatalf("expected relay to driver %v, got %v", tc.wantRelay, got)
+			}
+		})
+	}
+}
+
+func TestChangeDestination(t *testing.T) {
+	newDestination := models.Location{Latitude: 43.26, Longitude: 76.95, Address: "New destination"}
+
+	t.Run("re-quotes the fare and tells the driver", func(t *testing.T) {
+		var stored *models.Ride
+		var previous models.Location
+		repo := &mockRideRepo{
+			updateDestFunc: func(ctx context.Context, ride *models.Ride, prev models.Location) error {
+				stored, previous = ride, prev
+				return nil
+			},
+		}
+		pub := &mockPublisher{}
+		original := testRide(models.RideStatusEnRoute, "driver-1")
+		svc := newCommandService(original, repo, pub)
+
+		ride, eta, err := svc.ChangeDestination(context.Background(), "passenger-1", "ride-1", newDestination)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stored == nil || stored.DestinationLocation != newDestination || previous != original.DestinationLocation {
+			t.Fatalf("expected new destination to be stored with the previous one, got %+v", stored)
+		}
+		if !eta.After(time.Now()) {
+			t.Fatalf("expected ETA in the future, got %v", eta)
+		}
+
+		tariff, _ := resolveTariff(svc.catalog, models.VehicleTypeEconomy, "", ride.RequestedAt)
+		wantFare, _, _ := quote(tariff, ride.PickupLocation, newDestination, ride.ZoneSurcharge, "KZT")
+		if *ride.EstimatedFare != wantFare {
+			t.Fatalf("expected fare %v, got %v", wantFare, *ride.EstimatedFare)
+		}
+
+		updates := pub.passengerUpdates(t)
+		if len(updates) != 1 || updates[0].Type != messages.PassengerUpdateDestination || updates[0].EstimatedArrival == nil {
+			t.Fatalf("expected destination update for the driver, got %+v", updates)
+		}
+	})
+
+	t.Run("finished ride", func(t *testing.T) {
+		svc := newCommandService(testRide(models.RideStatusCompleted, "driver-1"), &mockRideRepo{}, &mockPublisher{})
+
+		_, _, err := svc.ChangeDestination(context.Background(), "passenger-1", "ride-1", newDestination)
+		if !errors.Is(err, ErrRideNotActive) {
+			t.Fatalf("expected %v, got %v", ErrRideNotActive, err)
+		}
+	})
+
+	t.Run("ride finished during update", func(t *testing.T) {
+		repo := &mockRideRepo{
+			updateDestFunc: func(ctx context.Context, ride *models.Ride, prev models.Location) error {
+				return models.ErrRideNotFound
+			},
+		}
+		svc := newCommandService(testRide(models.RideStatusInProgress, "driver-1"), repo, &mockPublisher{})
+
+		_, _, err := svc.ChangeDestination(context.Background(), "passenger-1", "ride-1", newDestination)
+		if !errors.Is(err, ErrRideNotActive) {
+			t.Fatalf("expected %v, got %v", ErrRideNotActive, err)
+		}
+	})
+}
+
+func TestRouteEstimate(t *testing.T) {
+	ride := testRide(models.RideStatusInProgress, "driver-1")
+	destination := models.Location{Latitude: 43.26, Longitude: 76.95}
+	driverAt := models.Location{Latitude: 43.23, Longitude: 76.87}
+
+	direct := distanceBetween(ride.PickupLocation, destination)
+	driven := distanceBetween(ride.PickupLocation, driverAt)
+	rest := distanceBetween(driverAt, destination)
+
+	cases := []struct {
+		name          string
+		status        models.RideStatus
+		driverAt      *models.Location
+		wantTrip      float64
+		wantRemaining float64
+	}{
+		{name: "no driver position", status: models.RideStatusInProgress, wantTrip: direct, wantRemaining: direct},
+		{name: "in progress", status: models.RideStatusInProgress, driverAt: &driverAt, wantTrip: driven + rest, wantRemaining: rest},
+		{name: "driver on the way", status: models.RideStatusEnRoute, driverAt: &driverAt, wantTrip: direct, wantRemaining: driven + direct},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ride.Status = tc.status
+			trip, remaining := routeEstimate(ride, tc.driverAt, destination)
+			if trip != tc.wantTrip || remaining != tc.wantRemaining {
+				t.Fatalf("expected %.3f/%.3f km, got %.3f/%.3f km", tc.wantTrip, tc.wantRemaining, trip, remaining)
+			}
+		})
+	}
+}
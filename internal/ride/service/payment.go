@@ -0,0 +1,23 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"ride-hail/internal/ride/domain/models"
+)
+
+var ErrInvalidPaymentMethod = errors.New("invalid payment method")
+
+// paymentMethod normalizes the requested payment method; rides are paid by card by default.
+func paymentMethod(requested string) (string, error) {
+	switch method := strings.ToUpper(strings.TrimSpace(requested)); method {
+	case "":
+		return models.PaymentCard, nil
+	case models.PaymentCard, models.PaymentCash:
+		return method, nil
+	default:
+		return "", fmt.Errorf("%w: %q, use CASH or CARD", ErrInvalidPaymentMethod, requested)
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ride-hail/internal/ride/domain/models"
+)
+
+func TestQuote_PaymentMethod(t *testing.T) {
+	cases := []struct {
+		name    string
+		method  string
+		want    string
+		wantErr error
+	}{
+		{name: "default", method: "", want: models.PaymentCard},
+		{name: "cash", method: "cash", want: models.PaymentCash},
+		{name: "card", method: "CARD", want: models.PaymentCard},
+		{name: "unknown", method: "CRYPTO", wantErr: ErrInvalidPaymentMethod},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewRideService(&mockRideRepo{}, nil, newCatalog(), nil, nil, nil, []byte("secret"))
+
+			ride, err := svc.Quote(context.Background(), models.CreateRideCommand{
+				PassengerID:   "passenger-1",
+				VehicleType:   models.VehicleTypeEconomy,
+				Pickup:        models.Location{Latitude: 43.238949, Longitude: 76.889709},
+				Destination:   models.Location{Latitude: 43.222015, Longitude: 76.851511},
+				PaymentMethod: tc.method,
+			})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ride.PaymentMethod != tc.want {
+				t.Fatalf("expected %s, got %s", tc.want, ride.PaymentMethod)
+			}
+		})
+	}
+}
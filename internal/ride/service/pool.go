@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pool"
+	"ride-hail/internal/shared/pricing"
+)
+
+// poolDiscountPercent is taken off the fare of a pooled ride in exchange for sharing the car.
+const poolDiscountPercent = 25
+
+// ErrSharedRideRoute is returned for route changes that would move the other riders of a shared trip.
+var ErrSharedRideRoute = errors.New("route of a shared ride cannot be changed")
+
+// rideType maps the requested ride type to the vehicle class that serves it.
+func rideType(requested models.VehicleType) (models.VehicleType, bool) {
+	switch requested {
+	case "":
+		return models.VehicleTypeEconomy, false
+	case pool.RideType:
+		return pool.VehicleType, true
+	default:
+		return requested, false
+	}
+}
+
+// applyPoolDiscount takes the shared ride discount off the fare of a pooled ride.
+// It goes before the promo discount, which applies to what is left.
+func applyPoolDiscount(b *pricing.Breakdown, ride models.Ride) {
+	if !ride.Pooled {
+		return
+	}
+	b.Add(pricing.ItemPoolDiscount, b.Total.Percent(poolDiscountPercent, money.Down).Neg())
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pool"
+	"ride-hail/internal/shared/pricing"
+)
+
+func TestQuote_Pool(t *testing.T) {
+	cases := []struct {
+		name        string
+		rideType    models.VehicleType
+		wantVehicle models.VehicleType
+		wantPooled  bool
+	}{
+		{name: "pool", rideType: pool.RideType, wantVehicle: models.VehicleTypeEconomy, wantPooled: true},
+		{name: "economy", rideType: models.VehicleTypeEconomy, wantVehicle: models.VehicleTypeEconomy},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewRideService(&mockRideRepo{}, nil, newCatalog(), nil, nil, nil, []byte("secret"))
+
+			ride, err := svc.Quote(context.Background(), models.CreateRideCommand{
+				PassengerID: "passenger-1",
+				VehicleType: tc.rideType,
+				Pickup:      models.Location{Latitude: 43.238949, Longitude: 76.889709},
+				Destination: models.Location{Latitude: 43.222015, Longitude: 76.851511},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ride.VehicleType != tc.wantVehicle || ride.Pooled != tc.wantPooled {
+				t.Fatalf("expected %s pooled=%v, got %s pooled=%v", tc.wantVehicle, tc.wantPooled, ride.VehicleType, ride.Pooled)
+			}
+			if ride.RideType() != string(tc.rideType) {
+				t.Fatalf("expected ride type %s, got %s", tc.rideType, ride.RideType())
+			}
+
+			discount := ride.FareBreakdown.Amount(pricing.ItemPoolDiscount)
+			if !tc.wantPooled {
+				if !discount.IsZero() {
+					t.Fatalf("expected no pool discount, got %v", discount)
+				}
+				return
+			}
+			full := ride.FareBreakdown.Total.Sub(discount)
+			if want := full.Percent(poolDiscountPercent, money.Down).Neg(); discount != want {
+				t.Fatalf("expected pool discount %v, got %v", want, discount)
+			}
+		})
+	}
+}
+
+func TestSharedRideRoute(t *testing.T) {
+	newLocation := models.Location{Latitude: 43.25, Longitude: 76.92}
+
+	pooled := func(status models.RideStatus, driverID string) models.Ride {
+		ride := testRide(status, driverID)
+		ride.Pooled = true
+		return ride
+	}
+
+	t.Run("pickup of a matched pooled ride", func(t *testing.T) {
+		svc := newCommandService(pooled(models.RideStatusMatched, "driver-1"), &mockRideRepo{}, &mockPublisher{})
+
+		_, err := svc.UpdatePickup(context.Background(), "passenger-1", "ride-1", newLocation)
+		if !errors.Is(err, ErrSharedRideRoute) {
+			t.Fatalf("expected %v, got %v", ErrSharedRideRoute, err)
+		}
+	})
+
+	t.Run("pickup of a pooled ride waiting for a driver", func(t *testing.T) {
+		svc := newCommandService(pooled(models.RideStatusRequested, ""), &mockRideRepo{}, &mockPublisher{})
+
+		ride, err := svc.UpdatePickup(context.Background(), "passenger-1", "ride-1", newLocation)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ride.FareBreakdown.Amount(pricing.ItemPoolDiscount).IsZero() {
+			t.Fatalf("expected the pool discount to be kept, got %+v", ride.FareBreakdown)
+		}
+	})
+
+	t.Run("destination of a pooled ride", func(t *testing.T) {
+		svc := newCommandService(pooled(models.RideStatusInProgress, "driver-1"), &mockRideRepo{}, &mockPublisher{})
+
+		_, _, err := svc.ChangeDestination(context.Background(), "passenger-1", "ride-1", newLocation)
+		if !errors.Is(err, ErrSharedRideRoute) {
+			t.Fatalf("expected %v, got %v", ErrSharedRideRoute, err)
+		}
+	})
+}
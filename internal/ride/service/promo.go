@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/pricing"
+	"ride-hail/internal/shared/promo"
+)
+
+// checkPromo finds the code and checks that it applies to the ride and that the
+// campaign and the passenger have redemptions left. The limits are checked again
+// when the redemption is reserved with the ride.
+func (s *RideService) checkPromo(ctx context.Context, code string, ride *models.Ride) (promo.Campaign, error) {
+	campaign, err := s.repo.GetPromoCampaign(ctx, promo.NormalizeCode(code))
+	if err != nil {
+		return promo.Campaign{}, err
+	}
+	if err := campaign.CheckEligible(string(ride.VehicleType), ride.CityID, ride.Currency, ride.RequestedAt); err != nil {
+		return promo.Campaign{}, err
+	}
+
+	redeemedByUser, err := s.repo.CountPromoRedemptions(ctx, campaign.ID, ride.PassengerID)
+	if err != nil {
+		return promo.Campaign{}, err
+	}
+	if err := campaign.CheckLimits(campaign.Redeemed, redeemedByUser); err != nil {
+		return promo.Campaign{}, err
+	}
+
+	return campaign, nil
+}
+
+// applyDiscount takes the campaign discount off the whole fare as a negative line item.
+func applyDiscount(b *pricing.Breakdown, campaign promo.Campaign) {
+	b.Add(pricing.ItemDiscount, campaign.Discount(b.Total).Neg())
+}
+
+// reapplyPromo keeps the reserved discount on a re-quoted fare. The code was checked
+// when the ride was requested, so only the discount is recalculated.
+func (s *RideService) reapplyPromo(ctx context.Context, ride models.Ride, b *pricing.Breakdown) error {
+	if ride.PromoCode == "" {
+		return nil
+	}
+	campaign, err := s.repo.GetPromoCampaign(ctx, ride.PromoCode)
+	if err != nil {
+		return err
+	}
+	applyDiscount(b, campaign)
+	return nil
+}
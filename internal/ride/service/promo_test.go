@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
+	"ride-hail/internal/shared/promo"
+)
+
+func TestQuote_Promo(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	summer := promo.Campaign{ID: "campaign-1", Code: "SUMMER", DiscountType: promo.DiscountPercent, PercentOff: 10, IsActive: true}
+
+	cases := []struct {
+		name         string
+		code         string
+		campaign     promo.Campaign
+		redeemed     int
+		byUser       int
+		wantErr      error
+		wantDiscount bool
+	}{
+		{name: "no code"},
+		{name: "applied", code: " summer ", campaign: summer, wantDiscount: true},
+		{name: "unknown", code: "WINTER", campaign: summer, wantErr: promo.ErrCodeNotFound},
+		{name: "expired", code: "SUMMER", campaign: withCampaign(summer, func(c *promo.Campaign) { c.ValidUntil = &past }), wantErr: promo.ErrCodeExpired},
+		{name: "not started", code: "SUMMER", campaign: withCampaign(summer, func(c *promo.Campaign) { c.ValidFrom = &future }), wantErr: promo.ErrCodeExpired},
+		{name: "other vehicle type", code: "SUMMER", campaign: withCampaign(summer, func(c *promo.Campaign) { c.VehicleTypes = []string{"ELECTRIC"} }), wantErr: promo.ErrCodeNotEligible},
+		{name: "exhausted", code: "SUMMER", campaign: withCampaign(summer, func(c *promo.Campaign) { c.MaxRedemptions = 5; c.Redeemed = 5 }), wantErr: promo.ErrCodeExhausted},
+		{name: "used up by passenger", code: "SUMMER", campaign: withCampaign(summer, func(c *promo.Campaign) { c.MaxPerUser = 1 }), byUser: 1, wantErr: promo.ErrCodeUsedUp},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mockRideRepo{
+				promoFunc: func(ctx context.Context, code string) (promo.Campaign, error) {
+					if code != tc.campaign.Code {
+						return promo.Campaign{}, promo.ErrCodeNotFound
+					}
+					return tc.campaign, nil
+				},
+				promoUsedFunc: func(ctx context.Context, campaignID, passengerID string) (int, error) {
+					return tc.byUser, nil
+				},
+			}
+			svc := NewRideService(repo, nil, newCatalog(), nil, nil, nil, []byte("secret"))
+
+			ride, err := svc.Quote(context.Background(), models.CreateRideCommand{
+				PassengerID: "passenger-1",
+				Pickup:      models.Location{Latitude: 43.238949, Longitude: 76.889709},
+				Destination: models.Location{Latitude: 43.222015, Longitude: 76.851511},
+				PromoCode:   tc.code,
+			})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			discount := ride.FareBreakdown.Amount(pricing.ItemDiscount)
+			if !tc.wantDiscount {
+				if !discount.IsZero() || ride.PromoCode != "" {
+					t.Fatalf("expected no discount, got %v (%q)", discount, ride.PromoCode)
+				}
+				return
+			}
+
+			full := ride.FareBreakdown.Total.Sub(discount)
+			if want := full.Percent(10, money.Down).Neg(); discount != want {
+				t.Fatalf("expected discount %v, got %v", want, discount)
+			}
+			if *ride.EstimatedFare != ride.FareBreakdown.Total {
+				t.Fatalf("expected estimated fare %v to be discounted, got %v", ride.FareBreakdown.Total, *ride.EstimatedFare)
+			}
+			if ride.PromoCode != "SUMMER" || ride.PromoCampaignID != "campaign-1" {
+				t.Fatalf("expected the campaign on the ride, got %q %q", ride.PromoCode, ride.PromoCampaignID)
+			}
+		})
+	}
+}
+
+func withCampaign(c promo.Campaign, change func(c *promo.Campaign)) promo.Campaign {
+	change(&c)
+	return c
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/pricing"
+)
+
+var ErrReceiptNotReady = errors.New("receipt is available once the ride is finished and charged")
+
+// Receipt returns the receipt of the passenger's ride. Completed rides and rides
+// cancelled with a fee have one; the fare is itemized when the driver service stored
+// the breakdown, otherwise it is a single line. Passengers who accepted to split the
+// fare get the receipt too, with the share each passenger was charged.
+func (s *RideService) Receipt(ctx context.Context, passengerID, rideID string) (models.Receipt, error) {
+	ride, splits, err := s.FareSplit(ctx, passengerID, rideID)
+	if err != nil {
+		return models.Receipt{}, err
+	}
+	if split := findSplit(splits, passengerID); ride.PassengerID != passengerID && split.Status != models.SplitAccepted {
+		return models.Receipt{}, ErrRideNotOwned
+	}
+
+	var endedAt *time.Time
+	switch ride.Status {
+	case models.RideStatusCompleted:
+		endedAt = ride.CompletedAt
+	case models.RideStatusCancelled:
+		endedAt = ride.CancelledAt
+	}
+	if endedAt == nil || ride.FinalFare == nil || ride.FinalFare.IsZero() {
+		return models.Receipt{}, ErrReceiptNotReady
+	}
+
+	var fare pricing.Breakdown
+	if ride.FinalFareBreakdown != nil {
+		fare = ride.FinalFareBreakdown.Clone()
+	} else {
+		fare.Add(pricing.ItemFare, *ride.FinalFare)
+	}
+
+	receipt := models.Receipt{
+		RideID:      ride.ID,
+		RideNumber:  ride.RideNumber,
+		Status:      ride.Status,
+		VehicleType: ride.VehicleType,
+		Pickup:      ride.PickupLocation,
+		Destination: ride.DestinationLocation,
+		StartedAt:   ride.StartedAt,
+		EndedAt:     *endedAt,
+		Fare:        fare.WithCurrency(ride.Currency),
+		IssuedAt:    time.Now(),
+	}
+	if ride.Status == models.RideStatusCancelled {
+		receipt.Reason = ride.CancellationReason
+	}
+	if ride.Status == models.RideStatusCompleted {
+		if shares := fareShares(ride.PassengerID, splits, receipt.Fare.Total); len(shares) > 1 {
+			receipt.Shares = shares
+			for _, share := range shares {
+				if share.PassengerID == passengerID {
+					amount := share.Amount
+					receipt.Share = &amount
+				}
+			}
+		}
+	}
+	return receipt, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
+)
+
+func TestReceipt(t *testing.T) {
+	endedAt := time.Now().Add(-time.Minute)
+	fare := money.New(180000, "KZT")
+
+	var itemized pricing.Breakdown
+	itemized.Add(pricing.ItemBase, money.New(50000, "KZT"))
+	itemized.Add(pricing.ItemDistance, money.New(100000, "KZT"))
+	itemized.Add(pricing.ItemWaiting, money.New(30000, "KZT"))
+
+	finished := func(status models.RideStatus, breakdown *pricing.Breakdown) models.Ride {
+		ride := testRide(status, "driver-1")
+		ride.FinalFare = &fare
+		ride.FinalFareBreakdown = breakdown
+		if status == models.RideStatusCompleted {
+			ride.CompletedAt = &endedAt
+		} else {
+			ride.CancelledAt = &endedAt
+			ride.CancellationReason = "Passenger did not show up"
+		}
+		return ride
+	}
+
+	cases := []struct {
+		name        string
+		ride        models.Ride
+		passengerID string
+		wantErr     error
+		wantItems   []string
+	}{
+		{name: "itemized", ride: finished(models.RideStatusCompleted, &itemized), passengerID: "passenger-1", wantItems: []string{pricing.ItemBase, pricing.ItemDistance, pricing.ItemWaiting}},
+		{name: "without breakdown", ride: finished(models.RideStatusCompleted, nil), passengerID: "passenger-1", wantItems: []string{pricing.ItemFare}},
+		{name: "no-show fee", ride: finished(models.RideStatusCancelled, nil), passengerID: "passenger-1", wantItems: []string{pricing.ItemFare}},
+		{name: "ride in progress", ride: testRide(models.RideStatusInProgress, "driver-1"), passengerID: "passenger-1", wantErr: ErrReceiptNotReady},
+		{name: "someone else's ride", ride: finished(models.RideStatusCompleted, &itemized), passengerID: "passenger-2", wantErr: ErrRideNotOwned},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			svc := newCommandService(tc.ride, &mockRideRepo{}, &mockPublisher{})
+
+			receipt, err := svc.Receipt(context.Background(), tc.passengerID, "ride-1")
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if receipt.Fare.Total != fare || !receipt.EndedAt.Equal(endedAt) {
+				t.Fatalf("expected total %v ended at %v, got %+v", fare, endedAt, receipt)
+			}
+			if len(receipt.Fare.Items) != len(tc.wantItems) {
+				t.Fatalf("expected items %v, got %+v", tc.wantItems, receipt.Fare.Items)
+			}
+			for i, item := range receipt.Fare.Items {
+				if item.Type != tc.wantItems[i] {
+					t.Fatalf("expected item %d to be %s, got %s", i, tc.wantItems[i], item.Type)
+				}
+			}
+		})
+	}
+}
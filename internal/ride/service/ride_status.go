@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/ride/domain/ports"
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/broker/rabbitmq"
+	"ride-hail/internal/shared/logger"
+)
+
+// passengerStatuses are the ride statuses reported by the driver service that
+// the passenger is told about.
+var passengerStatuses = map[string]bool{
+	string(models.RideStatusArrived):    true,
+	string(models.RideStatusInProgress): true,
+	string(models.RideStatusCompleted):  true,
+	string(models.RideStatusCancelled):  true,
+}
+
+// RideStatusConsumer consumes ride status changes from ride_topic, charges the
+// passengers for finished rides and pushes the changes to them.
+type RideStatusConsumer struct {
+	repo     ports.RideRepository
+	consume  ports.Consume
+	notifier ports.PassengerNotifier
+	payments ports.Payments
+	logger   *logger.Logger
+}
+
+func NewRideStatusConsumer(
+	repo ports.RideRepository,
+	consume ports.Consume,
+	notifier ports.PassengerNotifier,
+	payments ports.Payments,
+	log *logger.Logger,
+) *RideStatusConsumer {
+	return &RideStatusConsumer{
+		repo:     repo,
+		consume:  consume,
+		notifier: notifier,
+		payments: payments,
+		logger:   log,
+	}
+}
+
+func (c *RideStatusConsumer) Start(ctx context.Context) error {
+	ch, err := c.consume.Consume(ctx, messages.QueueRideStatus, "")
+	if err != nil {
+		return err
+	}
+
+	go c.processMessages(ctx, ch)
+	return nil
+}
+
+func (c *RideStatusConsumer) processMessages(ctx context.Context, ch <-chan rabbitmq.Message) {
+	for msg := range ch {
+		var update messages.RideStatusUpdate
+		if err := json.Unmarshal(msg.Body(), &update); err != nil {
+			c.logError(ctx, "ride_status_failed", "invalid ride status update", err)
+		} else if err := c.Handle(ctx, update); err != nil {
+			c.logError(ctx, "ride_status_failed", "failed to notify passenger", err)
+		}
+		_ = msg.Ack(false)
+	}
+}
+
+// Handle charges the final fare of a finished ride and pushes the status change to
+// the passenger if they are connected. A completed fare is split between the owner and
+// the passengers who accepted to share it, who are told about the completion too; a
+// cancellation fee is charged to the owner.
+func (c *RideStatusConsumer) Handle(ctx context.Context, update messages.RideStatusUpdate) error {
+	if !passengerStatuses[update.Status] {
+		return nil
+	}
+
+	ride, err := c.repo.GetRide(ctx, update.RideID)
+	if err != nil {
+		return err
+	}
+
+	recipients := []string{ride.PassengerID}
+	if update.FinalFare != nil {
+		shares := []models.FareShare{{PassengerID: ride.PassengerID, Amount: *update.FinalFare, Owner: true}}
+		if update.Status == string(models.RideStatusCompleted) {
+			splits, err := c.repo.ListFareSplits(ctx, ride.ID)
+			if err != nil {
+				return err
+			}
+			shares = fareShares(ride.PassengerID, splits, *update.FinalFare)
+			for _, share := range shares[1:] {
+				recipients = append(recipients, share.PassengerID)
+			}
+		}
+		if c.payments != nil {
+			if err := c.payments.Charge(ctx, ride.ID, shares); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, passengerID := range recipients {
+		if !c.notifier.IsConnected(passengerID) {
+			continue
+		}
+		if err := c.notifier.SendRideStatus(passengerID, update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *RideStatusConsumer) logError(ctx context.Context, action, message string, err error) {
+	if c.logger != nil {
+		c.logger.Error(ctx, action, message, err)
+	}
+}
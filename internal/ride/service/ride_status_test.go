@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/broker/messages"
+)
+
+func TestRideStatusConsumer_Handle(t *testing.T) {
+	fee := major(500)
+
+	cases := []struct {
+		name      string
+		update    messages.RideStatusUpdate
+		connected bool
+		wantSent  bool
+	}{
+		{name: "driver arrived", update: messages.RideStatusUpdate{RideID: "ride-1", Status: "ARRIVED"}, connected: true, wantSent: true},
+		{name: "no-show cancellation", update: messages.RideStatusUpdate{RideID: "ride-1", Status: "CANCELLED", FinalFare: &fee}, connected: true, wantSent: true},
+		{name: "passenger offline", update: messages.RideStatusUpdate{RideID: "ride-1", Status: "ARRIVED"}},
+		{name: "not for the passenger", update: messages.RideStatusUpdate{RideID: "ride-1", Status: "REQUESTED"}, connected: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mockRideRepo{
+				getRideFunc: func(ctx context.Context, id string) (models.Ride, error) {
+					return testRide(models.RideStatusArrived, "driver-1"), nil
+				},
+			}
+			notifier := &mockPassengerNotifier{connected: map[string]bool{"passenger-1": tc.connected}}
+			c := NewRideStatusConsumer(repo, nil, notifier, nil, nil)
+
+			if err := c.Handle(context.Background(), tc.update); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := len(notifier.statuses) == 1; got != tc.wantSent {
+				t.Fatalf("expected sent %v, got %d", tc.wantSent, len(notifier.statuses))
+			}
+			if tc.wantSent && notifier.statuses[0].FinalFare != tc.update.FinalFare {
+				t.Fatalf("expected fee %v forwarded, got %+v", tc.update.FinalFare, notifier.statuses[0])
+			}
+		})
+	}
+}
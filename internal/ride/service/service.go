@@ -10,19 +10,37 @@ import (
 	"ride-hail/internal/ride/domain/models"
 	"ride-hail/internal/ride/domain/ports"
 	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/cities"
+	"ride-hail/internal/shared/geo"
 	"ride-hail/internal/shared/logger"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
 )
 
 type RideService struct {
 	repo      ports.RideRepository
+	zones     *geo.ZoneCache
+	catalog   *pricing.Catalog
+	cities    *cities.Registry
 	publisher ports.Publish
 	logger    *logger.Logger
 	secretKey []byte
 }
 
-func NewRideService(repo ports.RideRepository, publisher ports.Publish, log *logger.Logger, secretKey []byte) *RideService {
+func NewRideService(
+	repo ports.RideRepository,
+	zones *geo.ZoneCache,
+	catalog *pricing.Catalog,
+	cityRegistry *cities.Registry,
+	publisher ports.Publish,
+	log *logger.Logger,
+	secretKey []byte,
+) *RideService {
 	return &RideService{
 		repo:      repo,
+		zones:     zones,
+		catalog:   catalog,
+		cities:    cityRegistry,
 		publisher: publisher,
 		logger:    log,
 		secretKey: secretKey,
@@ -30,6 +48,49 @@ func NewRideService(repo ports.RideRepository, publisher ports.Publish, log *log
 }
 
 func (s *RideService) CreateRide(ctx context.Context, cmd models.CreateRideCommand) (*models.Ride, error) {
+	// 1-6. Проверки и расчёт цены, как в Quote
+	ride, err := s.quoteRide(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. Генерация ride_number
+	ride.RideNumber = fmt.Sprintf("RIDE-%d", time.Now().UnixNano())
+
+	// 8. Сохраняем в репозитории (внутри транзакции repo создаст coordinates и зарезервирует промокод)
+	if err := s.repo.CreateRide(ctx, ride); err != nil {
+		s.logError(ctx, "db_error", "failed to create ride", err)
+		return nil, err
+	}
+
+	// 9. Логирование
+	ctx = logger.WithRideID(ctx, ride.ID)
+	s.logInfo(ctx, "ride_created", "ride successfully created", map[string]any{
+		"passenger_id":   ride.PassengerID,
+		"ride_number":    ride.RideNumber,
+		"vehicle_type":   ride.VehicleType,
+		"estimated_fare": ride.EstimatedFare,
+		"city_id":        ride.CityID,
+		"zone_surcharge": ride.ZoneSurcharge,
+		"promo_code":     ride.PromoCode,
+	})
+
+	// 10. Публикуем событие в брокер (если есть)
+	if err := s.publishRideMatchRequest(ctx, ride); err != nil {
+		s.logError(ctx, "publish_error", "failed to publish ride match request", err)
+	}
+
+	return ride, nil
+}
+
+// Quote prices a ride without requesting it. A promo code is checked against its
+// eligibility and limits but not reserved.
+func (s *RideService) Quote(ctx context.Context, cmd models.CreateRideCommand) (*models.Ride, error) {
+	return s.quoteRide(ctx, cmd)
+}
+
+// quoteRide validates the request and builds the ride with its estimated fare.
+func (s *RideService) quoteRide(ctx context.Context, cmd models.CreateRideCommand) (*models.Ride, error) {
 	// 1. Валидация координат
 	if err := validateLanLon(cmd.Pickup.Latitude, cmd.Pickup.Longitude); err != nil {
 		s.logError(ctx, "validation_error", "invalid pickup coordinates", err)
@@ -40,54 +101,79 @@ func (s *RideService) CreateRide(ctx context.Context, cmd models.CreateRideComma
 		return nil, err
 	}
 
-	// 2. Расчёты
-	distanceKm := calculateDistance(cmd.Pickup.Latitude, cmd.Pickup.Longitude,
-		cmd.Destination.Latitude, cmd.Destination.Longitude)
-	durationMin := estimateDuration(distanceKm)
+	// 2. Геозоны: зона обслуживания, запрет посадки, доплаты
+	zoneRes, err := applyZones(s.zones, cmd.Pickup, cmd.Destination)
+	if err != nil {
+		s.logError(ctx, "validation_error", "pickup rejected by zones", err)
+		return nil, err
+	}
+	cmd.Pickup = zoneRes.Pickup
+
+	// 3. Город по точке посадки: от него зависят тариф и валюта
+	city, err := resolveCity(s.cities, cmd.Pickup)
+	if err != nil {
+		s.logError(ctx, "validation_error", "pickup outside every city", err)
+		return nil, err
+	}
 
-	vehicleType := cmd.VehicleType
-	if !vehicleType.IsValid() {
-		vehicleType = models.VehicleTypeEconomy
+	// 4. Способ оплаты, класс авто и действующий тариф; POOL обслуживается эконом-классом
+	payment, err := paymentMethod(cmd.PaymentMethod)
+	if err != nil {
+		s.logError(ctx, "validation_error", "payment method rejected", err)
+		return nil, err
 	}
+	vehicleType, pooled := rideType(cmd.VehicleType)
 
-	pricing := models.PricingTable[vehicleType]
-	estimatedFare := pricing.BaseFare + distanceKm*pricing.RatePerKm + float64(durationMin)*pricing.RatePerMin
+	requestedAt := time.Now()
+	tariff, err := resolveTariff(s.catalog, vehicleType, city.ID, requestedAt)
+	if errors.Is(err, ErrTariffsNotLoaded) {
+		s.logError(ctx, "catalog_error", "ride not priced: tariffs are not loaded", err)
+		return nil, err
+	}
+	if err != nil {
+		s.logError(ctx, "validation_error", "vehicle type rejected", err)
+		return nil, err
+	}
 
-	// 3. Формируем Ride
+	// 5. Расчёты
+	breakdown, distanceKm, durationMin := estimate(tariff, cmd.Pickup, cmd.Destination, zoneRes.Surcharge, city.Currency)
+
+	// 6. Формируем Ride
 	ride := &models.Ride{
 		PassengerID:              cmd.PassengerID,
 		VehicleType:              vehicleType,
 		Status:                   models.RideStatusRequested,
 		PickupLocation:           cmd.Pickup,
 		DestinationLocation:      cmd.Destination,
-		RequestedAt:              time.Now(),
-		EstimatedFare:            &estimatedFare,
+		RequestedAt:              requestedAt,
 		EstimatedDistanceKm:      distanceKm,
 		EstimatedDurationMinutes: durationMin,
+		ZoneSurcharge:            zoneRes.Surcharge,
+		PickupAdjusted:           zoneRes.PickupAdjusted,
+		CityID:                   city.ID,
+		Currency:                 city.Currency,
+		Pooled:                   pooled,
+		PaymentMethod:            payment,
+	}
+
+	// Скидка за совместную поездку
+	applyPoolDiscount(&breakdown, *ride)
+
+	// Промокод: скидка считается от полной цены с доплатами
+	if cmd.PromoCode != "" {
+		campaign, err := s.checkPromo(ctx, cmd.PromoCode, ride)
+		if err != nil {
+			s.logError(ctx, "validation_error", "promo code rejected", err)
+			return nil, err
+		}
+		applyDiscount(&breakdown, campaign)
+		ride.PromoCode = campaign.Code
+		ride.PromoCampaignID = campaign.ID
 	}
 
-	// 4. Генерация ride_number
-	ride.RideNumber = fmt.Sprintf("RIDE-%d", time.Now().UnixNano())
-
-	// 5. Сохраняем в репозитории (внутри транзакции repo создаст coordinates)
-	if err := s.repo.CreateRide(ctx, ride); err != nil {
-		s.logError(ctx, "db_error", "failed to create ride", err)
-		return nil, err
-	}
-
-	// 6. Логирование
-	ctx = logger.WithRideID(ctx, ride.ID)
-	s.logInfo(ctx, "ride_created", "ride successfully created", map[string]any{
-		"passenger_id":   ride.PassengerID,
-		"ride_number":    ride.RideNumber,
-		"vehicle_type":   ride.VehicleType,
-		"estimated_fare": estimatedFare,
-	})
-
-	// 7. Публикуем событие в брокер (если есть)
-	if err := s.publishRideMatchRequest(ctx, ride); err != nil {
-		s.logError(ctx, "publish_error", "failed to publish ride match request", err)
-	}
+	estimatedFare := breakdown.Total
+	ride.EstimatedFare = &estimatedFare
+	ride.FareBreakdown = &breakdown
 
 	return ride, nil
 }
@@ -126,7 +212,10 @@ func (s *RideService) UpdateRideStatus(ctx context.Context, rideID string, statu
 }
 
 func (s *RideService) CloseRide(ctx context.Context, id string, reason string) error {
-	if err := s.repo.CloseRide(ctx, id, reason); err != nil {
+	if _, err := s.repo.CloseRide(ctx, id, reason); err != nil {
+		if errors.Is(err, models.ErrRideNotFound) {
+			return ErrRideNotCancellable
+		}
 		return err
 	}
 	return nil
@@ -153,20 +242,29 @@ func contains(slice []string, value string) bool {
 	return false
 }
 
-// calculateDistance calculates the distance in km between two coordinates using Haversine formula
-func calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
-	const earthRadiusKm = 6371.0
+// distanceBetween returns the great-circle distance in km between two locations.
+func distanceBetween(a, b models.Location) float64 {
+	return geo.DistanceKm(geo.Point{Lat: a.Latitude, Lng: a.Longitude}, geo.Point{Lat: b.Latitude, Lng: b.Longitude})
+}
 
-	lat1Rad := lat1 * (3.141592653589793 / 180.0)
-	lat2Rad := lat2 * (3.141592653589793 / 180.0)
-	deltaLat := (lat2 - lat1) * (3.141592653589793 / 180.0)
-	deltaLon := (lon2 - lon1) * (3.141592653589793 / 180.0)
+// quote estimates the fare, distance and duration of a trip between two points.
+func quote(tariff pricing.Tariff, pickup, destination models.Location, surcharge money.Money, currency string) (money.Money, float64, int) {
+	breakdown, distanceKm, durationMin := estimate(tariff, pickup, destination, surcharge, currency)
+	return breakdown.Total, distanceKm, durationMin
+}
 
-	a := sin(deltaLat/2)*sin(deltaLat/2) +
-		cos(lat1Rad)*cos(lat2Rad)*sin(deltaLon/2)*sin(deltaLon/2)
-	c := 2 * atan2(sqrt(a), sqrt(1-a))
+// estimate is quote with the fare itemized.
+func estimate(tariff pricing.Tariff, pickup, destination models.Location, surcharge money.Money, currency string) (pricing.Breakdown, float64, int) {
+	distanceKm := distanceBetween(pickup, destination)
+	durationMin := estimateDuration(distanceKm)
+	return fareBreakdown(tariff, distanceKm, durationMin, surcharge, currency), distanceKm, durationMin
+}
 
-	return earthRadiusKm * c
+// fareBreakdown prices a trip of the given length with the tariff and the zone surcharges.
+func fareBreakdown(tariff pricing.Tariff, distanceKm float64, durationMin int, surcharge money.Money, currency string) pricing.Breakdown {
+	b := tariff.Breakdown(distanceKm, float64(durationMin))
+	b.Add(pricing.ItemZoneSurcharge, surcharge)
+	return b.WithCurrency(currency)
 }
 
 // estimateDuration estimates ride duration in minutes based on distance
@@ -177,74 +275,6 @@ func estimateDuration(distanceKm float64) int {
 	return int(durationHours * 60)
 }
 
-// Math helper functions to avoid math package import
-func sin(x float64) float64 {
-	// Taylor series approximation
-	x = fmod(x, 2*3.141592653589793)
-	sum := x
-	term := x
-	for i := 1; i < 10; i++ {
-		term *= -x * x / float64((2*i)*(2*i+1))
-		sum += term
-	}
-	return sum
-}
-
-func cos(x float64) float64 {
-	return sin(x + 3.141592653589793/2)
-}
-
-func sqrt(x float64) float64 {
-	if x <= 0 {
-		return 0
-	}
-	z := x / 2
-	for i := 0; i < 10; i++ {
-		z = (z + x/z) / 2
-	}
-	return z
-}
-
-func atan2(y, x float64) float64 {
-	if x > 0 {
-		return atan(y / x)
-	}
-	if x < 0 && y >= 0 {
-		return atan(y/x) + 3.141592653589793
-	}
-	if x < 0 && y < 0 {
-		return atan(y/x) - 3.141592653589793
-	}
-	if x == 0 && y > 0 {
-		return 3.141592653589793 / 2
-	}
-	if x == 0 && y < 0 {
-		return -3.141592653589793 / 2
-	}
-	return 0
-}
-
-func atan(x float64) float64 {
-	// Taylor series for small x
-	if x > 1 {
-		return 3.141592653589793/2 - atan(1/x)
-	}
-	if x < -1 {
-		return -3.141592653589793/2 - atan(1/x)
-	}
-	sum := x
-	term := x
-	for i := 1; i < 20; i++ {
-		term *= -x * x
-		sum += term / float64(2*i+1)
-	}
-	return sum
-}
-
-func fmod(x, y float64) float64 {
-	return x - float64(int(x/y))*y
-}
-
 // publishRideMatchRequest publishes a ride match request to the message broker
 func (s *RideService) publishRideMatchRequest(ctx context.Context, ride *models.Ride) error {
 	if s.publisher == nil {
@@ -264,8 +294,13 @@ func (s *RideService) publishRideMatchRequest(ctx context.Context, ride *models.
 			Lng:     ride.DestinationLocation.Longitude,
 			Address: ride.DestinationLocation.Address,
 		},
-		RideType:       string(ride.VehicleType),
+		RideType:       ride.RideType(),
+		VehicleType:    string(ride.VehicleType),
+		Pooled:         ride.Pooled,
 		EstimatedFare:  getEstimatedFare(ride.EstimatedFare),
+		Currency:       ride.Currency,
+		DistanceKm:     ride.EstimatedDistanceKm,
+		PaymentMethod:  ride.PaymentMethod,
 		MaxDistanceKm:  10.0, // Default max distance for driver matching
 		TimeoutSeconds: 60,   // Default timeout for driver response
 		RequestedAt:    ride.RequestedAt,
@@ -276,7 +311,7 @@ func (s *RideService) publishRideMatchRequest(ctx context.Context, ride *models.
 		return err
 	}
 
-	routingKey := messages.RideRequestRoutingKey(string(ride.VehicleType))
+	routingKey := messages.RideRequestRoutingKey(ride.RideType())
 	return s.publisher.Publish(ctx, messages.ExchangeRideTopic, routingKey, body)
 }
 
@@ -306,9 +341,9 @@ func (s *RideService) publishRideStatusUpdate(ctx context.Context, ride *models.
 	return s.publisher.Publish(ctx, messages.ExchangeRideTopic, routingKey, body)
 }
 
-func getEstimatedFare(fare *float64) float64 {
+func getEstimatedFare(fare *money.Money) money.Money {
 	if fare == nil {
-		return 0
+		return money.Money{}
 	}
 	return *fare
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/promo"
 )
 
 // Mock repository for testing
@@ -14,7 +15,16 @@ type mockRideRepo struct {
 	getRideFunc      func(ctx context.Context, id string) (models.Ride, error)
 	listByStatusFunc func(ctx context.Context, passengerID, status string) ([]models.Ride, error)
 	updateStatusFunc func(ctx context.Context, rideID, status string) error
-	closeRideFunc    func(ctx context.Context, id, reason string) error
+	closeRideFunc    func(ctx context.Context, id, reason string) (string, error)
+	activeRidesFunc  func(ctx context.Context, driverID string) ([]models.Ride, error)
+	updatePickupFunc func(ctx context.Context, ride *models.Ride) error
+	updateDestFunc   func(ctx context.Context, ride *models.Ride, previous models.Location) error
+	driverLocFunc    func(ctx context.Context, driverID string) (models.Location, error)
+	promoFunc        func(ctx context.Context, code string) (promo.Campaign, error)
+	promoUsedFunc    func(ctx context.Context, campaignID, passengerID string) (int, error)
+	savedLocations   []models.Location
+	emails           map[string]string // passenger IDs by email
+	splits           []models.FareSplit
 }
 
 func (m *mockRideRepo) CreateRide(ctx context.Context, ride *models.Ride) error {
@@ -50,10 +60,88 @@ func (m *mockRideRepo) UpdateStatus(ctx context.Context, rideID, status string)
 	return nil
 }
 
-func (m *mockRideRepo) CloseRide(ctx context.Context, id, reason string) error {
+func (m *mockRideRepo) CloseRide(ctx context.Context, id, reason string) (string, error) {
 	if m.closeRideFunc != nil {
 		return m.closeRideFunc(ctx, id, reason)
 	}
+	return "", nil
+}
+
+func (m *mockRideRepo) ListActiveRidesByDriver(ctx context.Context, driverID string) ([]models.Ride, error) {
+	if m.activeRidesFunc != nil {
+		return m.activeRidesFunc(ctx, driverID)
+	}
+	return nil, nil
+}
+
+func (m *mockRideRepo) UpdatePickup(ctx context.Context, ride *models.Ride) error {
+	if m.updatePickupFunc != nil {
+		return m.updatePickupFunc(ctx, ride)
+	}
+	return nil
+}
+
+func (m *mockRideRepo) UpdateDestination(ctx context.Context, ride *models.Ride, previous models.Location) error {
+	if m.updateDestFunc != nil {
+		return m.updateDestFunc(ctx, ride, previous)
+	}
+	return nil
+}
+
+func (m *mockRideRepo) GetDriverLocation(ctx context.Context, driverID string) (models.Location, error) {
+	if m.driverLocFunc != nil {
+		return m.driverLocFunc(ctx, driverID)
+	}
+	return models.Location{}, models.ErrRideNotFound
+}
+
+func (m *mockRideRepo) GetPromoCampaign(ctx context.Context, code string) (promo.Campaign, error) {
+	if m.promoFunc != nil {
+		return m.promoFunc(ctx, code)
+	}
+	return promo.Campaign{}, promo.ErrCodeNotFound
+}
+
+func (m *mockRideRepo) CountPromoRedemptions(ctx context.Context, campaignID, passengerID string) (int, error) {
+	if m.promoUsedFunc != nil {
+		return m.promoUsedFunc(ctx, campaignID, passengerID)
+	}
+	return 0, nil
+}
+
+func (m *mockRideRepo) FindPassengerByEmail(ctx context.Context, email string) (string, error) {
+	if id, ok := m.emails[email]; ok {
+		return id, nil
+	}
+	return "", models.ErrPassengerNotFound
+}
+
+func (m *mockRideRepo) ListFareSplits(ctx context.Context, rideID string) ([]models.FareSplit, error) {
+	return m.splits, nil
+}
+
+func (m *mockRideRepo) SaveFareSplits(ctx context.Context, rideID string, splits []models.FareSplit) ([]models.FareSplit, error) {
+	for _, split := range splits {
+		if existing := findSplit(m.splits, split.PassengerID); existing != nil {
+			*existing = split
+		} else {
+			m.splits = append(m.splits, split)
+		}
+	}
+	return m.splits, nil
+}
+
+func (m *mockRideRepo) RespondToFareSplit(ctx context.Context, rideID, passengerID, status string) (models.FareSplit, error) {
+	split := findSplit(m.splits, passengerID)
+	if split == nil || split.Status != models.SplitInvited {
+		return models.FareSplit{}, models.ErrSplitNotFound
+	}
+	split.Status = status
+	return *split, nil
+}
+
+func (m *mockRideRepo) SavePassengerLocation(ctx context.Context, passengerID string, loc models.Location) error {
+	m.savedLocations = append(m.savedLocations, loc)
 	return nil
 }
 
@@ -110,7 +198,7 @@ func TestNewRideService(t *testing.T) {
 	repo := &mockRideRepo{}
 	secret := []byte("test-secret")
 
-	svc := NewRideService(repo, nil, nil, secret)
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, secret)
 
 	if svc == nil {
 		t.Fatal("expected non-nil service")
@@ -122,7 +210,7 @@ func TestNewRideService(t *testing.T) {
 
 func TestCreateRide_Success(t *testing.T) {
 	repo := &mockRideRepo{}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, newCatalog(), nil, nil, nil, []byte("secret"))
 
 	cmd := models.CreateRideCommand{
 		PassengerID: "passenger-123",
@@ -144,7 +232,7 @@ func TestCreateRide_Success(t *testing.T) {
 
 func TestCreateRide_InvalidPickupCoords(t *testing.T) {
 	repo := &mockRideRepo{}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	cmd := models.CreateRideCommand{
 		PassengerID: "passenger-123",
@@ -160,7 +248,7 @@ func TestCreateRide_InvalidPickupCoords(t *testing.T) {
 
 func TestCreateRide_InvalidDestCoords(t *testing.T) {
 	repo := &mockRideRepo{}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	cmd := models.CreateRideCommand{
 		PassengerID: "passenger-123",
@@ -180,7 +268,7 @@ func TestCreateRide_RepoError(t *testing.T) {
 			return errors.New("db error")
 		},
 	}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	cmd := models.CreateRideCommand{
 		PassengerID: "passenger-123",
@@ -200,7 +288,7 @@ func TestGetRideById_Success(t *testing.T) {
 			return models.Ride{ID: id, PassengerID: "passenger-123"}, nil
 		},
 	}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	ride, err := svc.GetRideById(context.Background(), "ride-123", "passenger-123")
 	if err != nil {
@@ -217,7 +305,7 @@ func TestGetRideById_NotFound(t *testing.T) {
 			return models.Ride{}, errors.New("not found")
 		},
 	}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	_, err := svc.GetRideById(context.Background(), "nonexistent", "passenger-123")
 	if err == nil {
@@ -231,7 +319,7 @@ func TestGetRideByStatus_Success(t *testing.T) {
 			return []models.Ride{{ID: "ride-1"}, {ID: "ride-2"}}, nil
 		},
 	}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	rides, err := svc.GetRideByStatus(context.Background(), "passenger-123", "REQUESTED")
 	if err != nil {
@@ -244,7 +332,7 @@ func TestGetRideByStatus_Success(t *testing.T) {
 
 func TestUpdateRideStatus_ValidStatus(t *testing.T) {
 	repo := &mockRideRepo{}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	validStatuses := []string{"REQUESTED", "IN_PROGRESS", "COMPLETED", "CANCELLED"}
 	for _, status := range validStatuses {
@@ -257,7 +345,7 @@ func TestUpdateRideStatus_ValidStatus(t *testing.T) {
 
 func TestUpdateRideStatus_InvalidStatus(t *testing.T) {
 	repo := &mockRideRepo{}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	err := svc.UpdateRideStatus(context.Background(), "ride-123", "INVALID_STATUS")
 	if err == nil {
@@ -271,7 +359,7 @@ func TestUpdateRideStatus_RepoError(t *testing.T) {
 			return errors.New("db error")
 		},
 	}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	err := svc.UpdateRideStatus(context.Background(), "ride-123", "COMPLETED")
 	if err == nil {
@@ -281,7 +369,7 @@ func TestUpdateRideStatus_RepoError(t *testing.T) {
 
 func TestCloseRide_Success(t *testing.T) {
 	repo := &mockRideRepo{}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	err := svc.CloseRide(context.Background(), "ride-123", "changed my mind")
 	if err != nil {
@@ -291,11 +379,11 @@ func TestCloseRide_Success(t *testing.T) {
 
 func TestCloseRide_RepoError(t *testing.T) {
 	repo := &mockRideRepo{
-		closeRideFunc: func(ctx context.Context, id, reason string) error {
-			return errors.New("db error")
+		closeRideFunc: func(ctx context.Context, id, reason string) (string, error) {
+			return "", errors.New("db error")
 		},
 	}
-	svc := NewRideService(repo, nil, nil, []byte("secret"))
+	svc := NewRideService(repo, nil, nil, nil, nil, nil, []byte("secret"))
 
 	err := svc.CloseRide(context.Background(), "ride-123", "reason")
 	if err == nil {
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/cities"
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/pricing"
+)
+
+var (
+	ErrUnknownVehicleType = errors.New("unknown vehicle type")
+	ErrNoTariff           = errors.New("no tariff configured for vehicle type")
+	ErrTariffsNotLoaded   = errors.New("tariffs are not loaded yet, try again later")
+)
+
+// resolveTariff validates the requested vehicle class and returns the tariff in
+// effect in the city at the given time. Rides are not priced until the catalog
+// has been loaded.
+func resolveTariff(catalog *pricing.Catalog, vehicleType models.VehicleType, cityID string, at time.Time) (pricing.Tariff, error) {
+	if catalog == nil || len(catalog.Classes()) == 0 {
+		return pricing.Tariff{}, ErrTariffsNotLoaded
+	}
+
+	if !catalog.IsValid(string(vehicleType)) {
+		return pricing.Tariff{}, fmt.Errorf("%w: %q", ErrUnknownVehicleType, vehicleType)
+	}
+
+	tariff, ok := catalog.TariffAt(string(vehicleType), cityID, at)
+	if !ok {
+		return pricing.Tariff{}, fmt.Errorf("%w %q", ErrNoTariff, vehicleType)
+	}
+
+	return tariff, nil
+}
+
+// resolveCity assigns the ride to the city of its pickup point. Without
+// configured cities the ride has no city and the shared tariffs apply.
+func resolveCity(registry *cities.Registry, pickup models.Location) (cities.City, error) {
+	if registry == nil || len(registry.All()) == 0 {
+		return cities.City{}, nil
+	}
+
+	city, ok := registry.CityAt(geo.Point{Lat: pickup.Latitude, Lng: pickup.Longitude})
+	if !ok {
+		return cities.City{}, ErrPickupOutsideServiceArea
+	}
+	return city, nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/cities"
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
+)
+
+// major builds an amount without currency from whole major units.
+func major(units int64) money.Money {
+	return money.New(units*money.MinorPerMajor, "")
+}
+
+func newCatalog() *pricing.Catalog {
+	catalog := pricing.NewCatalog(nil)
+	catalog.Set(
+		[]pricing.VehicleClass{
+			{Code: "ECONOMY", Capacity: 4, IsActive: true},
+			{Code: "ELECTRIC", Capacity: 4, IsActive: true},
+			{Code: "XL", Capacity: 6, IsActive: false},
+		},
+		[]pricing.Tariff{
+			{VehicleType: "ECONOMY", BaseFare: major(500), RatePerKm: major(100), RatePerMin: major(50), EffectiveFrom: time.Unix(0, 0)},
+			{VehicleType: "ELECTRIC", BaseFare: major(600), RatePerKm: major(90), RatePerMin: major(40), MinimumFare: major(100000), EffectiveFrom: time.Unix(0, 0)},
+			{VehicleType: "XL", BaseFare: major(1000), RatePerKm: major(150), RatePerMin: major(75), EffectiveFrom: time.Unix(0, 0)},
+		},
+	)
+	return catalog
+}
+
+func TestCreateRide_VehicleClasses(t *testing.T) {
+	cases := []struct {
+		name        string
+		vehicleType models.VehicleType
+		wantErr     error
+		wantFare    money.Money
+	}{
+		{name: "class added in database", vehicleType: "ELECTRIC", wantFare: major(100000)},
+		{name: "empty defaults to economy", vehicleType: ""},
+		{name: "inactive class", vehicleType: models.VehicleTypeXL, wantErr: ErrUnknownVehicleType},
+		{name: "unknown class", vehicleType: "LUX", wantErr: ErrUnknownVehicleType},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewRideService(&mockRideRepo{}, nil, newCatalog(), nil, nil, nil, []byte("secret"))
+
+			ride, err := svc.CreateRide(context.Background(), models.CreateRideCommand{
+				PassengerID: "passenger-123",
+				VehicleType: tc.vehicleType,
+				Pickup:      models.Location{Latitude: 43.238949, Longitude: 76.889709},
+				Destination: models.Location{Latitude: 43.222015, Longitude: 76.851511},
+			})
+
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tc.wantFare.IsZero() && *ride.EstimatedFare != tc.wantFare {
+				t.Fatalf("expected fare %v, got %v", tc.wantFare, *ride.EstimatedFare)
+			}
+			if tc.vehicleType == "" && ride.VehicleType != models.VehicleTypeEconomy {
+				t.Fatalf("expected ECONOMY, got %s", ride.VehicleType)
+			}
+		})
+	}
+}
+
+func TestCreateRide_CityFromPickup(t *testing.T) {
+	catalog := newCatalog()
+	catalog.Set(
+		[]pricing.VehicleClass{{Code: "ECONOMY", IsActive: true}},
+		[]pricing.Tariff{
+			{VehicleType: "ECONOMY", BaseFare: major(500), RatePerKm: major(100), RatePerMin: major(50), EffectiveFrom: time.Unix(0, 0)},
+			{VehicleType: "ECONOMY", CityID: "nqz", MinimumFare: major(7000), EffectiveFrom: time.Unix(0, 0)},
+		},
+	)
+
+	registry := cities.NewRegistry(nil)
+	registry.Set([]cities.City{
+		{ID: "ala", Code: "ALA", Currency: "KZT", IsDefault: true, IsActive: true},
+		{ID: "nqz", Code: "NQZ", Currency: "KZT", IsActive: true, ServiceArea: geo.Polygon{
+			{Lat: 51.00, Lng: 71.20},
+			{Lat: 51.00, Lng: 71.70},
+			{Lat: 51.30, Lng: 71.70},
+			{Lat: 51.30, Lng: 71.20},
+		}},
+	})
+
+	svc := NewRideService(&mockRideRepo{}, nil, catalog, registry, nil, nil, []byte("secret"))
+
+	ride, err := svc.CreateRide(context.Background(), models.CreateRideCommand{
+		PassengerID: "passenger-123",
+		Pickup:      models.Location{Latitude: 51.128207, Longitude: 71.430411},
+		Destination: models.Location{Latitude: 51.130000, Longitude: 71.430000},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ride.CityID != "nqz" || ride.Currency != "KZT" {
+		t.Fatalf("expected nqz/KZT, got %s/%s", ride.CityID, ride.Currency)
+	}
+	if *ride.EstimatedFare != major(7000).WithCurrency("KZT") {
+		t.Fatalf("expected city tariff fare 7000, got %v", *ride.EstimatedFare)
+	}
+}
+
+func TestCreateRide_TariffsNotLoaded(t *testing.T) {
+	cases := []struct {
+		name    string
+		catalog *pricing.Catalog
+	}{
+		{name: "no catalog"},
+		{name: "catalog not loaded yet", catalog: pricing.NewCatalog(nil)},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewRideService(&mockRideRepo{}, nil, tc.catalog, nil, nil, nil, []byte("secret"))
+
+			_, err := svc.CreateRide(context.Background(), models.CreateRideCommand{
+				PassengerID: "passenger-123",
+				VehicleType: models.VehicleTypeEconomy,
+				Pickup:      models.Location{Latitude: 43.238949, Longitude: 76.889709},
+				Destination: models.Location{Latitude: 43.222015, Longitude: 76.851511},
+			})
+			if !errors.Is(err, ErrTariffsNotLoaded) {
+				t.Fatalf("expected ErrTariffsNotLoaded, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/geo"
+	"ride-hail/internal/shared/money"
+)
+
+// pickupMarginKm is how far past the edge of a no-pickup zone the pickup is moved.
+const pickupMarginKm = 0.02
+
+var ErrPickupOutsideServiceArea = errors.New("pickup location is outside the service area")
+
+// zoneResult is the outcome of checking a ride request against the geofenced zones.
+type zoneResult struct {
+	Pickup         models.Location
+	PickupAdjusted bool
+	Surcharge      money.Money
+}
+
+// applyZones validates the pickup against the service area, moves it out of
+// no-pickup zones and sums the surcharges of the zones the trip touches.
+func applyZones(zones *geo.ZoneCache, pickup, destination models.Location) (zoneResult, error) {
+	res := zoneResult{Pickup: pickup}
+	if zones == nil {
+		return res, nil
+	}
+
+	pt := geo.Point{Lat: pickup.Latitude, Lng: pickup.Longitude}
+	if !zones.InServiceArea(pt) {
+		return res, ErrPickupOutsideServiceArea
+	}
+
+	for _, z := range zones.ZonesAt(pt) {
+		if z.Type != geo.ZoneTypeNoPickup {
+			continue
+		}
+		moved := z.Polygon.PushOutside(pt, pickupMarginKm)
+		if !zones.InServiceArea(moved) {
+			return res, ErrPickupOutsideServiceArea
+		}
+		pt = moved
+		res.PickupAdjusted = true
+	}
+
+	if res.PickupAdjusted {
+		res.Pickup.Latitude = pt.Lat
+		res.Pickup.Longitude = pt.Lng
+	}
+
+	// Each surcharge zone is charged once, even if both ends of the trip are inside it
+	charged := make(map[string]bool)
+	dest := geo.Point{Lat: destination.Latitude, Lng: destination.Longitude}
+	for _, p := range []geo.Point{pt, dest} {
+		for _, z := range zones.ZonesAt(p) {
+			if z.Type != geo.ZoneTypeSurcharge && z.Type != geo.ZoneTypeAirport {
+				continue
+			}
+			if charged[z.ID] {
+				continue
+			}
+			charged[z.ID] = true
+			res.Surcharge = res.Surcharge.Add(z.Surcharge)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ride-hail/internal/ride/domain/models"
+	"ride-hail/internal/shared/geo"
+)
+
+var testCity = geo.Polygon{
+	{Lat: 43.10, Lng: 76.70},
+	{Lat: 43.10, Lng: 77.00},
+	{Lat: 43.40, Lng: 77.00},
+	{Lat: 43.40, Lng: 76.70},
+}
+
+var testPlaza = geo.Polygon{
+	{Lat: 43.230, Lng: 76.880},
+	{Lat: 43.230, Lng: 76.900},
+	{Lat: 43.245, Lng: 76.900},
+	{Lat: 43.245, Lng: 76.880},
+}
+
+func newZoneCache(zones ...geo.Zone) *geo.ZoneCache {
+	cache := geo.NewZoneCache(nil)
+	cache.Set(zones)
+	return cache
+}
+
+func TestCreateRide_OutsideServiceArea(t *testing.T) {
+	zones := newZoneCache(geo.Zone{ID: "city", Type: geo.ZoneTypeServiceArea, Polygon: testCity})
+	svc := NewRideService(&mockRideRepo{}, zones, nil, nil, nil, nil, []byte("secret"))
+
+	cmd := models.CreateRideCommand{
+		PassengerID: "passenger-123",
+		Pickup:      models.Location{Latitude: 51.128207, Longitude: 71.430411},
+		Destination: models.Location{Latitude: 43.222015, Longitude: 76.851511},
+	}
+
+	_, err := svc.CreateRide(context.Background(), cmd)
+	if !errors.Is(err, ErrPickupOutsideServiceArea) {
+		t.Fatalf("expected ErrPickupOutsideServiceArea, got %v", err)
+	}
+}
+
+func TestCreateRide_PickupMovedOutOfNoPickupZone(t *testing.T) {
+	zones := newZoneCache(
+		geo.Zone{ID: "city", Type: geo.ZoneTypeServiceArea, Polygon: testCity},
+		geo.Zone{ID: "plaza", Type: geo.ZoneTypeNoPickup, Polygon: testPlaza},
+	)
+	svc := NewRideService(&mockRideRepo{}, zones, newCatalog(), nil, nil, nil, []byte("secret"))
+
+	cmd := models.CreateRideCommand{
+		PassengerID: "passenger-123",
+		Pickup:      models.Location{Latitude: 43.238949, Longitude: 76.889709, Address: "Pickup"},
+		Destination: models.Location{Latitude: 43.222015, Longitude: 76.851511},
+	}
+
+	ride, err := svc.CreateRide(context.Background(), cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ride.PickupAdjusted {
+		t.Fatal("expected pickup to be adjusted")
+	}
+	moved := geo.Point{Lat: ride.PickupLocation.Latitude, Lng: ride.PickupLocation.Longitude}
+	if testPlaza.Contains(moved) {
+		t.Fatalf("expected pickup outside no-pickup zone, got %+v", moved)
+	}
+	if ride.PickupLocation.Address != "Pickup" {
+		t.Errorf("expected address to be kept, got %q", ride.PickupLocation.Address)
+	}
+}
+
+func TestCreateRide_ZoneSurcharge(t *testing.T) {
+	zones := newZoneCache(
+		geo.Zone{ID: "city", Type: geo.ZoneTypeServiceArea, Polygon: testCity},
+		geo.Zone{ID: "center", Type: geo.ZoneTypeSurcharge, Polygon: testCity, Surcharge: major(150)},
+	)
+	withZones := NewRideService(&mockRideRepo{}, zones, newCatalog(), nil, nil, nil, []byte("secret"))
+	withoutZones := NewRideService(&mockRideRepo{}, nil, newCatalog(), nil, nil, nil, []byte("secret"))
+
+	cmd := models.CreateRideCommand{
+		PassengerID: "passenger-123",
+		Pickup:      models.Location{Latitude: 43.238949, Longitude: 76.889709},
+		Destination: models.Location{Latitude: 43.222015, Longitude: 76.851511},
+	}
+
+	charged, err := withZones.CreateRide(context.Background(), cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	base, err := withoutZones.CreateRide(context.Background(), cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if charged.ZoneSurcharge != major(150) {
+		t.Fatalf("expected surcharge charged once, got %v", charged.ZoneSurcharge)
+	}
+	if diff := charged.EstimatedFare.Sub(*base.EstimatedFare); diff != major(150) {
+		t.Fatalf("expected fare to grow by 150, got %v", diff)
+	}
+}
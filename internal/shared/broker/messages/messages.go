@@ -3,6 +3,9 @@ package messages
 import (
 	"fmt"
 	"time"
+
+	"ride-hail/internal/shared/money"
+	"ride-hail/internal/shared/pricing"
 )
 
 // Exchanges
@@ -17,21 +20,30 @@ const (
 	// ride_topic
 	QueueRideRequests = "ride_requests"
 	QueueRideStatus   = "ride_status"
+	// ride.passenger.* is consumed by the driver service
+	QueuePassengerUpdates = "passenger_updates"
+	// ride.chat.* is consumed by the driver service
+	QueueDriverChat = "driver_chat"
 
 	// driver_topic
 	QueueDriverMatching  = "driver_matching"
 	QueueDriverResponses = "driver_responses"
 	QueueDriverStatus    = "driver_status"
+	// driver.chat.* is consumed by the ride service
+	QueuePassengerChat = "passenger_chat"
 
 	// location_fanout
 	QueueLocationUpdatesRide = "location_updates"
 )
 
 // Routing key helpers
-func RideRequestRoutingKey(rideType string) string  { return fmt.Sprintf("ride.request.%s", rideType) }
-func RideStatusRoutingKey(status string) string     { return fmt.Sprintf("ride.status.%s", status) }
-func DriverResponseRoutingKey(rideID string) string { return fmt.Sprintf("driver.response.%s", rideID) }
-func DriverStatusRoutingKey(driverID string) string { return fmt.Sprintf("driver.status.%s", driverID) }
+func RideRequestRoutingKey(rideType string) string   { return fmt.Sprintf("ride.request.%s", rideType) }
+func RideStatusRoutingKey(status string) string      { return fmt.Sprintf("ride.status.%s", status) }
+func DriverResponseRoutingKey(rideID string) string  { return fmt.Sprintf("driver.response.%s", rideID) }
+func DriverStatusRoutingKey(driverID string) string  { return fmt.Sprintf("driver.status.%s", driverID) }
+func PassengerUpdateRoutingKey(rideID string) string { return fmt.Sprintf("ride.passenger.%s", rideID) }
+func RideChatRoutingKey(rideID string) string        { return fmt.Sprintf("ride.chat.%s", rideID) }
+func DriverChatRoutingKey(rideID string) string      { return fmt.Sprintf("driver.chat.%s", rideID) }
 
 // ---------- Common / Nested types ----------
 
@@ -59,16 +71,29 @@ type DriverInfo struct {
 
 // RideMatchRequest is published to ride_topic with routing key ride.request.{ride_type}
 type RideMatchRequest struct {
-	RideID         string     `json:"ride_id"`
-	RideNumber     string     `json:"ride_number,omitempty"`
-	PickupLocation Coordinate `json:"pickup_location"`
-	Destination    Coordinate `json:"destination_location"`
-	RideType       string     `json:"ride_type"`
-	EstimatedFare  float64    `json:"estimated_fare,omitempty"`
-	MaxDistanceKm  float64    `json:"max_distance_km,omitempty"`
-	TimeoutSeconds int        `json:"timeout_seconds,omitempty"`
-	CorrelationID  string     `json:"correlation_id,omitempty"`
-	RequestedAt    time.Time  `json:"requested_at,omitempty"`
+	RideID         string      `json:"ride_id"`
+	RideNumber     string      `json:"ride_number,omitempty"`
+	PickupLocation Coordinate  `json:"pickup_location"`
+	Destination    Coordinate  `json:"destination_location"`
+	RideType       string      `json:"ride_type"`
+	VehicleType    string      `json:"vehicle_type,omitempty"` // drivers' vehicle class when it differs from RideType
+	Pooled         bool        `json:"pooled,omitempty"`       // may join a trip shared with other riders
+	EstimatedFare  money.Money `json:"estimated_fare"`
+	Currency       string      `json:"currency,omitempty"`
+	DistanceKm     float64     `json:"distance_km,omitempty"`    // estimated trip length
+	PaymentMethod  string      `json:"payment_method,omitempty"` // CASH or CARD, CARD when empty
+	MaxDistanceKm  float64     `json:"max_distance_km,omitempty"`
+	TimeoutSeconds int         `json:"timeout_seconds,omitempty"`
+	CorrelationID  string      `json:"correlation_id,omitempty"`
+	RequestedAt    time.Time   `json:"requested_at,omitempty"`
+}
+
+// DispatchVehicleType returns the vehicle class of the drivers the ride is offered to.
+func (r RideMatchRequest) DispatchVehicleType() string {
+	if r.VehicleType != "" {
+		return r.VehicleType
+	}
+	return r.RideType
 }
 
 // ---------- Driver -> Ride service (incoming) ----------
@@ -100,13 +125,82 @@ type LocationUpdate struct {
 // ---------- Ride status updates (ride_topic) ----------
 
 type RideStatusUpdate struct {
-	RideID        string    `json:"ride_id"`
-	DriverID      string    `json:"driver_id"`
-	Status        string    `json:"status"`
-	Timestamp     time.Time `json:"timestamp,omitempty"`
-	FinalFare     *float64  `json:"final_fare,omitempty"`
-	CorrelationID string    `json:"correlation_id,omitempty"`
-	Message       string    `json:"message,omitempty"`
+	RideID        string       `json:"ride_id"`
+	DriverID      string       `json:"driver_id"`
+	Status        string       `json:"status"`
+	Timestamp     time.Time    `json:"timestamp,omitempty"`
+	FinalFare     *money.Money `json:"final_fare,omitempty"`
+	CorrelationID string       `json:"correlation_id,omitempty"`
+	Message       string       `json:"message,omitempty"`
+	// WaitingFreeUntil is set on ARRIVED: waiting is charged per minute after it
+	WaitingFreeUntil *time.Time `json:"waiting_free_until,omitempty"`
+	// FareBreakdown itemizes FinalFare
+	FareBreakdown *pricing.Breakdown `json:"fare_breakdown,omitempty"`
+}
+
+// ---------- Passenger updates (ride_topic) ----------
+
+// PassengerUpdate types
+const (
+	PassengerUpdateLocation    = "passenger_location"
+	PassengerUpdatePickup      = "pickup_updated"
+	PassengerUpdateCancelled   = "ride_cancelled"
+	PassengerUpdateDestination = "destination_updated"
+)
+
+// PassengerUpdate is published by ride service to ride_topic with routing key ride.passenger.{ride_id}
+// and relayed by driver service to the driver assigned to the ride.
+type PassengerUpdate struct {
+	Type          string       `json:"type"`
+	RideID        string       `json:"ride_id"`
+	PassengerID   string       `json:"passenger_id"`
+	DriverID      string       `json:"driver_id"`
+	Location      *Coordinate  `json:"location,omitempty"`
+	EstimatedFare *money.Money `json:"estimated_fare,omitempty"`
+	Reason        string       `json:"reason,omitempty"`
+	Timestamp     time.Time    `json:"timestamp"`
+
+	// Set on destination_updated
+	DistanceKm       float64    `json:"distance_km,omitempty"`
+	DurationMinutes  int        `json:"duration_minutes,omitempty"`
+	EstimatedArrival *time.Time `json:"estimated_arrival,omitempty"`
+}
+
+// ---------- Chat (ride_topic from passengers, driver_topic from drivers) ----------
+
+// Chat event types
+const (
+	ChatTypeMessage = "chat_message"
+	ChatTypeReceipt = "chat_receipt"
+)
+
+// Chat receipt statuses
+const (
+	ChatDelivered = "delivered"
+	ChatRead      = "read"
+)
+
+// ChatMessage carries a chat message to the service the recipient is connected to.
+// Published with routing key ride.chat.{ride_id} or driver.chat.{ride_id}.
+type ChatMessage struct {
+	Type        string    `json:"type"`
+	ID          string    `json:"message_id"`
+	RideID      string    `json:"ride_id"`
+	SenderID    string    `json:"sender_id"`
+	SenderRole  string    `json:"sender_role"`
+	RecipientID string    `json:"recipient_id"`
+	Body        string    `json:"body"`
+	SentAt      time.Time `json:"sent_at"`
+}
+
+// ChatReceipt tells the sender that their messages were delivered or read.
+type ChatReceipt struct {
+	Type        string    `json:"type"`
+	RideID      string    `json:"ride_id"`
+	RecipientID string    `json:"recipient_id"`
+	MessageIDs  []string  `json:"message_ids"`
+	Status      string    `json:"status"`
+	At          time.Time `json:"at"`
 }
 
 // ---------- Driver status updates (driver_topic) ----------
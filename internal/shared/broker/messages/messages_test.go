@@ -59,6 +59,16 @@ func TestDriverStatusRoutingKey(t *testing.T) {
 	}
 }
 
+func TestPassengerUpdateRoutingKey(t *testing.T) {
+	rideID := "550e8400-e29b-41d4-a716-446655440000"
+	expected := "ride.passenger.550e8400-e29b-41d4-a716-446655440000"
+
+	got := PassengerUpdateRoutingKey(rideID)
+	if got != expected {
+		t.Errorf("PassengerUpdateRoutingKey(%q) = %q, want %q", rideID, got, expected)
+	}
+}
+
 func TestExchangeConstants(t *testing.T) {
 	if ExchangeRideTopic != "ride_topic" {
 		t.Errorf("ExchangeRideTopic = %q, want ride_topic", ExchangeRideTopic)
@@ -79,6 +89,9 @@ func TestQueueConstants(t *testing.T) {
 		"QueueDriverResponses":     QueueDriverResponses,
 		"QueueDriverStatus":        QueueDriverStatus,
 		"QueueLocationUpdatesRide": QueueLocationUpdatesRide,
+		"QueuePassengerUpdates":    QueuePassengerUpdates,
+		"QueueDriverChat":          QueueDriverChat,
+		"QueuePassengerChat":       QueuePassengerChat,
 	}
 
 	for name, value := range queues {
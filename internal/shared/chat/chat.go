@@ -0,0 +1,321 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ride-hail/internal/shared/broker/messages"
+	"ride-hail/internal/shared/broker/rabbitmq"
+)
+
+// Sender roles
+const (
+	RolePassenger = "PASSENGER"
+	RoleDriver    = "DRIVER"
+)
+
+const (
+	// CloseAfter is how long the chat stays open after the ride is completed or cancelled.
+	CloseAfter = 10 * time.Minute
+	// HistoryLimit is how many of the last messages are replayed on reconnect.
+	HistoryLimit = 50
+	// MaxBodyLength is the longest message in characters.
+	MaxBodyLength = 1000
+	// MaxFrameSize is the largest WebSocket frame the chat sockets read: a body of
+	// MaxBodyLength characters at up to 4 bytes each and the command around it.
+	MaxFrameSize = MaxBodyLength*utf8.UTFMax + 1024
+)
+
+var (
+	ErrNoConversation = errors.New("no open chat")
+	ErrChatClosed     = errors.New("chat is closed")
+	ErrNotParticipant = errors.New("user is not a participant of the ride")
+	ErrEmptyMessage   = errors.New("message is empty")
+	ErrMessageTooLong = errors.New("message is too long")
+)
+
+// Message is a chat message stored per ride.
+type Message struct {
+	ID          string     `json:"message_id"`
+	RideID      string     `json:"ride_id"`
+	SenderID    string     `json:"sender_id"`
+	SenderRole  string     `json:"sender_role"`
+	Body        string     `json:"body"`
+	CreatedAt   time.Time  `json:"sent_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+}
+
+// Conversation is the chat of one ride between its passenger and driver.
+type Conversation struct {
+	RideID      string
+	PassengerID string
+	DriverID    string
+	Status      string
+	EndedAt     *time.Time // completed_at or cancelled_at
+}
+
+// Counterpart returns the other participant and the role of the user.
+func (c Conversation) Counterpart(userID string) (counterpartID, role string, err error) {
+	switch userID {
+	case c.PassengerID:
+		return c.DriverID, RolePassenger, nil
+	case c.DriverID:
+		return c.PassengerID, RoleDriver, nil
+	default:
+		return "", "", ErrNotParticipant
+	}
+}
+
+// IsOpen reports whether messages can be sent: a driver is assigned and
+// the ride ended no longer than CloseAfter ago.
+func (c Conversation) IsOpen(now time.Time) bool {
+	if c.DriverID == "" {
+		return false
+	}
+	if c.EndedAt == nil {
+		return c.Status != "COMPLETED" && c.Status != "CANCELLED"
+	}
+	return now.Sub(*c.EndedAt) < CloseAfter
+}
+
+// Store persists conversations and messages.
+type Store interface {
+	GetConversation(ctx context.Context, rideID string) (Conversation, error)
+	// LatestConversation returns the most recent ride of the user with a driver
+	// that ended after since or has not ended yet.
+	LatestConversation(ctx context.Context, userID string, since time.Time) (Conversation, error)
+	SaveMessage(ctx context.Context, msg *Message) error
+	RecentMessages(ctx context.Context, rideID string, limit int) ([]Message, error)
+	MarkDelivered(ctx context.Context, messageIDs []string, at time.Time) error
+	// MarkRead marks the messages of the ride sent to the reader as read and returns their IDs.
+	MarkRead(ctx context.Context, rideID, readerID string, at time.Time) ([]string, error)
+}
+
+// Relay hands chat events to the service the counterpart is connected to.
+type Relay interface {
+	RelayMessage(ctx context.Context, msg messages.ChatMessage) error
+	RelayReceipt(ctx context.Context, receipt messages.ChatReceipt) error
+}
+
+// Notifier pushes events to users connected to this service.
+type Notifier interface {
+	IsConnected(userID string) bool
+	Send(userID string, event any) error
+}
+
+// Service sends, delivers and acknowledges chat messages on one side of the chat:
+// passengers in the ride service, drivers in the driver service.
+type Service struct {
+	store    Store
+	relay    Relay
+	notifier Notifier
+	now      func() time.Time
+}
+
+func NewService(store Store, relay Relay, notifier Notifier) *Service {
+	return &Service{
+		store:    store,
+		relay:    relay,
+		notifier: notifier,
+		now:      time.Now,
+	}
+}
+
+// Send stores a message from a participant and relays it to the counterpart.
+func (s *Service) Send(ctx context.Context, senderID, rideID, body string) (Message, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return Message{}, ErrEmptyMessage
+	}
+	if utf8.RuneCountInString(body) > MaxBodyLength {
+		return Message{}, ErrMessageTooLong
+	}
+
+	conv, err := s.store.GetConversation(ctx, rideID)
+	if err != nil {
+		return Message{}, err
+	}
+	recipientID, role, err := conv.Counterpart(senderID)
+	if err != nil {
+		return Message{}, err
+	}
+	if !conv.IsOpen(s.now()) {
+		return Message{}, ErrChatClosed
+	}
+
+	msg := Message{
+		RideID:     rideID,
+		SenderID:   senderID,
+		SenderRole: role,
+		Body:       body,
+	}
+	if err := s.store.SaveMessage(ctx, &msg); err != nil {
+		return Message{}, err
+	}
+
+	// The message is stored: if the relay fails the recipient still gets it on reconnect
+	err = s.relay.RelayMessage(ctx, messages.ChatMessage{
+		Type:        messages.ChatTypeMessage,
+		ID:          msg.ID,
+		RideID:      msg.RideID,
+		SenderID:    msg.SenderID,
+		SenderRole:  msg.SenderRole,
+		RecipientID: recipientID,
+		Body:        msg.Body,
+		SentAt:      msg.CreatedAt,
+	})
+	if err != nil {
+		slog.Error("failed to relay chat message", "ride_id", rideID, "error", err.Error())
+	}
+	return msg, nil
+}
+
+// Deliver pushes a relayed message to the recipient if they are connected here.
+// Offline recipients get it with the history on reconnect.
+func (s *Service) Deliver(ctx context.Context, msg messages.ChatMessage) error {
+	if !s.notifier.IsConnected(msg.RecipientID) {
+		return nil
+	}
+	if err := s.notifier.Send(msg.RecipientID, msg); err != nil {
+		return err
+	}
+	return s.acknowledge(ctx, msg.RideID, msg.SenderID, []string{msg.ID})
+}
+
+// DeliverReceipt pushes a delivered or read receipt to the original sender.
+func (s *Service) DeliverReceipt(ctx context.Context, receipt messages.ChatReceipt) error {
+	if !s.notifier.IsConnected(receipt.RecipientID) {
+		return nil
+	}
+	return s.notifier.Send(receipt.RecipientID, receipt)
+}
+
+// MarkRead marks the counterpart's messages as read and tells the counterpart.
+func (s *Service) MarkRead(ctx context.Context, readerID, rideID string) error {
+	conv, err := s.store.GetConversation(ctx, rideID)
+	if err != nil {
+		return err
+	}
+	counterpartID, _, err := conv.Counterpart(readerID)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	ids, err := s.store.MarkRead(ctx, rideID, readerID, now)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	return s.relay.RelayReceipt(ctx, messages.ChatReceipt{
+		Type:        messages.ChatTypeReceipt,
+		RideID:      rideID,
+		RecipientID: counterpartID,
+		MessageIDs:  ids,
+		Status:      messages.ChatRead,
+		At:          now,
+	})
+}
+
+// Replay sends the last messages of the user's open chat after a reconnect and
+// acknowledges the ones that were waiting for them.
+func (s *Service) Replay(ctx context.Context, userID string) error {
+	now := s.now()
+	conv, err := s.store.LatestConversation(ctx, userID, now.Add(-CloseAfter))
+	if err != nil {
+		if errors.Is(err, ErrNoConversation) {
+			return nil
+		}
+		return err
+	}
+	counterpartID, _, err := conv.Counterpart(userID)
+	if err != nil {
+		return err
+	}
+
+	history, err := s.store.RecentMessages(ctx, conv.RideID, HistoryLimit)
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	if err := s.notifier.Send(userID, map[string]any{
+		"type":     "chat_history",
+		"ride_id":  conv.RideID,
+		"messages": history,
+	}); err != nil {
+		return err
+	}
+
+	var waiting []string
+	for _, m := range history {
+		if m.SenderID == counterpartID && m.DeliveredAt == nil {
+			waiting = append(waiting, m.ID)
+		}
+	}
+	if len(waiting) == 0 {
+		return nil
+	}
+	return s.acknowledge(ctx, conv.RideID, counterpartID, waiting)
+}
+
+// acknowledge marks messages delivered and sends the receipt back to their sender.
+func (s *Service) acknowledge(ctx context.Context, rideID, senderID string, ids []string) error {
+	now := s.now()
+	if err := s.store.MarkDelivered(ctx, ids, now); err != nil {
+		return err
+	}
+
+	return s.relay.RelayReceipt(ctx, messages.ChatReceipt{
+		Type:        messages.ChatTypeReceipt,
+		RideID:      rideID,
+		RecipientID: senderID,
+		MessageIDs:  ids,
+		Status:      messages.ChatDelivered,
+		At:          now,
+	})
+}
+
+// Consume delivers the messages and receipts relayed from the other service.
+func (s *Service) Consume(ctx context.Context, ch <-chan rabbitmq.Message) {
+	for msg := range ch {
+		if err := s.handle(ctx, msg.Body()); err != nil {
+			slog.Error("failed to deliver chat event", "error", err.Error())
+		}
+		_ = msg.Ack(false)
+	}
+}
+
+func (s *Service) handle(ctx context.Context, body []byte) error {
+	var envelope struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return err
+	}
+
+	switch envelope.Type {
+	case messages.ChatTypeMessage:
+		var msg messages.ChatMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return err
+		}
+		return s.Deliver(ctx, msg)
+	case messages.ChatTypeReceipt:
+		var receipt messages.ChatReceipt
+		if err := json.Unmarshal(body, &receipt); err != nil {
+			return err
+		}
+		return s.DeliverReceipt(ctx, receipt)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,259 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ride-hail/internal/shared/broker/messages"
+)
+
+type mockStore struct {
+	conv      Conversation
+	convErr   error
+	saved     []Message
+	history   []Message
+	delivered []string
+	readIDs   []string
+}
+
+func (m *mockStore) GetConversation(ctx context.Context, rideID string) (Conversation, error) {
+	return m.conv, m.convErr
+}
+
+func (m *mockStore) LatestConversation(ctx context.Context, userID string, since time.Time) (Conversation, error) {
+	return m.conv, m.convErr
+}
+
+func (m *mockStore) SaveMessage(ctx context.Context, msg *Message) error {
+	msg.ID = "msg-new"
+	m.saved = append(m.saved, *msg)
+	return nil
+}
+
+func (m *mockStore) RecentMessages(ctx context.Context, rideID string, limit int) ([]Message, error) {
+	return m.history, nil
+}
+
+func (m *mockStore) MarkDelivered(ctx context.Context, messageIDs []string, at time.Time) error {
+	m.delivered = append(m.delivered, messageIDs...)
+	return nil
+}
+
+func (m *mockStore) MarkRead(ctx context.Context, rideID, readerID string, at time.Time) ([]string, error) {
+	return m.readIDs, nil
+}
+
+type mockRelay struct {
+	messages []messages.ChatMessage
+	receipts []messages.ChatReceipt
+}
+
+func (m *mockRelay) RelayMessage(ctx context.Context, msg messages.ChatMessage) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func (m *mockRelay) RelayReceipt(ctx context.Context, receipt messages.ChatReceipt) error {
+	m.receipts = append(m.receipts, receipt)
+	return nil
+}
+
+type mockNotifier struct {
+	connected map[string]bool
+	sent      map[string][]any
+}
+
+func (m *mockNotifier) IsConnected(userID string) bool {
+	return m.connected[userID]
+}
+
+func (m *mockNotifier) Send(userID string, event any) error {
+	if m.sent == nil {
+		m.sent = make(map[string][]any)
+	}
+	m.sent[userID] = append(m.sent[userID], event)
+	return nil
+}
+
+var now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func activeConversation() Conversation {
+	return Conversation{RideID: "ride-1", PassengerID: "passenger-1", DriverID: "driver-1", Status: "IN_PROGRESS"}
+}
+
+func newTestService(store *mockStore, relay *mockRelay, notifier *mockNotifier) *Service {
+	svc := NewService(store, relay, notifier)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestConversation_IsOpen(t *testing.T) {
+	ended := func(ago time.Duration) *time.Time {
+		at := now.Add(-ago)
+		return &at
+	}
+
+	cases := []struct {
+		name string
+		conv Conversation
+		want bool
+	}{
+		{name: "no driver yet", conv: Conversation{Status: "REQUESTED"}, want: false},
+		{name: "ride in progress", conv: activeConversation(), want: true},
+		{name: "just completed", conv: Conversation{DriverID: "d", Status: "COMPLETED", EndedAt: ended(time.Minute)}, want: true},
+		{name: "completed long ago", conv: Conversation{DriverID: "d", Status: "COMPLETED", EndedAt: ended(CloseAfter)}, want: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.conv.IsOpen(now); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestService_Send(t *testing.T) {
+	cases := []struct {
+		name     string
+		senderID string
+		body     string
+		conv     Conversation
+		wantErr  error
+		wantTo   string
+		wantRole string
+	}{
+		{name: "passenger to driver", senderID: "passenger-1", body: " hi ", conv: activeConversation(), wantTo: "driver-1", wantRole: RolePassenger},
+		{name: "driver to passenger", senderID: "driver-1", body: "here", conv: activeConversation(), wantTo: "passenger-1", wantRole: RoleDriver},
+		{name: "stranger", senderID: "someone", body: "hi", conv: activeConversation(), wantErr: ErrNotParticipant},
+		{name: "empty", senderID: "passenger-1", body: "  ", conv: activeConversation(), wantErr: ErrEmptyMessage},
+		{name: "too long", senderID: "passenger-1", body: strings.Repeat("a", MaxBodyLength+1), conv: activeConversation(), wantErr: ErrMessageTooLong},
+		{name: "closed", senderID: "passenger-1", body: "hi", conv: Conversation{PassengerID: "passenger-1", Status: "CANCELLED"}, wantErr: ErrChatClosed},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			store := &mockStore{conv: tc.conv}
+			relay := &mockRelay{}
+			svc := newTestService(store, relay, &mockNotifier{})
+
+			msg, err := svc.Send(context.Background(), tc.senderID, "ride-1", tc.body)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				if len(store.saved) != 0 || len(relay.messages) != 0 {
+					t.Fatal("rejected message must not be stored or relayed")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if msg.SenderRole != tc.wantRole || msg.Body != strings.TrimSpace(tc.body) {
+				t.Fatalf("unexpected message %+v", msg)
+			}
+			if len(relay.messages) != 1 || relay.messages[0].RecipientID != tc.wantTo {
+				t.Fatalf("expected message relayed to %s, got %+v", tc.wantTo, relay.messages)
+			}
+		})
+	}
+}
+
+func TestService_Deliver(t *testing.T) {
+	msg := messages.ChatMessage{ID: "msg-1", RideID: "ride-1", SenderID: "driver-1", RecipientID: "passenger-1"}
+
+	t.Run("recipient connected", func(t *testing.T) {
+		store := &mockStore{}
+		relay := &mockRelay{}
+		notifier := &mockNotifier{connected: map[string]bool{"passenger-1": true}}
+		svc := newTestService(store, relay, notifier)
+
+		if err := svc.Deliver(context.Background(), msg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifier.sent["passenger-1"]) != 1 {
+			t.Fatal("expected message pushed to the passenger")
+		}
+		if len(store.delivered) != 1 || len(relay.receipts) != 1 {
+			t.Fatalf("expected delivered receipt, got %+v", relay.receipts)
+		}
+		if r := relay.receipts[0]; r.RecipientID != "driver-1" || r.Status != messages.ChatDelivered {
+			t.Fatalf("unexpected receipt %+v", r)
+		}
+	})
+
+	t.Run("recipient offline", func(t *testing.T) {
+		store := &mockStore{}
+		relay := &mockRelay{}
+		svc := newTestService(store, relay, &mockNotifier{})
+
+		if err := svc.Deliver(context.Background(), msg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(store.delivered) != 0 || len(relay.receipts) != 0 {
+			t.Fatal("offline recipient must not produce receipts")
+		}
+	})
+}
+
+func TestService_MarkRead(t *testing.T) {
+	store := &mockStore{conv: activeConversation(), readIDs: []string{"msg-1", "msg-2"}}
+	relay := &mockRelay{}
+	svc := newTestService(store, relay, &mockNotifier{})
+
+	if err := svc.MarkRead(context.Background(), "passenger-1", "ride-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(relay.receipts) != 1 {
+		t.Fatalf("expected one receipt, got %d", len(relay.receipts))
+	}
+	if r := relay.receipts[0]; r.RecipientID != "driver-1" || r.Status != messages.ChatRead || len(r.MessageIDs) != 2 {
+		t.Fatalf("unexpected receipt %+v", r)
+	}
+}
+
+func TestService_Replay(t *testing.T) {
+	deliveredAt := now.Add(-time.Minute)
+	store := &mockStore{
+		conv: activeConversation(),
+		history: []Message{
+			{ID: "msg-1", SenderID: "passenger-1", Body: "where are you?"},
+			{ID: "msg-2", SenderID: "driver-1", Body: "2 minutes", DeliveredAt: &deliveredAt},
+			{ID: "msg-3", SenderID: "driver-1", Body: "here"},
+		},
+	}
+	relay := &mockRelay{}
+	notifier := &mockNotifier{}
+	svc := newTestService(store, relay, notifier)
+
+	if err := svc.Replay(context.Background(), "passenger-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.sent["passenger-1"]) != 1 {
+		t.Fatal("expected chat history pushed to the passenger")
+	}
+	if len(store.delivered) != 1 || store.delivered[0] != "msg-3" {
+		t.Fatalf("expected only msg-3 marked delivered, got %v", store.delivered)
+	}
+	if len(relay.receipts) != 1 || relay.receipts[0].RecipientID != "driver-1" {
+		t.Fatalf("expected delivered receipt to the driver, got %+v", relay.receipts)
+	}
+}
+
+func TestService_Replay_NoChat(t *testing.T) {
+	notifier := &mockNotifier{}
+	svc := newTestService(&mockStore{convErr: ErrNoConversation}, &mockRelay{}, notifier)
+
+	if err := svc.Replay(context.Background(), "passenger-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.sent) != 0 {
+		t.Fatal("expected nothing sent without an open chat")
+	}
+}
@@ -0,0 +1,46 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+
+	"ride-hail/internal/shared/broker/messages"
+)
+
+// Publisher publishes raw messages to an exchange.
+type Publisher interface {
+	Publish(ctx context.Context, exchange, routingKey string, body []byte) error
+}
+
+// BrokerRelay publishes chat events to the exchange the other service consumes.
+type BrokerRelay struct {
+	publisher  Publisher
+	exchange   string
+	routingKey func(rideID string) string
+}
+
+// NewBrokerRelay creates a relay. The ride service publishes to ride_topic with
+// RideChatRoutingKey, the driver service to driver_topic with DriverChatRoutingKey.
+func NewBrokerRelay(publisher Publisher, exchange string, routingKey func(rideID string) string) *BrokerRelay {
+	return &BrokerRelay{
+		publisher:  publisher,
+		exchange:   exchange,
+		routingKey: routingKey,
+	}
+}
+
+func (r *BrokerRelay) RelayMessage(ctx context.Context, msg messages.ChatMessage) error {
+	return r.publish(ctx, msg.RideID, msg)
+}
+
+func (r *BrokerRelay) RelayReceipt(ctx context.Context, receipt messages.ChatReceipt) error {
+	return r.publish(ctx, receipt.RideID, receipt)
+}
+
+func (r *BrokerRelay) publish(ctx context.Context, rideID string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return r.publisher.Publish(ctx, r.exchange, r.routingKey(rideID), body)
+}
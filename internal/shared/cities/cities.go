@@ -0,0 +1,135 @@
+package cities
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+	_ "time/tzdata" // часовые пояса городов не должны зависеть от образа контейнера
+
+	"ride-hail/internal/shared/geo"
+)
+
+// City is a market with its own tariffs, currency, timezone and service area.
+type City struct {
+	ID          string      `json:"id"`
+	Code        string      `json:"code"`
+	Name        string      `json:"name"`
+	Currency    string      `json:"currency"`
+	Timezone    string      `json:"timezone"`
+	ServiceArea geo.Polygon `json:"service_area,omitempty"`
+	IsDefault   bool        `json:"is_default"`
+	IsActive    bool        `json:"is_active"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// Location returns the city's timezone, UTC when it is unknown.
+func (c City) Location() *time.Location {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Loader loads the cities from storage.
+type Loader interface {
+	ListCities(ctx context.Context) ([]City, error)
+}
+
+// Registry keeps the cities in memory and refreshes them periodically.
+type Registry struct {
+	loader Loader
+
+	mu     sync.RWMutex
+	cities []City
+}
+
+func NewRegistry(loader Loader) *Registry {
+	return &Registry{loader: loader}
+}
+
+// Refresh reloads the cities. On error the previous snapshot is kept.
+func (r *Registry) Refresh(ctx context.Context) error {
+	cities, err := r.loader.ListCities(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.Set(cities)
+	return nil
+}
+
+// Run refreshes the registry every interval until ctx is cancelled.
+func (r *Registry) Run(ctx context.Context, interval time.Duration) {
+	if err := r.Refresh(ctx); err != nil {
+		slog.Error("failed to load cities", "error", err.Error())
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				slog.Error("failed to refresh cities", "error", err.Error())
+			}
+		}
+	}
+}
+
+// Set replaces the cached cities.
+func (r *Registry) Set(cities []City) {
+	r.mu.Lock()
+	r.cities = cities
+	r.mu.Unlock()
+}
+
+// All returns a copy of the cached cities.
+func (r *Registry) All() []City {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]City(nil), r.cities...)
+}
+
+// ByID returns the city with the given ID.
+func (r *Registry) ByID(id string) (City, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.cities {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return City{}, false
+}
+
+// CityAt returns the active city whose service area contains pt.
+// Points outside every service area belong to the default city, if there is one.
+func (r *Registry) CityAt(pt geo.Point) (City, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var fallback *City
+	for i, c := range r.cities {
+		if !c.IsActive {
+			continue
+		}
+		if c.ServiceArea.IsValid() && c.ServiceArea.Contains(pt) {
+			return c, true
+		}
+		if c.IsDefault {
+			fallback = &r.cities[i]
+		}
+	}
+
+	if fallback != nil {
+		return *fallback, true
+	}
+	return City{}, false
+}
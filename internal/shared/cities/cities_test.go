@@ -0,0 +1,78 @@
+package cities
+
+import (
+	"testing"
+	"time"
+
+	"ride-hail/internal/shared/geo"
+)
+
+var almatyArea = geo.Polygon{
+	{Lat: 43.10, Lng: 76.70},
+	{Lat: 43.10, Lng: 77.00},
+	{Lat: 43.40, Lng: 77.00},
+	{Lat: 43.40, Lng: 76.70},
+}
+
+var astanaArea = geo.Polygon{
+	{Lat: 51.00, Lng: 71.20},
+	{Lat: 51.00, Lng: 71.70},
+	{Lat: 51.30, Lng: 71.70},
+	{Lat: 51.30, Lng: 71.20},
+}
+
+func TestRegistry_CityAt(t *testing.T) {
+	cases := []struct {
+		name     string
+		cities   []City
+		pt       geo.Point
+		wantCode string
+		wantOK   bool
+	}{
+		{
+			name:     "inside service area",
+			cities:   []City{{Code: "ALA", ServiceArea: almatyArea, IsActive: true}, {Code: "NQZ", ServiceArea: astanaArea, IsActive: true}},
+			pt:       geo.Point{Lat: 51.128207, Lng: 71.430411},
+			wantCode: "NQZ",
+			wantOK:   true,
+		},
+		{
+			name:     "outside every area falls back to default",
+			cities:   []City{{Code: "ALA", IsDefault: true, IsActive: true}, {Code: "NQZ", ServiceArea: astanaArea, IsActive: true}},
+			pt:       geo.Point{Lat: 47.1, Lng: 51.9},
+			wantCode: "ALA",
+			wantOK:   true,
+		},
+		{
+			name:   "inactive city is skipped",
+			cities: []City{{Code: "NQZ", ServiceArea: astanaArea, IsActive: false}},
+			pt:     geo.Point{Lat: 51.128207, Lng: 71.430411},
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRegistry(nil)
+			r.Set(tc.cities)
+
+			city, ok := r.CityAt(tc.pt)
+			if ok != tc.wantOK {
+				t.Fatalf("expected ok=%v, got %v", tc.wantOK, ok)
+			}
+			if city.Code != tc.wantCode {
+				t.Fatalf("expected %q, got %q", tc.wantCode, city.Code)
+			}
+		})
+	}
+}
+
+func TestCity_Location(t *testing.T) {
+	if loc := (City{Timezone: "Asia/Almaty"}).Location(); loc.String() != "Asia/Almaty" {
+		t.Fatalf("expected Asia/Almaty, got %s", loc)
+	}
+	if loc := (City{Timezone: "Mars/Olympus"}).Location(); loc != time.UTC {
+		t.Fatalf("expected UTC fallback, got %s", loc)
+	}
+}
@@ -0,0 +1,68 @@
+package destination
+
+import (
+	"errors"
+	"time"
+
+	"ride-hail/internal/shared/geo"
+)
+
+// Defaults used when the service is not configured otherwise.
+const (
+	DefaultMaxPerDay       = 2
+	DefaultMinProgress     = 0.5
+	DefaultTimeout         = 2 * time.Hour
+	DefaultArrivalRadiusKm = 0.5
+)
+
+// Reasons destination mode ends
+const (
+	EndArrived = "ARRIVED"
+	EndExpired = "EXPIRED"
+	EndCleared = "CLEARED"
+)
+
+var ErrDailyLimit = errors.New("destination mode used up for today")
+
+// Policy limits destination mode. A driver may set a destination MaxPerDay times
+// a UTC day. While it is set, a ride is offered only if its drop-off is closer
+// to the destination than the driver is by at least MinProgress of the trip.
+// The mode ends within ArrivalRadiusKm of the destination or after Timeout.
+type Policy struct {
+	MaxPerDay       int
+	MinProgress     float64
+	Timeout         time.Duration
+	ArrivalRadiusKm float64
+}
+
+// DefaultPolicy is used when the service is not configured otherwise.
+var DefaultPolicy = Policy{
+	MaxPerDay:       DefaultMaxPerDay,
+	MinProgress:     DefaultMinProgress,
+	Timeout:         DefaultTimeout,
+	ArrivalRadiusKm: DefaultArrivalRadiusKm,
+}
+
+// DayStart returns when the day containing t started; the daily limit counts from there.
+func DayStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Progress returns how much closer to dest a trip ending at dropoff brings a
+// driver who is at from. It is negative when the trip leads away.
+func Progress(from, dropoff, dest geo.Point) float64 {
+	return geo.DistanceKm(from, dest) - geo.DistanceKm(dropoff, dest)
+}
+
+// Allows reports whether a trip of tripKm from the driver at from to dropoff
+// brings the driver close enough to dest.
+func (p Policy) Allows(from, dropoff, dest geo.Point, tripKm float64) bool {
+	progress := Progress(from, dropoff, dest)
+	return progress > 0 && progress >= p.MinProgress*tripKm
+}
+
+// Arrived reports whether the driver at is close enough to dest to end the mode.
+func (p Policy) Arrived(at, dest geo.Point) bool {
+	return geo.DistanceKm(at, dest) <= p.ArrivalRadiusKm
+}
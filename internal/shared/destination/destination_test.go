@@ -0,0 +1,58 @@
+package destination
+
+import (
+	"testing"
+	"time"
+
+	"ride-hail/internal/shared/geo"
+)
+
+// Points along a meridian, about 11.1 km apart per 0.1 degree
+var (
+	home    = geo.Point{Lat: 51.3, Lng: 71.4}
+	driver  = geo.Point{Lat: 51.0, Lng: 71.4}
+	towards = geo.Point{Lat: 51.2, Lng: 71.4}
+	away    = geo.Point{Lat: 50.9, Lng: 71.4}
+)
+
+func TestDayStart(t *testing.T) {
+	at := time.Date(2026, 10, 18, 23, 30, 0, 0, time.FixedZone("UTC+5", 5*3600))
+	want := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	if got := DayStart(at); !got.Equal(want) {
+		t.Errorf("DayStart() = %s, want %s", got, want)
+	}
+}
+
+func TestPolicy_Allows(t *testing.T) {
+	cases := []struct {
+		name    string
+		pickup  geo.Point
+		dropoff geo.Point
+		want    bool
+	}{
+		{"straight towards home", driver, towards, true},
+		{"away from home", driver, away, false},
+		{"detour for little progress", away, geo.Point{Lat: 51.05, Lng: 71.4}, false},
+		{"ends at home", driver, home, true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tripKm := geo.DistanceKm(tc.pickup, tc.dropoff)
+			if got := DefaultPolicy.Allows(driver, tc.dropoff, home, tripKm); got != tc.want {
+				t.Errorf("Allows() = %v, want %v (progress %.1f km of a %.1f km trip)",
+					got, tc.want, Progress(driver, tc.dropoff, home), tripKm)
+			}
+		})
+	}
+}
+
+func TestPolicy_Arrived(t *testing.T) {
+	if DefaultPolicy.Arrived(driver, home) {
+		t.Error("Arrived() = true 33 km away")
+	}
+	if !DefaultPolicy.Arrived(geo.Point{Lat: 51.302, Lng: 71.4}, home) {
+		t.Error("Arrived() = false 200 m away")
+	}
+}
@@ -0,0 +1,46 @@
+package earnings
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+// Report periods. Periods are calendar days, weeks starting on Monday and months in UTC.
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+var ErrInvalidPeriod = errors.New("invalid earnings period")
+
+// Bounds returns the start and the end of the period that contains at.
+func Bounds(period string, at time.Time) (time.Time, time.Time, error) {
+	at = at.UTC()
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case PeriodDay:
+		return day, day.AddDate(0, 0, 1), nil
+	case PeriodWeek:
+		// Weekday counts from Sunday, weeks start on Monday
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return start, start.AddDate(0, 0, 7), nil
+	case PeriodMonth:
+		start := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0), nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %q, use day, week or month", ErrInvalidPeriod, period)
+	}
+}
+
+// PerHour is the amount earned per hour online; zero when no time was spent online.
+func PerHour(amount money.Money, hours float64) money.Money {
+	if hours <= 0 {
+		return money.Zero(amount.Currency)
+	}
+	return amount.Mul(1/hours, money.HalfUp)
+}
@@ -0,0 +1,53 @@
+package earnings
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+func TestBounds(t *testing.T) {
+	// Sunday evening
+	at := time.Date(2026, 10, 18, 21, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		period   string
+		at       time.Time
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		{PeriodDay, at, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{PeriodWeek, at, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{PeriodWeek, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{PeriodMonth, at, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{PeriodMonth, time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.period+" "+tc.at.Format(time.DateOnly), func(t *testing.T) {
+			from, to, err := Bounds(tc.period, tc.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !from.Equal(tc.wantFrom) || !to.Equal(tc.wantTo) {
+				t.Errorf("Bounds() = %s..%s, want %s..%s", from, to, tc.wantFrom, tc.wantTo)
+			}
+		})
+	}
+
+	if _, _, err := Bounds("year", at); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("Bounds(year): err = %v, want ErrInvalidPeriod", err)
+	}
+}
+
+func TestPerHour(t *testing.T) {
+	if got := PerHour(money.New(10000, "KZT"), 4); got != money.New(2500, "KZT") {
+		t.Errorf("PerHour = %v, want 25.00 KZT", got)
+	}
+	if got := PerHour(money.New(10000, "KZT"), 0); !got.IsZero() {
+		t.Errorf("PerHour with no hours = %v, want zero", got)
+	}
+}
@@ -0,0 +1,187 @@
+package fatigue
+
+import (
+	"sort"
+	"time"
+)
+
+// Defaults used when the service is not configured otherwise.
+const (
+	DefaultMaxOnline     = 10 * time.Hour
+	DefaultWindow        = 24 * time.Hour
+	DefaultMaxContinuous = 5 * time.Hour
+	DefaultRequiredBreak = 30 * time.Minute
+	DefaultWarnBefore    = 30 * time.Minute
+)
+
+// Limits a driver can reach
+const (
+	LimitDaily      = "daily_online_hours"
+	LimitContinuous = "continuous_driving"
+)
+
+// Policy caps driving hours. Working time is time online outside breaks: at most
+// MaxOnline of it within any Window, and at most MaxContinuous of it without a
+// rest of RequiredBreak, on a break or offline.
+type Policy struct {
+	MaxOnline     time.Duration
+	Window        time.Duration
+	MaxContinuous time.Duration
+	RequiredBreak time.Duration
+	// WarnBefore is how long before a limit the driver is warned
+	WarnBefore time.Duration
+}
+
+// DefaultPolicy is used when the service is not configured otherwise.
+var DefaultPolicy = Policy{
+	MaxOnline:     DefaultMaxOnline,
+	Window:        DefaultWindow,
+	MaxContinuous: DefaultMaxContinuous,
+	RequiredBreak: DefaultRequiredBreak,
+	WarnBefore:    DefaultWarnBefore,
+}
+
+// Interval is a session or a break. A zero End means it is still going on.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Status is where a driver stands against the policy.
+type Status struct {
+	// Online is the working time within the window
+	Online time.Duration
+	// Continuous is the working time since the last rest
+	Continuous time.Duration
+	// Limit is the limit reached, or the one the driver is closest to
+	Limit string
+	// Remaining is the working time left before Limit
+	Remaining time.Duration
+	// RestUntil is when the driver may work again once a limit is reached
+	RestUntil time.Time
+}
+
+// Exceeded reports whether the driver reached a limit.
+func (s Status) Exceeded() bool {
+	return s.Remaining <= 0
+}
+
+// Warn reports whether the driver is close enough to a limit to be warned.
+func (p Policy) Warn(s Status) bool {
+	return !s.Exceeded() && s.Remaining <= p.WarnBefore
+}
+
+// Lookback is how far back sessions and breaks are needed to evaluate a driver.
+func (p Policy) Lookback() time.Duration {
+	return p.Window + p.MaxContinuous + p.RequiredBreak
+}
+
+// Evaluate works out the driver's status at now from their sessions and the
+// breaks taken during them.
+func (p Policy) Evaluate(sessions, breaks []Interval, now time.Time) Status {
+	working := subtract(clip(sessions, now), clip(breaks, now))
+
+	var s Status
+	windowStart := now.Add(-p.Window)
+	for _, w := range working {
+		if w.End.After(windowStart) {
+			s.Online += w.End.Sub(later(w.Start, windowStart))
+		}
+	}
+
+	// Working time since the last rest long enough, walking back from now
+	lastWork := time.Time{}
+	if n := len(working); n > 0 {
+		lastWork = working[n-1].End
+	}
+	restFrom := now
+	for i := len(working) - 1; i >= 0; i-- {
+		if restFrom.Sub(working[i].End) >= p.RequiredBreak {
+			break
+		}
+		s.Continuous += working[i].End.Sub(working[i].Start)
+		restFrom = working[i].Start
+	}
+
+	daily := p.MaxOnline - s.Online
+	continuous := p.MaxContinuous - s.Continuous
+	s.Limit, s.Remaining = LimitDaily, daily
+	if continuous < daily {
+		s.Limit, s.Remaining = LimitContinuous, continuous
+	}
+
+	if continuous <= 0 {
+		s.RestUntil = lastWork.Add(p.RequiredBreak)
+	}
+	if daily <= 0 {
+		if until := dailyRestUntil(working, windowStart, -daily, p.Window); until.After(s.RestUntil) {
+			s.RestUntil = until
+		}
+	}
+	return s
+}
+
+// dailyRestUntil returns when the oldest excess of working time in the window has
+// left it.
+func dailyRestUntil(working []Interval, windowStart time.Time, excess, window time.Duration) time.Time {
+	for _, w := range working {
+		if !w.End.After(windowStart) {
+			continue
+		}
+		start := later(w.Start, windowStart)
+		if d := w.End.Sub(start); d < excess {
+			excess -= d
+			continue
+		}
+		return start.Add(excess).Add(window)
+	}
+	return time.Time{}
+}
+
+// clip ends intervals still going on at now and drops the ones not started yet.
+func clip(intervals []Interval, now time.Time) []Interval {
+	result := make([]Interval, 0, len(intervals))
+	for _, in := range intervals {
+		if in.End.IsZero() || in.End.After(now) {
+			in.End = now
+		}
+		if in.Start.Before(in.End) {
+			result = append(result, in)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+	return result
+}
+
+// subtract returns the parts of the sorted intervals not covered by any cut.
+func subtract(intervals, cuts []Interval) []Interval {
+	var result []Interval
+	for _, in := range intervals {
+		parts := []Interval{in}
+		for _, cut := range cuts {
+			var next []Interval
+			for _, part := range parts {
+				if !cut.Start.Before(part.End) || !cut.End.After(part.Start) {
+					next = append(next, part)
+					continue
+				}
+				if part.Start.Before(cut.Start) {
+					next = append(next, Interval{Start: part.Start, End: cut.Start})
+				}
+				if cut.End.Before(part.End) {
+					next = append(next, Interval{Start: cut.End, End: part.End})
+				}
+			}
+			parts = next
+		}
+		result = append(result, parts...)
+	}
+	return result
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
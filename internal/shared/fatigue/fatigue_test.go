@@ -0,0 +1,122 @@
+package fatigue
+
+import (
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC)
+
+// ago returns the interval from..to hours before now; a zero to is still going on.
+func ago(from, to float64) Interval {
+	in := Interval{Start: now.Add(-hours(from))}
+	if to > 0 {
+		in.End = now.Add(-hours(to))
+	}
+	return in
+}
+
+func hours(h float64) time.Duration {
+	return time.Duration(h * float64(time.Hour))
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	cases := []struct {
+		name           string
+		sessions       []Interval
+		breaks         []Interval
+		wantOnline     time.Duration
+		wantContinuous time.Duration
+		wantLimit      string
+		wantExceeded   bool
+		wantRestUntil  time.Time
+	}{
+		{
+			name:           "fresh driver",
+			sessions:       []Interval{ago(2, 0)},
+			wantOnline:     2 * time.Hour,
+			wantContinuous: 2 * time.Hour,
+			wantLimit:      LimitContinuous,
+		},
+		{
+			name:           "break resets continuous driving",
+			sessions:       []Interval{ago(6, 0)},
+			breaks:         []Interval{ago(2, 1.5)},
+			wantOnline:     5*time.Hour + 30*time.Minute,
+			wantContinuous: 90 * time.Minute,
+			wantLimit:      LimitContinuous,
+		},
+		{
+			name:           "short break does not count as rest",
+			sessions:       []Interval{ago(5.5, 0)},
+			breaks:         []Interval{ago(2, 1.75)},
+			wantOnline:     5*time.Hour + 15*time.Minute,
+			wantContinuous: 5*time.Hour + 15*time.Minute,
+			wantLimit:      LimitContinuous,
+			wantExceeded:   true,
+			wantRestUntil:  now.Add(30 * time.Minute),
+		},
+		{
+			name:           "offline long enough",
+			sessions:       []Interval{ago(9, 5), ago(4, 0)},
+			wantOnline:     8 * time.Hour,
+			wantContinuous: 4 * time.Hour,
+			wantLimit:      LimitContinuous,
+		},
+		{
+			name:           "daily hours used up",
+			sessions:       []Interval{ago(23, 19), ago(18, 14), ago(13, 11)},
+			wantOnline:     10 * time.Hour,
+			wantContinuous: 0,
+			wantLimit:      LimitDaily,
+			wantExceeded:   true,
+			// work started at 21:00 yesterday begins to leave the window at 21:00 today
+			wantRestUntil: now.Add(time.Hour),
+		},
+		{
+			name:           "sessions outside the window",
+			sessions:       []Interval{ago(40, 30), ago(1, 0)},
+			wantOnline:     time.Hour,
+			wantContinuous: time.Hour,
+			wantLimit:      LimitContinuous,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := DefaultPolicy.Evaluate(tc.sessions, tc.breaks, now)
+			if got.Online != tc.wantOnline || got.Continuous != tc.wantContinuous {
+				t.Fatalf("expected online %v continuous %v, got %v and %v", tc.wantOnline, tc.wantContinuous, got.Online, got.Continuous)
+			}
+			if got.Limit != tc.wantLimit || got.Exceeded() != tc.wantExceeded {
+				t.Fatalf("expected limit %s exceeded=%v, got %s exceeded=%v", tc.wantLimit, tc.wantExceeded, got.Limit, got.Exceeded())
+			}
+			if !got.RestUntil.Equal(tc.wantRestUntil) {
+				t.Fatalf("expected rest until %v, got %v", tc.wantRestUntil, got.RestUntil)
+			}
+		})
+	}
+}
+
+func TestPolicy_Warn(t *testing.T) {
+	cases := []struct {
+		name     string
+		sessions []Interval
+		want     bool
+	}{
+		{name: "far from limits", sessions: []Interval{ago(1, 0)}},
+		{name: "close to continuous limit", sessions: []Interval{ago(4.75, 0)}, want: true},
+		{name: "limit reached", sessions: []Interval{ago(5, 0)}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			status := DefaultPolicy.Evaluate(tc.sessions, nil, now)
+			if got := DefaultPolicy.Warn(status); got != tc.want {
+				t.Fatalf("expected warn=%v, got %v for %+v", tc.want, got, status)
+			}
+		})
+	}
+}
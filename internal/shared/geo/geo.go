@@ -0,0 +1,138 @@
+package geo
+
+import "math"
+
+const earthRadiusKm = 6371.0
+
+// Point is a WGS84 coordinate.
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Polygon is a closed ring of points. The last point does not have to repeat the first one.
+type Polygon []Point
+
+// DistanceKm returns the great-circle distance between two points using the Haversine formula.
+func DistanceKm(a, b Point) float64 {
+	lat1 := toRadians(a.Lat)
+	lat2 := toRadians(b.Lat)
+	dLat := toRadians(b.Lat - a.Lat)
+	dLng := toRadians(b.Lng - a.Lng)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusKm * math.Atan2(math.Sqrt(h), math.Sqrt(1-h))
+}
+
+// IsValid reports whether the polygon has enough vertices and all of them are valid coordinates.
+func (p Polygon) IsValid() bool {
+	if len(p) < 3 {
+		return false
+	}
+	for _, pt := range p {
+		if pt.Lat < -90 || pt.Lat > 90 || pt.Lng < -180 || pt.Lng > 180 {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains reports whether pt lies inside the polygon (ray casting).
+func (p Polygon) Contains(pt Point) bool {
+	if len(p) < 3 {
+		return false
+	}
+
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if (a.Lat > pt.Lat) != (b.Lat > pt.Lat) &&
+			pt.Lng < (b.Lng-a.Lng)*(pt.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// NearestBoundaryPoint returns the point on the polygon edge closest to pt.
+// Edges are projected on a local equirectangular plane, which is accurate
+// enough for city-sized zones.
+func (p Polygon) NearestBoundaryPoint(pt Point) Point {
+	if len(p) == 0 {
+		return pt
+	}
+
+	scale := math.Cos(toRadians(pt.Lat))
+	best := p[0]
+	bestDist := math.MaxFloat64
+
+	for i := range p {
+		a := p[i]
+		b := p[(i+1)%len(p)]
+
+		ax, ay := (a.Lng-pt.Lng)*scale, a.Lat-pt.Lat
+		bx, by := (b.Lng-pt.Lng)*scale, b.Lat-pt.Lat
+		dx, dy := bx-ax, by-ay
+
+		t := 0.0
+		if lenSq := dx*dx + dy*dy; lenSq > 0 {
+			t = -(ax*dx + ay*dy) / lenSq
+			t = math.Max(0, math.Min(1, t))
+		}
+
+		cx, cy := ax+t*dx, ay+t*dy
+		if d := cx*cx + cy*cy; d < bestDist {
+			bestDist = d
+			best = Point{Lat: a.Lat + t*(b.Lat-a.Lat), Lng: a.Lng + t*(b.Lng-a.Lng)}
+		}
+	}
+
+	return best
+}
+
+// PushOutside moves pt just past the nearest edge of the polygon so the
+// resulting point is no longer inside it. Points already outside are returned unchanged.
+func (p Polygon) PushOutside(pt Point, marginKm float64) Point {
+	if !p.Contains(pt) {
+		return pt
+	}
+
+	edge := p.NearestBoundaryPoint(pt)
+	dist := DistanceKm(pt, edge)
+	if dist == 0 {
+		// Point sits on the edge: step away from the polygon centroid instead.
+		c := p.Centroid()
+		dist = DistanceKm(c, edge)
+		if dist == 0 {
+			return edge
+		}
+		pt = c
+	}
+
+	f := (dist + marginKm) / dist
+	return Point{
+		Lat: pt.Lat + (edge.Lat-pt.Lat)*f,
+		Lng: pt.Lng + (edge.Lng-pt.Lng)*f,
+	}
+}
+
+// Centroid returns the arithmetic mean of the polygon vertices.
+func (p Polygon) Centroid() Point {
+	if len(p) == 0 {
+		return Point{}
+	}
+	var c Point
+	for _, pt := range p {
+		c.Lat += pt.Lat
+		c.Lng += pt.Lng
+	}
+	c.Lat /= float64(len(p))
+	c.Lng /= float64(len(p))
+	return c
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
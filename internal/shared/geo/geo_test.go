@@ -0,0 +1,129 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"ride-hail/internal/shared/money"
+)
+
+var square = Polygon{
+	{Lat: 43.20, Lng: 76.80},
+	{Lat: 43.20, Lng: 76.90},
+	{Lat: 43.30, Lng: 76.90},
+	{Lat: 43.30, Lng: 76.80},
+}
+
+func TestDistanceKm(t *testing.T) {
+	a := Point{Lat: 43.238949, Lng: 76.889709}
+	b := Point{Lat: 43.222015, Lng: 76.851511}
+
+	got := DistanceKm(a, b)
+	if math.Abs(got-3.62) > 0.05 {
+		t.Fatalf("expected ~3.62 km, got %.3f", got)
+	}
+	if DistanceKm(a, a) != 0 {
+		t.Fatal("expected zero distance for identical points")
+	}
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	cases := []struct {
+		name string
+		pt   Point
+		want bool
+	}{
+		{name: "inside", pt: Point{Lat: 43.25, Lng: 76.85}, want: true},
+		{name: "outside north", pt: Point{Lat: 43.35, Lng: 76.85}, want: false},
+		{name: "outside east", pt: Point{Lat: 43.25, Lng: 76.95}, want: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := square.Contains(tc.pt); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if (Polygon{{Lat: 0, Lng: 0}, {Lat: 1, Lng: 1}}).Contains(Point{}) {
+		t.Fatal("degenerate polygon must not contain points")
+	}
+}
+
+func TestPolygon_IsValid(t *testing.T) {
+	if !square.IsValid() {
+		t.Fatal("expected square to be valid")
+	}
+	if (Polygon{{Lat: 0, Lng: 0}, {Lat: 1, Lng: 1}}).IsValid() {
+		t.Fatal("expected two-point polygon to be invalid")
+	}
+	if (Polygon{{Lat: 91, Lng: 0}, {Lat: 1, Lng: 1}, {Lat: 2, Lng: 2}}).IsValid() {
+		t.Fatal("expected out-of-range polygon to be invalid")
+	}
+}
+
+func TestPolygon_NearestBoundaryPoint(t *testing.T) {
+	got := square.NearestBoundaryPoint(Point{Lat: 43.29, Lng: 76.85})
+	if math.Abs(got.Lat-43.30) > 1e-9 || math.Abs(got.Lng-76.85) > 1e-9 {
+		t.Fatalf("expected north edge, got %+v", got)
+	}
+}
+
+func TestPolygon_PushOutside(t *testing.T) {
+	inside := Point{Lat: 43.29, Lng: 76.85}
+
+	got := square.PushOutside(inside, 0.05)
+	if square.Contains(got) {
+		t.Fatalf("expected point outside polygon, got %+v", got)
+	}
+	if d := DistanceKm(inside, got); d > 1.5 {
+		t.Fatalf("expected a short move, got %.3f km", d)
+	}
+
+	outside := Point{Lat: 43.40, Lng: 76.85}
+	if square.PushOutside(outside, 0.05) != outside {
+		t.Fatal("expected outside point to be returned unchanged")
+	}
+}
+
+func TestZoneCache(t *testing.T) {
+	cache := NewZoneCache(nil)
+
+	if !cache.InServiceArea(Point{Lat: 0, Lng: 0}) {
+		t.Fatal("expected every point to be serviceable without service areas")
+	}
+
+	cache.Set([]Zone{
+		{ID: "city", Type: ZoneTypeServiceArea, Polygon: square},
+		{ID: "fee", Type: ZoneTypeSurcharge, Polygon: square, Surcharge: money.New(20000, "")},
+	})
+
+	if !cache.InServiceArea(Point{Lat: 43.25, Lng: 76.85}) {
+		t.Fatal("expected point inside service area")
+	}
+	if cache.InServiceArea(Point{Lat: 0, Lng: 0}) {
+		t.Fatal("expected point outside service area")
+	}
+	if got := len(cache.ZonesAt(Point{Lat: 43.25, Lng: 76.85})); got != 2 {
+		t.Fatalf("expected 2 zones, got %d", got)
+	}
+	if got := len(cache.ByType(ZoneTypeAirport)); got != 0 {
+		t.Fatalf("expected no airport zones, got %d", got)
+	}
+}
+
+func TestZoneCache_StagingFor(t *testing.T) {
+	cache := NewZoneCache(nil)
+	cache.Set([]Zone{
+		{ID: "airport", Type: ZoneTypeAirport, Polygon: square},
+		{ID: "lot-a", Type: ZoneTypeAirportStaging, ParentID: "airport", Polygon: square},
+		{ID: "lot-b", Type: ZoneTypeAirportStaging, ParentID: "other", Polygon: square},
+	})
+
+	staging := cache.StagingFor("airport")
+	if len(staging) != 1 || staging[0].ID != "lot-a" {
+		t.Fatalf("expected only lot-a, got %+v", staging)
+	}
+}
@@ -0,0 +1,167 @@
+package geo
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+// ZoneType - тип геозоны
+type ZoneType string
+
+const (
+	ZoneTypeServiceArea ZoneType = "SERVICE_AREA"
+	ZoneTypeNoPickup    ZoneType = "NO_PICKUP"
+	ZoneTypeAirport     ZoneType = "AIRPORT"
+	ZoneTypeSurcharge   ZoneType = "SURCHARGE"
+
+	// ZoneTypeAirportStaging is the waiting area of an airport; ParentID points at the airport zone.
+	ZoneTypeAirportStaging ZoneType = "AIRPORT_STAGING"
+)
+
+func (t ZoneType) IsValid() bool {
+	switch t {
+	case ZoneTypeServiceArea, ZoneTypeNoPickup, ZoneTypeAirport, ZoneTypeSurcharge, ZoneTypeAirportStaging:
+		return true
+	default:
+		return false
+	}
+}
+
+// Zone is an admin-managed polygon with a type and an optional surcharge.
+type Zone struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Type      ZoneType    `json:"zone_type"`
+	Polygon   Polygon     `json:"polygon"`
+	Surcharge money.Money `json:"surcharge_amount"`
+	ParentID  string      `json:"parent_zone_id,omitempty"`
+	IsActive  bool        `json:"is_active"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// ZoneLoader loads the active zones from storage.
+type ZoneLoader interface {
+	ListActiveZones(ctx context.Context) ([]Zone, error)
+}
+
+// ZoneCache keeps the active zones in memory and refreshes them periodically.
+type ZoneCache struct {
+	loader ZoneLoader
+
+	mu    sync.RWMutex
+	zones []Zone
+}
+
+func NewZoneCache(loader ZoneLoader) *ZoneCache {
+	return &ZoneCache{loader: loader}
+}
+
+// Refresh reloads zones from the loader. On error the previous snapshot is kept.
+func (c *ZoneCache) Refresh(ctx context.Context) error {
+	zones, err := c.loader.ListActiveZones(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.zones = zones
+	c.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the cache every interval until ctx is cancelled.
+func (c *ZoneCache) Run(ctx context.Context, interval time.Duration) {
+	if err := c.Refresh(ctx); err != nil {
+		slog.Error("failed to load zones", "error", err.Error())
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil {
+				slog.Error("failed to refresh zones", "error", err.Error())
+			}
+		}
+	}
+}
+
+// Set replaces the cached zones. Useful for tests and for write-through updates.
+func (c *ZoneCache) Set(zones []Zone) {
+	c.mu.Lock()
+	c.zones = zones
+	c.mu.Unlock()
+}
+
+// All returns a copy of the cached zones.
+func (c *ZoneCache) All() []Zone {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Zone(nil), c.zones...)
+}
+
+// ZonesAt returns every zone containing pt.
+func (c *ZoneCache) ZonesAt(pt Point) []Zone {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []Zone
+	for _, z := range c.zones {
+		if z.Polygon.Contains(pt) {
+			result = append(result, z)
+		}
+	}
+	return result
+}
+
+// ByType returns the cached zones of the given type.
+func (c *ZoneCache) ByType(t ZoneType) []Zone {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []Zone
+	for _, z := range c.zones {
+		if z.Type == t {
+			result = append(result, z)
+		}
+	}
+	return result
+}
+
+// StagingFor returns the staging zones that serve the given airport zone.
+func (c *ZoneCache) StagingFor(airportID string) []Zone {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []Zone
+	for _, z := range c.zones {
+		if z.Type == ZoneTypeAirportStaging && z.ParentID == airportID {
+			result = append(result, z)
+		}
+	}
+	return result
+}
+
+// InServiceArea reports whether pt is inside any service area.
+// When no service area is configured every point is considered serviceable.
+func (c *ZoneCache) InServiceArea(pt Point) bool {
+	areas := c.ByType(ZoneTypeServiceArea)
+	if len(areas) == 0 {
+		return true
+	}
+	for _, z := range areas {
+		if z.Polygon.Contains(pt) {
+			return true
+		}
+	}
+	return false
+}
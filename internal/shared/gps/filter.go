@@ -0,0 +1,171 @@
+package gps
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"ride-hail/internal/shared/geo"
+)
+
+// Verdict is what the filter decided about a fix.
+type Verdict string
+
+const (
+	// Accepted fixes are smoothed and used as the driver's position
+	Accepted Verdict = "ACCEPTED"
+	// Suspicious fixes are used too but flagged as possible spoofing
+	Suspicious Verdict = "SUSPICIOUS"
+	// Rejected fixes are dropped; the position stays where it was
+	Rejected Verdict = "REJECTED"
+)
+
+// Reasons given with suspicious and rejected fixes
+const (
+	ReasonImpossibleJump = "impossible_jump"
+	ReasonLowAccuracy    = "low_accuracy"
+	ReasonSpeedMismatch  = "speed_mismatch"
+	ReasonTrackReset     = "track_reset"
+)
+
+// Fix is a position reported by a driver's device.
+type Fix struct {
+	Point          geo.Point
+	AccuracyMeters *float64
+	SpeedKmh       *float64
+	At             time.Time
+}
+
+// Result is the filtered position together with the raw one it was made from.
+type Result struct {
+	Point   geo.Point
+	Raw     geo.Point
+	Verdict Verdict
+	Reason  string
+}
+
+// Policy tunes the filter.
+type Policy struct {
+	// MaxSpeedKmh is the fastest a car may have travelled between two fixes,
+	// after allowing for their accuracy; jumps beyond it are rejected
+	MaxSpeedKmh float64
+	// MaxAccuracyMeters rejects fixes the device itself does not trust
+	MaxAccuracyMeters float64
+	// DefaultAccuracyMeters is assumed for fixes without accuracy_meters
+	DefaultAccuracyMeters float64
+	// ProcessNoise is how fast, in metres per second, the position is expected to
+	// drift from the last estimate; higher values follow raw fixes more closely
+	ProcessNoise float64
+	// SpeedToleranceKmh flags fixes that moved this much faster than the speed
+	// the device reported
+	SpeedToleranceKmh float64
+	// MaxRejects is the number of jumps in a row after which the filter gives in
+	// and starts over from the new position, flagging it
+	MaxRejects int
+	// MaxGap starts the track over when the driver has not reported for this long
+	MaxGap time.Duration
+}
+
+// DefaultPolicy is used when the service is not configured otherwise.
+var DefaultPolicy = Policy{
+	MaxSpeedKmh:           200,
+	MaxAccuracyMeters:     500,
+	DefaultAccuracyMeters: 20,
+	ProcessNoise:          3,
+	SpeedToleranceKmh:     60,
+	MaxRejects:            3,
+	MaxGap:                5 * time.Minute,
+}
+
+type track struct {
+	point    geo.Point
+	variance float64 // m²
+	at       time.Time
+	rejects  int
+}
+
+// Filter smooths the positions of every driver with a simple Kalman filter and
+// gates out fixes a car could not have reached.
+type Filter struct {
+	mu     sync.Mutex
+	policy Policy
+	tracks map[string]*track
+}
+
+func NewFilter(policy Policy) *Filter {
+	return &Filter{
+		policy: policy,
+		tracks: make(map[string]*track),
+	}
+}
+
+// Apply runs the driver's fix through the filter.
+func (f *Filter) Apply(driverID string, fix Fix) Result {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	accuracy := f.policy.DefaultAccuracyMeters
+	if fix.AccuracyMeters != nil && *fix.AccuracyMeters > 0 {
+		accuracy = math.Max(*fix.AccuracyMeters, 1)
+	}
+
+	t, ok := f.tracks[driverID]
+	if !ok || fix.At.Sub(t.at) > f.policy.MaxGap {
+		f.start(driverID, fix, accuracy)
+		return Result{Point: fix.Point, Raw: fix.Point, Verdict: Accepted}
+	}
+
+	rejected := Result{Point: t.point, Raw: fix.Point, Verdict: Rejected}
+	if accuracy > f.policy.MaxAccuracyMeters {
+		rejected.Reason = ReasonLowAccuracy
+		return rejected
+	}
+
+	elapsed := math.Max(fix.At.Sub(t.at).Seconds(), 1)
+	moved := geo.DistanceKm(t.point, fix.Point)*1000 - accuracy - math.Sqrt(t.variance)
+	speedKmh := math.Max(moved, 0) / elapsed * 3.6
+
+	if speedKmh > f.policy.MaxSpeedKmh {
+		t.rejects++
+		if t.rejects < f.policy.MaxRejects {
+			rejected.Reason = ReasonImpossibleJump
+			return rejected
+		}
+		f.start(driverID, fix, accuracy)
+		return Result{Point: fix.Point, Raw: fix.Point, Verdict: Suspicious, Reason: ReasonTrackReset}
+	}
+	t.rejects = 0
+
+	// Kalman step: the estimate gets less certain with time, then moves towards
+	// the fix as far as their accuracies warrant
+	t.variance += elapsed * f.policy.ProcessNoise * f.policy.ProcessNoise
+	gain := t.variance / (t.variance + accuracy*accuracy)
+	t.point = geo.Point{
+		Lat: t.point.Lat + gain*(fix.Point.Lat-t.point.Lat),
+		Lng: t.point.Lng + gain*(fix.Point.Lng-t.point.Lng),
+	}
+	t.variance *= 1 - gain
+	t.at = fix.At
+
+	result := Result{Point: t.point, Raw: fix.Point, Verdict: Accepted}
+	if fix.SpeedKmh != nil && speedKmh > *fix.SpeedKmh+f.policy.SpeedToleranceKmh {
+		result.Verdict = Suspicious
+		result.Reason = ReasonSpeedMismatch
+	}
+	return result
+}
+
+// Reset forgets the driver's track, e.g. when they go offline.
+func (f *Filter) Reset(driverID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.tracks, driverID)
+}
+
+func (f *Filter) start(driverID string, fix Fix, accuracy float64) {
+	f.tracks[driverID] = &track{
+		point:    fix.Point,
+		variance: accuracy * accuracy,
+		at:       fix.At,
+	}
+}
@@ -0,0 +1,90 @@
+package gps
+
+import (
+	"testing"
+	"time"
+
+	"ride-hail/internal/shared/geo"
+)
+
+var (
+	start = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	home  = geo.Point{Lat: 43.238949, Lng: 76.889709}
+)
+
+func float(v float64) *float64 { return &v }
+
+// north returns the point metres north of home.
+func north(metres float64) geo.Point {
+	return geo.Point{Lat: home.Lat + metres/111_195, Lng: home.Lng}
+}
+
+func TestFilter_Apply(t *testing.T) {
+	cases := []struct {
+		name        string
+		fix         Fix
+		wantVerdict Verdict
+		wantReason  string
+		wantKeep    bool // filtered position stays at home
+	}{
+		{name: "jitter", fix: Fix{Point: north(15), AccuracyMeters: float(10), At: start.Add(5 * time.Second)}, wantVerdict: Accepted},
+		{name: "driving", fix: Fix{Point: north(150), AccuracyMeters: float(10), SpeedKmh: float(100), At: start.Add(5 * time.Second)}, wantVerdict: Accepted},
+		{name: "impossible jump", fix: Fix{Point: north(5000), AccuracyMeters: float(10), At: start.Add(5 * time.Second)}, wantVerdict: Rejected, wantReason: ReasonImpossibleJump, wantKeep: true},
+		{name: "low accuracy", fix: Fix{Point: north(100), AccuracyMeters: float(900), At: start.Add(5 * time.Second)}, wantVerdict: Rejected, wantReason: ReasonLowAccuracy, wantKeep: true},
+		{name: "faster than reported", fix: Fix{Point: north(200), AccuracyMeters: float(10), SpeedKmh: float(0), At: start.Add(5 * time.Second)}, wantVerdict: Suspicious, wantReason: ReasonSpeedMismatch},
+		{name: "long gap", fix: Fix{Point: north(50_000), AccuracyMeters: float(10), At: start.Add(time.Hour)}, wantVerdict: Accepted},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			f := NewFilter(DefaultPolicy)
+			f.Apply("driver-1", Fix{Point: home, AccuracyMeters: float(10), At: start})
+
+			got := f.Apply("driver-1", tc.fix)
+			if got.Verdict != tc.wantVerdict || got.Reason != tc.wantReason {
+				t.Fatalf("expected %s %q, got %s %q", tc.wantVerdict, tc.wantReason, got.Verdict, got.Reason)
+			}
+			if got.Raw != tc.fix.Point {
+				t.Fatalf("expected the raw fix kept, got %+v", got.Raw)
+			}
+			if tc.wantKeep && got.Point != home {
+				t.Fatalf("expected the position kept at %+v, got %+v", home, got.Point)
+			}
+		})
+	}
+}
+
+func TestFilter_SmoothsJitter(t *testing.T) {
+	f := NewFilter(DefaultPolicy)
+	f.Apply("driver-1", Fix{Point: home, AccuracyMeters: float(10), At: start})
+
+	raw := north(15)
+	got := f.Apply("driver-1", Fix{Point: raw, AccuracyMeters: float(10), At: start.Add(time.Second)})
+	if d := geo.DistanceKm(home, got.Point); d <= 0 || d >= geo.DistanceKm(home, raw) {
+		t.Fatalf("expected the position between the last one and the fix, got %.1f m away", d*1000)
+	}
+}
+
+func TestFilter_ResetsAfterRepeatedJumps(t *testing.T) {
+	f := NewFilter(DefaultPolicy)
+	f.Apply("driver-1", Fix{Point: home, AccuracyMeters: float(10), At: start})
+
+	far := north(20_000)
+	var got Result
+	for i := 1; i <= DefaultPolicy.MaxRejects; i++ {
+		got = f.Apply("driver-1", Fix{Point: far, AccuracyMeters: float(10), At: start.Add(time.Duration(i) * time.Second)})
+	}
+	if got.Verdict != Suspicious || got.Reason != ReasonTrackReset || got.Point != far {
+		t.Fatalf("expected the track to start over flagged, got %+v", got)
+	}
+
+	// Other drivers are not affected, and a reset driver starts from scratch
+	if got := f.Apply("driver-2", Fix{Point: far, At: start}); got.Verdict != Accepted {
+		t.Fatalf("expected the first fix of another driver accepted, got %+v", got)
+	}
+	f.Reset("driver-1")
+	if got := f.Apply("driver-1", Fix{Point: home, At: start.Add(time.Minute)}); got.Verdict != Accepted || got.Point != home {
+		t.Fatalf("expected a fresh track after reset, got %+v", got)
+	}
+}
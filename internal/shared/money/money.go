@@ -0,0 +1,328 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MinorPerMajor is the number of minor units in one major unit. Every currency we
+// operate in has two decimals, which matches the decimal(10,2) columns.
+const MinorPerMajor = 100
+
+var (
+	ErrCurrencyMismatch = errors.New("money: currency mismatch")
+	ErrInvalidAmount    = errors.New("money: invalid amount")
+)
+
+// RoundingMode decides what happens to fractions of a minor unit.
+type RoundingMode int
+
+const (
+	// HalfUp rounds halves away from zero: 0.5 -> 1, -0.5 -> -1.
+	HalfUp RoundingMode = iota
+	// HalfEven rounds halves to the nearest even unit (banker's rounding).
+	HalfEven
+	// Down truncates towards zero.
+	Down
+	// Up rounds away from zero.
+	Up
+)
+
+// Money is an amount in integer minor units with its ISO 4217 currency.
+// The currency may be empty when it comes from context, e.g. tariffs are
+// priced in the currency of the city they are used in.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// New returns an amount given in minor units.
+func New(minor int64, currency string) Money {
+	return Money{Amount: minor, Currency: currency}
+}
+
+// Zero returns a zero amount in the currency.
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// FromMajor converts a float amount in major units, rounding with mode.
+// Use it only at boundaries with float inputs such as rates times distance.
+func FromMajor(major float64, currency string, mode RoundingMode) Money {
+	return Money{Amount: round(major*MinorPerMajor, mode), Currency: currency}
+}
+
+// Parse reads a decimal string such as "1234.5" or "-0.07" in major units.
+// Digits past the second decimal are rounded half up.
+func Parse(s, currency string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Money{}, fmt.Errorf("%w: empty", ErrInvalidAmount)
+	}
+
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	for _, part := range []string{intPart, fracPart} {
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+			}
+		}
+	}
+
+	major, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || major > math.MaxInt64/MinorPerMajor-1 {
+		return Money{}, fmt.Errorf("%w: %q out of range", ErrInvalidAmount, s)
+	}
+
+	fracPart += "000"
+	minor, _ := strconv.ParseInt(fracPart[:2], 10, 64)
+	if fracPart[2] >= '5' {
+		minor++
+	}
+
+	amount := major*MinorPerMajor + minor
+	if neg {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Add returns m + o. Adding amounts in different currencies is a programming
+// error and panics; an empty currency takes the currency of the other operand.
+func (m Money) Add(o Money) Money {
+	return Money{Amount: m.Amount + o.Amount, Currency: m.currencyWith(o)}
+}
+
+// Sub returns m - o with the same currency rules as Add.
+func (m Money) Sub(o Money) Money {
+	return Money{Amount: m.Amount - o.Amount, Currency: m.currencyWith(o)}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Mul multiplies by a float factor such as a distance, rounding with mode.
+func (m Money) Mul(factor float64, mode RoundingMode) Money {
+	return Money{Amount: round(float64(m.Amount)*factor, mode), Currency: m.Currency}
+}
+
+// MulInt multiplies by an integer without rounding.
+func (m Money) MulInt(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// Percent returns pct percent of m, rounding with mode.
+func (m Money) Percent(pct float64, mode RoundingMode) Money {
+	return m.Mul(pct/100, mode)
+}
+
+// Allocate splits m by the ratios so that the parts always add up to m.
+// Leftover minor units go one each to the parts with the largest remainders,
+// earlier parts first on ties. Ratios must not be negative.
+func (m Money) Allocate(ratios ...int64) []Money {
+	parts := make([]Money, len(ratios))
+	var total int64
+	for i, r := range ratios {
+		if r < 0 {
+			panic("money: negative ratio")
+		}
+		total += r
+		parts[i].Currency = m.Currency
+	}
+	if total == 0 {
+		return parts
+	}
+
+	sign := int64(1)
+	amount := m.Amount
+	if amount < 0 {
+		sign, amount = -1, -amount
+	}
+
+	remainders := make([]int64, len(ratios))
+	var allocated int64
+	for i, r := range ratios {
+		parts[i].Amount = amount * r / total
+		remainders[i] = amount * r % total
+		allocated += parts[i].Amount
+	}
+
+	for left := amount - allocated; left > 0; left-- {
+		best := 0
+		for i := range remainders {
+			if remainders[i] > remainders[best] {
+				best = i
+			}
+		}
+		parts[best].Amount++
+		remainders[best] = -1
+	}
+
+	for i := range parts {
+		parts[i].Amount *= sign
+	}
+	return parts
+}
+
+// Split divides m into n parts that differ by at most one minor unit.
+func (m Money) Split(n int) []Money {
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// WithCurrency returns the same amount in the given currency.
+func (m Money) WithCurrency(currency string) Money {
+	return Money{Amount: m.Amount, Currency: currency}
+}
+
+func (m Money) IsZero() bool     { return m.Amount == 0 }
+func (m Money) IsNegative() bool { return m.Amount < 0 }
+
+// LessThan compares amounts with the same currency rules as Add.
+func (m Money) LessThan(o Money) bool {
+	m.currencyWith(o)
+	return m.Amount < o.Amount
+}
+
+// Max returns the larger of m and o.
+func (m Money) Max(o Money) Money {
+	if m.LessThan(o) {
+		return o.WithCurrency(m.currencyWith(o))
+	}
+	return m.WithCurrency(m.currencyWith(o))
+}
+
+// Float64 returns the amount in major units, for metrics and logs only.
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / MinorPerMajor
+}
+
+// Decimal formats the amount in major units with two decimals, e.g. "-12.05".
+func (m Money) Decimal() string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/MinorPerMajor, amount%MinorPerMajor)
+}
+
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) currencyWith(o Money) string {
+	switch {
+	case m.Currency == "":
+		return o.Currency
+	case o.Currency == "" || o.Currency == m.Currency:
+		return m.Currency
+	default:
+		panic(fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency))
+	}
+}
+
+// round converts a fractional number of minor units to an integer.
+func round(x float64, mode RoundingMode) int64 {
+	// Убираем хвосты двоичного представления: 0.285*100 = 28.499999999999996
+	x = math.Round(x*1e6) / 1e6
+
+	switch mode {
+	case HalfEven:
+		return int64(math.RoundToEven(x))
+	case Down:
+		return int64(math.Trunc(x))
+	case Up:
+		if x < 0 {
+			return int64(math.Floor(x))
+		}
+		return int64(math.Ceil(x))
+	default:
+		return int64(math.Round(x))
+	}
+}
+
+// MarshalJSON encodes the amount as a number in major units, e.g. 1234.50,
+// so API fields keep their shape. The currency travels in its own field.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON accepts a number or a quoted decimal string in major units.
+// The number is read from its text, never through a float.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+
+	parsed, err := Parse(s, m.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan implements [sql.Scanner] for decimal columns. NULL scans as zero.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		m.Amount = 0
+		return nil
+	case string:
+		return m.scanString(v)
+	case []byte:
+		return m.scanString(string(v))
+	case int64:
+		m.Amount = v * MinorPerMajor
+		return nil
+	case float64:
+		m.Amount = round(v*MinorPerMajor, HalfUp)
+		return nil
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidAmount, src)
+	}
+}
+
+func (m *Money) scanString(s string) error {
+	parsed, err := Parse(s, m.Currency)
+	if err != nil {
+		return err
+	}
+	m.Amount = parsed.Amount
+	return nil
+}
+
+// Value implements [driver.Valuer]; the amount is stored as a decimal string.
+func (m Money) Value() (driver.Value, error) {
+	return m.Decimal(), nil
+}
@@ -0,0 +1,185 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "1234.50", want: 123450},
+		{in: "1234.5", want: 123450},
+		{in: "7", want: 700},
+		{in: "-0.07", want: -7},
+		{in: ".5", want: 50},
+		{in: "0.125", want: 13},
+		{in: "0.124", want: 12},
+		{in: "abc", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := Parse(tc.in, "KZT")
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Fatalf("expected ErrInvalidAmount, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Amount != tc.want || got.Currency != "KZT" {
+				t.Fatalf("expected %d KZT, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRoundingModes(t *testing.T) {
+	cases := []struct {
+		name  string
+		major float64
+		mode  RoundingMode
+		want  int64
+	}{
+		{name: "half up", major: 0.285, mode: HalfUp, want: 29},
+		{name: "half up negative", major: -0.285, mode: HalfUp, want: -29},
+		{name: "half even down", major: 0.125, mode: HalfEven, want: 12},
+		{name: "half even up", major: 0.135, mode: HalfEven, want: 14},
+		{name: "down", major: 0.129, mode: Down, want: 12},
+		{name: "up", major: 0.121, mode: Up, want: 13},
+		{name: "up negative", major: -0.121, mode: Up, want: -13},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := FromMajor(tc.major, "", tc.mode); got.Amount != tc.want {
+				t.Fatalf("expected %d, got %d", tc.want, got.Amount)
+			}
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	cases := []struct {
+		name   string
+		amount int64
+		ratios []int64
+		want   []int64
+	}{
+		{name: "driver commission", amount: 100001, ratios: []int64{80, 20}, want: []int64{80001, 20000}},
+		{name: "three ways", amount: 100, ratios: []int64{1, 1, 1}, want: []int64{34, 33, 33}},
+		{name: "largest remainder wins", amount: 10, ratios: []int64{3, 7}, want: []int64{3, 7}},
+		{name: "negative", amount: -100, ratios: []int64{1, 1, 1}, want: []int64{-34, -33, -33}},
+		{name: "zero ratios", amount: 100, ratios: []int64{0, 0}, want: []int64{0, 0}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			parts := New(tc.amount, "KZT").Allocate(tc.ratios...)
+
+			var sum int64
+			for i, p := range parts {
+				if p.Amount != tc.want[i] {
+					t.Fatalf("part %d: expected %d, got %d", i, tc.want[i], p.Amount)
+				}
+				sum += p.Amount
+			}
+			if tc.amount != 0 && sum != tc.amount && tc.ratios[0] != 0 {
+				t.Fatalf("parts add up to %d, expected %d", sum, tc.amount)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	fare := New(50000, "KZT").Add(New(1550, "")).Sub(New(50, "KZT"))
+	if fare.Amount != 51500 || fare.Currency != "KZT" {
+		t.Fatalf("unexpected result %v", fare)
+	}
+
+	if got := New(10000, "").Mul(2.345, HalfUp); got.Amount != 23450 {
+		t.Fatalf("expected 23450, got %d", got.Amount)
+	}
+	if got := New(999, "").Percent(20, HalfUp); got.Amount != 200 {
+		t.Fatalf("expected 200, got %d", got.Amount)
+	}
+	if got := New(100, "").Max(New(250, "KZT")); got.Amount != 250 || got.Currency != "KZT" {
+		t.Fatalf("unexpected max %v", got)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic on currency mismatch")
+		}
+	}()
+	New(100, "KZT").Add(New(100, "USD"))
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		Fare Money `json:"fare"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"fare": 1234.5}`), &v); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Fare.Amount != 123450 {
+		t.Fatalf("expected 123450, got %d", v.Fare.Amount)
+	}
+
+	if err := json.Unmarshal([]byte(`{"fare": "-0.07"}`), &v); err != nil || v.Fare.Amount != -7 {
+		t.Fatalf("expected -7, got %d (%v)", v.Fare.Amount, err)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"fare":-0.07}` {
+		t.Fatalf("unexpected JSON %s", data)
+	}
+}
+
+func TestScanAndValue(t *testing.T) {
+	cases := []struct {
+		name string
+		src  any
+		want int64
+	}{
+		{name: "numeric text", src: "1234.56", want: 123456},
+		{name: "bytes", src: []byte("0.10"), want: 10},
+		{name: "integer", src: int64(15), want: 1500},
+		{name: "float", src: 0.285, want: 29},
+		{name: "null", src: nil, want: 0},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			m := New(99, "KZT")
+			if err := m.Scan(tc.src); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if m.Amount != tc.want || m.Currency != "KZT" {
+				t.Fatalf("expected %d KZT, got %v", tc.want, m)
+			}
+		})
+	}
+
+	v, err := New(-1205, "KZT").Value()
+	if err != nil || v != "-12.05" {
+		t.Fatalf("expected -12.05, got %v (%v)", v, err)
+	}
+}
@@ -0,0 +1,127 @@
+package onboarding
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"time"
+)
+
+// Document types
+const (
+	DocLicense      = "LICENSE"
+	DocRegistration = "REGISTRATION"
+	DocInsurance    = "INSURANCE"
+)
+
+// RequiredDocuments must all be approved and unexpired for a driver to go online.
+var RequiredDocuments = []string{DocLicense, DocRegistration, DocInsurance}
+
+// Review statuses
+const (
+	StatusPending  = "PENDING"
+	StatusApproved = "APPROVED"
+	StatusRejected = "REJECTED"
+)
+
+// MaxFileSize is the largest document scan accepted.
+const MaxFileSize = 10 << 20
+
+// DefaultExpiryNotice is how long before expiry drivers hear about a document.
+const DefaultExpiryNotice = 14 * 24 * time.Hour
+
+// contentTypes are the accepted scans with the extension they are stored under.
+var contentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+var ErrInvalidDocument = errors.New("invalid document")
+
+// Document is a scan uploaded by a driver with its review outcome. Each upload
+// is a new document; older uploads of the same type stay for the record.
+type Document struct {
+	ID          string     `json:"id"`
+	DriverID    string     `json:"driver_id"`
+	Type        string     `json:"type"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	StorageKey  string     `json:"-"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	Status      string     `json:"status"`
+	ReviewNote  string     `json:"review_note,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// IsValidType reports whether t is a known document type.
+func IsValidType(t string) bool {
+	return slices.Contains(RequiredDocuments, t)
+}
+
+// Expired reports whether the document is no longer valid at now.
+func (d Document) Expired(now time.Time) bool {
+	return !now.Before(d.ExpiresAt)
+}
+
+// Valid reports whether the document was approved and has not expired.
+func (d Document) Valid(now time.Time) bool {
+	return d.Status == StatusApproved && !d.Expired(now)
+}
+
+// ValidateUpload checks an upload before it is stored. The content type is
+// sniffed from the first bytes of the file rather than trusted from the client.
+// It returns the extension to store the file under.
+func ValidateUpload(docType string, expiresAt time.Time, head []byte, size int64, now time.Time) (string, error) {
+	if !IsValidType(docType) {
+		return "", fmt.Errorf("%w: unknown document type %q", ErrInvalidDocument, docType)
+	}
+	if !expiresAt.After(now) {
+		return "", fmt.Errorf("%w: document has already expired", ErrInvalidDocument)
+	}
+	if size <= 0 {
+		return "", fmt.Errorf("%w: file is empty", ErrInvalidDocument)
+	}
+	if size > MaxFileSize {
+		return "", fmt.Errorf("%w: file is larger than %d MB", ErrInvalidDocument, MaxFileSize>>20)
+	}
+	ext, ok := contentTypes[ContentType(head)]
+	if !ok {
+		return "", fmt.Errorf("%w: only PDF, JPEG and PNG files are accepted", ErrInvalidDocument)
+	}
+	return ext, nil
+}
+
+// ContentType sniffs the content type of a file from its first bytes.
+func ContentType(head []byte) string {
+	return http.DetectContentType(head)
+}
+
+// StorageKey is where a driver's document file is kept.
+func StorageKey(driverID, name, ext string) string {
+	return path.Join("drivers", driverID, name+ext)
+}
+
+// Missing returns the required document types the driver has no approved,
+// unexpired document for, in the order of RequiredDocuments.
+func Missing(docs []Document, now time.Time) []string {
+	var missing []string
+	for _, t := range RequiredDocuments {
+		if !slices.ContainsFunc(docs, func(d Document) bool { return d.Type == t && d.Valid(now) }) {
+			missing = append(missing, t)
+		}
+	}
+	return missing
+}
+
+// DaysLeft is the number of whole days until the document expires, zero once it has.
+func (d Document) DaysLeft(now time.Time) int {
+	if d.Expired(now) {
+		return 0
+	}
+	return int(d.ExpiresAt.Sub(now) / (24 * time.Hour))
+}
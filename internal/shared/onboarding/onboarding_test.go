@@ -0,0 +1,123 @@
+package onboarding
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+var pdf = []byte("%PDF-1.7\n")
+
+func doc(docType, status string, expiresIn time.Duration) Document {
+	return Document{Type: docType, Status: status, ExpiresAt: now.Add(expiresIn)}
+}
+
+func TestMissing(t *testing.T) {
+	year := 365 * 24 * time.Hour
+	cases := []struct {
+		name string
+		docs []Document
+		want []string
+	}{
+		{
+			name: "nothing uploaded",
+			want: []string{DocLicense, DocRegistration, DocInsurance},
+		},
+		{
+			name: "all approved",
+			docs: []Document{
+				doc(DocLicense, StatusApproved, year),
+				doc(DocRegistration, StatusApproved, year),
+				doc(DocInsurance, StatusApproved, year),
+			},
+		},
+		{
+			name: "pending and rejected do not count",
+			docs: []Document{
+				doc(DocLicense, StatusApproved, year),
+				doc(DocRegistration, StatusPending, year),
+				doc(DocInsurance, StatusRejected, year),
+			},
+			want: []string{DocRegistration, DocInsurance},
+		},
+		{
+			name: "expired insurance",
+			docs: []Document{
+				doc(DocLicense, StatusApproved, year),
+				doc(DocRegistration, StatusApproved, year),
+				doc(DocInsurance, StatusApproved, 0),
+			},
+			want: []string{DocInsurance},
+		},
+		{
+			name: "renewed document replaces the expired one",
+			docs: []Document{
+				doc(DocLicense, StatusApproved, year),
+				doc(DocRegistration, StatusApproved, year),
+				doc(DocInsurance, StatusApproved, -time.Hour),
+				doc(DocInsurance, StatusApproved, year),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := Missing(tc.docs, now)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Missing() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateUpload(t *testing.T) {
+	cases := []struct {
+		name      string
+		docType   string
+		expiresAt time.Time
+		head      []byte
+		size      int64
+		wantExt   string
+		wantErr   bool
+	}{
+		{name: "pdf", docType: DocLicense, expiresAt: now.AddDate(1, 0, 0), head: pdf, size: 1024, wantExt: ".pdf"},
+		{name: "png", docType: DocInsurance, expiresAt: now.AddDate(1, 0, 0), head: []byte("\x89PNG\r\n\x1a\n"), size: 1024, wantExt: ".png"},
+		{name: "unknown type", docType: "PASSPORT", expiresAt: now.AddDate(1, 0, 0), head: pdf, size: 1024, wantErr: true},
+		{name: "already expired", docType: DocLicense, expiresAt: now, head: pdf, size: 1024, wantErr: true},
+		{name: "empty file", docType: DocLicense, expiresAt: now.AddDate(1, 0, 0), head: nil, size: 0, wantErr: true},
+		{name: "too large", docType: DocLicense, expiresAt: now.AddDate(1, 0, 0), head: pdf, size: MaxFileSize + 1, wantErr: true},
+		{name: "not a scan", docType: DocLicense, expiresAt: now.AddDate(1, 0, 0), head: []byte("<html>"), size: 1024, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ext, err := ValidateUpload(tc.docType, tc.expiresAt, tc.head, tc.size, now)
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidDocument) {
+					t.Errorf("err = %v, want ErrInvalidDocument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ext != tc.wantExt {
+				t.Errorf("ext = %q, want %q", ext, tc.wantExt)
+			}
+		})
+	}
+}
+
+func TestDocument_DaysLeft(t *testing.T) {
+	if got := doc(DocLicense, StatusApproved, 36*time.Hour).DaysLeft(now); got != 1 {
+		t.Errorf("DaysLeft = %d, want 1", got)
+	}
+	if got := doc(DocLicense, StatusApproved, -time.Hour).DaysLeft(now); got != 0 {
+		t.Errorf("DaysLeft of an expired document = %d, want 0", got)
+	}
+}
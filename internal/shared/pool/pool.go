@@ -0,0 +1,223 @@
+package pool
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"ride-hail/internal/shared/geo"
+)
+
+// RideType is the ride type passengers request for a shared ride. Pooled rides
+// are served by drivers of VehicleType.
+const (
+	RideType    = "POOL"
+	VehicleType = "ECONOMY"
+)
+
+// Stop kinds
+const (
+	StopPickup  = "PICKUP"
+	StopDropoff = "DROPOFF"
+)
+
+var ErrDoesNotFit = errors.New("ride does not fit the pool")
+
+// Stop is a pickup or drop-off of one ride in the driver's plan.
+type Stop struct {
+	RideID   string    `json:"ride_id"`
+	Kind     string    `json:"kind"`
+	Location geo.Point `json:"location"`
+	Address  string    `json:"address,omitempty"`
+}
+
+// Plan is the ordered list of stops the driver still has to make. Riders that are
+// already in the car only have their drop-off left.
+type Plan []Stop
+
+// Policy limits how many riders share a car and how far each of them may be
+// taken out of the way for the others.
+type Policy struct {
+	// Capacity is the number of riders in the car at the same time
+	Capacity int
+	// MaxDetourKm is the extra distance a rider accepts, both on the way to their
+	// pickup and in the car: for riders already in the pool compared to the current
+	// plan, for the new rider compared to being driven there directly
+	MaxDetourKm float64
+}
+
+// DefaultPolicy is used when the service is not configured otherwise.
+var DefaultPolicy = Policy{Capacity: 3, MaxDetourKm: 3}
+
+// Insert adds the pickup and drop-off of a new ride to the plan of a driver at start.
+// Of all the positions that keep every rider within the policy it picks the one
+// with the shortest total route; it returns ErrDoesNotFit when there is none.
+func Insert(start geo.Point, plan Plan, pickup, dropoff Stop, policy Policy) (Plan, error) {
+	currentWait, currentRide := distances(start, plan)
+	directWait := geo.DistanceKm(start, pickup.Location)
+	directRide := geo.DistanceKm(pickup.Location, dropoff.Location)
+
+	var best Plan
+	bestKm := math.Inf(1)
+	for i := 0; i <= len(plan); i++ {
+		for j := i; j <= len(plan); j++ {
+			candidate := make(Plan, 0, len(plan)+2)
+			candidate = append(candidate, plan[:i]...)
+			candidate = append(candidate, pickup)
+			candidate = append(candidate, plan[i:j]...)
+			candidate = append(candidate, dropoff)
+			candidate = append(candidate, plan[j:]...)
+
+			if maxRiders(candidate) > policy.Capacity {
+				continue
+			}
+			wait, ride := distances(start, candidate)
+			if wait[pickup.RideID]-directWait > policy.MaxDetourKm || ride[pickup.RideID]-directRide > policy.MaxDetourKm {
+				continue
+			}
+			if detour(currentWait, wait) > policy.MaxDetourKm || detour(currentRide, ride) > policy.MaxDetourKm {
+				continue
+			}
+
+			if km := RouteKm(start, candidate); km < bestKm {
+				best, bestKm = candidate, km
+			}
+		}
+	}
+
+	if best == nil {
+		return nil, ErrDoesNotFit
+	}
+	return best, nil
+}
+
+// Without returns the plan without the stop of the given kind of the ride, or
+// without all its stops when kind is empty.
+func (p Plan) Without(rideID, kind string) Plan {
+	result := make(Plan, 0, len(p))
+	for _, stop := range p {
+		if stop.RideID == rideID && (kind == "" || stop.Kind == kind) {
+			continue
+		}
+		result = append(result, stop)
+	}
+	return result
+}
+
+// Riders returns the IDs of the rides that still have a stop in the plan.
+func (p Plan) Riders() []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, stop := range p {
+		if !seen[stop.RideID] {
+			seen[stop.RideID] = true
+			result = append(result, stop.RideID)
+		}
+	}
+	return result
+}
+
+// Value implements [driver.Valuer] for jsonb columns.
+func (p Plan) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p)
+}
+
+// Scan implements [sql.Scanner] for jsonb columns.
+func (p *Plan) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	case []byte:
+		return json.Unmarshal(v, p)
+	default:
+		return fmt.Errorf("pool: cannot scan %T into plan", src)
+	}
+}
+
+// RouteKm is the straight-line length of the route from start through every stop.
+func RouteKm(start geo.Point, plan Plan) float64 {
+	km := 0.0
+	at := start
+	for _, stop := range plan {
+		km += geo.DistanceKm(at, stop.Location)
+		at = stop.Location
+	}
+	return km
+}
+
+// distances returns, by ride, the distance driven before each pickup and the
+// distance each rider spends in the car along the plan. Riders already picked up
+// are in the car from the start.
+func distances(start geo.Point, plan Plan) (wait, ride map[string]float64) {
+	wait = make(map[string]float64)
+	ride = make(map[string]float64)
+
+	km := 0.0
+	at := start
+	for _, stop := range plan {
+		km += geo.DistanceKm(at, stop.Location)
+		at = stop.Location
+
+		switch stop.Kind {
+		case StopPickup:
+			wait[stop.RideID] = km
+		case StopDropoff:
+			ride[stop.RideID] = km - wait[stop.RideID]
+		}
+	}
+	return wait, ride
+}
+
+// detour returns the largest increase of a distance in before over after.
+func detour(before, after map[string]float64) float64 {
+	most := 0.0
+	for rideID, km := range before {
+		if d := after[rideID] - km; d > most {
+			most = d
+		}
+	}
+	return most
+}
+
+// maxRiders returns the largest number of riders in the car along the plan.
+func maxRiders(plan Plan) int {
+	riders := len(onBoard(plan))
+	most := riders
+	for _, stop := range plan {
+		switch stop.Kind {
+		case StopPickup:
+			riders++
+		case StopDropoff:
+			riders--
+		}
+		if riders > most {
+			most = riders
+		}
+	}
+	return most
+}
+
+// onBoard returns the rides that are dropped off without being picked up first.
+func onBoard(plan Plan) []string {
+	pickedUp := make(map[string]bool)
+	var result []string
+	for _, stop := range plan {
+		switch stop.Kind {
+		case StopPickup:
+			pickedUp[stop.RideID] = true
+		case StopDropoff:
+			if !pickedUp[stop.RideID] {
+				result = append(result, stop.RideID)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package pool
+
+import (
+	"errors"
+	"testing"
+
+	"ride-hail/internal/shared/geo"
+)
+
+// Points along one street, about 1.1 km apart.
+func point(km float64) geo.Point {
+	return geo.Point{Lat: 43.2 + km/111.2, Lng: 76.9}
+}
+
+func stops(rideID string, from, to float64) (Stop, Stop) {
+	return Stop{RideID: rideID, Kind: StopPickup, Location: point(from)},
+		Stop{RideID: rideID, Kind: StopDropoff, Location: point(to)}
+}
+
+func kinds(plan Plan) []string {
+	var result []string
+	for _, s := range plan {
+		result = append(result, s.RideID+":"+s.Kind)
+	}
+	return result
+}
+
+func TestInsert(t *testing.T) {
+	start := point(0)
+	aPickup, aDropoff := stops("a", 1, 10)
+
+	cases := []struct {
+		name    string
+		plan    Plan
+		from    float64
+		to      float64
+		policy  Policy
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "empty pool",
+			from:   1,
+			to:     10,
+			policy: DefaultPolicy,
+			want:   []string{"b:PICKUP", "b:DROPOFF"},
+		},
+		{
+			name:   "on the way",
+			plan:   Plan{aPickup, aDropoff},
+			from:   3,
+			to:     8,
+			policy: DefaultPolicy,
+			want:   []string{"a:PICKUP", "b:PICKUP", "b:DROPOFF", "a:DROPOFF"},
+		},
+		{
+			name:   "rider already in the car",
+			plan:   Plan{aDropoff},
+			from:   3,
+			to:     12,
+			policy: DefaultPolicy,
+			want:   []string{"b:PICKUP", "a:DROPOFF", "b:DROPOFF"},
+		},
+		{
+			name:    "opposite direction",
+			plan:    Plan{aPickup, aDropoff},
+			from:    5,
+			to:      -5,
+			policy:  DefaultPolicy,
+			wantErr: ErrDoesNotFit,
+		},
+		{
+			name:    "car full",
+			plan:    Plan{aPickup, aDropoff},
+			from:    3,
+			to:      8,
+			policy:  Policy{Capacity: 1, MaxDetourKm: 3},
+			wantErr: ErrDoesNotFit,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pickup, dropoff := stops("b", tc.from, tc.to)
+			plan, err := Insert(start, tc.plan, pickup, dropoff, tc.policy)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			got := kinds(plan)
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("expected %v, got %v", tc.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPlan_Without(t *testing.T) {
+	aPickup, aDropoff := stops("a", 1, 10)
+	bPickup, bDropoff := stops("b", 3, 8)
+	plan := Plan{aPickup, bPickup, bDropoff, aDropoff}
+
+	picked := plan.Without("a", StopPickup)
+	if got := kinds(picked); len(got) != 3 || got[0] != "b:PICKUP" {
+		t.Fatalf("expected the pickup of a removed, got %v", got)
+	}
+
+	cancelled := plan.Without("b", "")
+	if got := kinds(cancelled); len(got) != 2 || got[0] != "a:PICKUP" || got[1] != "a:DROPOFF" {
+		t.Fatalf("expected only the stops of a, got %v", got)
+	}
+	if riders := cancelled.Riders(); len(riders) != 1 || riders[0] != "a" {
+		t.Fatalf("expected rider a, got %v", riders)
+	}
+}
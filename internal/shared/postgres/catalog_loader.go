@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+
+	"ride-hail/internal/shared/pricing"
+)
+
+// CatalogLoader reads vehicle classes and tariffs for the pricing catalogs of the
+// ride and driver services.
+type CatalogLoader struct {
+	db *Database
+}
+
+func NewCatalogLoader(db *Database) *CatalogLoader {
+	return &CatalogLoader{db: db}
+}
+
+// ListVehicleClasses implements [pricing.CatalogLoader].
+func (r *CatalogLoader) ListVehicleClasses(ctx context.Context) ([]pricing.VehicleClass, error) {
+	query := `SELECT value, COALESCE(display_name, value), capacity, tier, is_active FROM vehicle_type`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var classes []pricing.VehicleClass
+	for rows.Next() {
+		var vc pricing.VehicleClass
+		if err := rows.Scan(&vc.Code, &vc.DisplayName, &vc.Capacity, &vc.Tier, &vc.IsActive); err != nil {
+			return nil, err
+		}
+		classes = append(classes, vc)
+	}
+
+	return classes, rows.Err()
+}
+
+// ListTariffs implements [pricing.CatalogLoader].
+func (r *CatalogLoader) ListTariffs(ctx context.Context) ([]pricing.Tariff, error) {
+	query := `SELECT id, vehicle_type, COALESCE(city_id::text, ''), base_fare, rate_per_km, rate_per_min, minimum_fare, waiting_rate_per_min, no_show_fee, effective_from, created_at
+	FROM tariffs`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tariffs []pricing.Tariff
+	for rows.Next() {
+		var t pricing.Tariff
+		if err := rows.Scan(
+			&t.ID,
+			&t.VehicleType,
+			&t.CityID,
+			&t.BaseFare,
+			&t.RatePerKm,
+			&t.RatePerMin,
+			&t.MinimumFare,
+			&t.WaitingRatePerMin,
+			&t.NoShowFee,
+			&t.EffectiveFrom,
+			&t.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tariffs = append(tariffs, t)
+	}
+
+	return tariffs, rows.Err()
+}
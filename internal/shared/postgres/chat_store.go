@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ride-hail/internal/shared/chat"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ChatStore keeps ride chats in ride_messages; both the ride and the driver service use it.
+type ChatStore struct {
+	db *Database
+}
+
+func NewChatStore(db *Database) *ChatStore {
+	return &ChatStore{db: db}
+}
+
+// GetConversation implements [chat.Store].
+func (r *ChatStore) GetConversation(ctx context.Context, rideID string) (chat.Conversation, error) {
+	query := `SELECT id, passenger_id, COALESCE(driver_id::text, ''), status, COALESCE(completed_at, cancelled_at)
+	FROM rides WHERE id = $1`
+
+	conv, err := scanConversation(r.db.QueryRow(ctx, query, rideID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return chat.Conversation{}, chat.ErrNoConversation
+	}
+	return conv, err
+}
+
+// LatestConversation implements [chat.Store].
+func (r *ChatStore) LatestConversation(ctx context.Context, userID string, since time.Time) (chat.Conversation, error) {
+	query := `SELECT id, passenger_id, COALESCE(driver_id::text, ''), status, COALESCE(completed_at, cancelled_at)
+	FROM rides
+	WHERE (passenger_id = $1 OR driver_id = $1)
+		AND driver_id IS NOT NULL
+		AND (status NOT IN ('COMPLETED', 'CANCELLED') OR COALESCE(completed_at, cancelled_at) > $2)
+	ORDER BY created_at DESC
+	LIMIT 1`
+
+	conv, err := scanConversation(r.db.QueryRow(ctx, query, userID, since))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return chat.Conversation{}, chat.ErrNoConversation
+	}
+	return conv, err
+}
+
+func scanConversation(row pgx.Row) (chat.Conversation, error) {
+	var conv chat.Conversation
+	err := row.Scan(&conv.RideID, &conv.PassengerID, &conv.DriverID, &conv.Status, &conv.EndedAt)
+	return conv, err
+}
+
+// SaveMessage implements [chat.Store].
+func (r *ChatStore) SaveMessage(ctx context.Context, msg *chat.Message) error {
+	query := `INSERT INTO ride_messages (ride_id, sender_id, sender_role, body)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at`
+
+	return r.db.QueryRow(ctx, query, msg.RideID, msg.SenderID, msg.SenderRole, msg.Body).
+		Scan(&msg.ID, &msg.CreatedAt)
+}
+
+// RecentMessages implements [chat.Store]. Messages are returned oldest first.
+func (r *ChatStore) RecentMessages(ctx context.Context, rideID string, limit int) ([]chat.Message, error) {
+	query := `SELECT id, ride_id, sender_id, sender_role, body, created_at, delivered_at, read_at
+	FROM (
+		SELECT * FROM ride_messages WHERE ride_id = $1 ORDER BY created_at DESC LIMIT $2
+	) recent
+	ORDER BY created_at`
+
+	rows, err := r.db.Query(ctx, query, rideID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []chat.Message
+	for rows.Next() {
+		var m chat.Message
+		if err := rows.Scan(&m.ID, &m.RideID, &m.SenderID, &m.SenderRole, &m.Body,
+			&m.CreatedAt, &m.DeliveredAt, &m.ReadAt); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, rows.Err()
+}
+
+// MarkDelivered implements [chat.Store].
+func (r *ChatStore) MarkDelivered(ctx context.Context, messageIDs []string, at time.Time) error {
+	query := `UPDATE ride_messages SET delivered_at = $2
+	WHERE id = ANY($1::uuid[]) AND delivered_at IS NULL`
+
+	_, err := r.db.Exec(ctx, query, messageIDs, at)
+	return err
+}
+
+// MarkRead implements [chat.Store]. Read messages also count as delivered.
+func (r *ChatStore) MarkRead(ctx context.Context, rideID, readerID string, at time.Time) ([]string, error) {
+	query := `UPDATE ride_messages
+	SET read_at = $3, delivered_at = COALESCE(delivered_at, $3)
+	WHERE ride_id = $1 AND sender_id <> $2 AND read_at IS NULL
+	RETURNING id`
+
+	rows, err := r.db.Query(ctx, query, rideID, readerID, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
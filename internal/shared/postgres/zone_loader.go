@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"ride-hail/internal/shared/geo"
+)
+
+// ZoneLoader reads the active zones for the zone caches of the ride and driver services.
+type ZoneLoader struct {
+	db *Database
+}
+
+func NewZoneLoader(db *Database) *ZoneLoader {
+	return &ZoneLoader{db: db}
+}
+
+// ListActiveZones implements [geo.ZoneLoader].
+func (r *ZoneLoader) ListActiveZones(ctx context.Context) ([]geo.Zone, error) {
+	query := `SELECT id, name, zone_type, polygon, surcharge_amount, COALESCE(parent_zone_id::text, ''), is_active, created_at, updated_at
+	FROM zones WHERE is_active = true`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var zones []geo.Zone
+	for rows.Next() {
+		var zone geo.Zone
+		var polygonJSON []byte
+		if err := rows.Scan(
+			&zone.ID,
+			&zone.Name,
+			&zone.Type,
+			&polygonJSON,
+			&zone.Surcharge,
+			&zone.ParentID,
+			&zone.IsActive,
+			&zone.CreatedAt,
+			&zone.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(polygonJSON, &zone.Polygon); err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
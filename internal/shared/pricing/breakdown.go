@@ -0,0 +1,134 @@
+package pricing
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"ride-hail/internal/shared/money"
+)
+
+// Line item types of a fare breakdown, in the order they appear on a receipt.
+const (
+	ItemBase          = "base"
+	ItemDistance      = "distance"
+	ItemTime          = "time"
+	ItemMinimumFare   = "minimum_fare"
+	ItemFare          = "fare"
+	ItemZoneSurcharge = "zone_surcharge"
+	ItemWaiting       = "waiting"
+	ItemNoShowFee     = "no_show_fee"
+	ItemPoolDiscount  = "pool_discount"
+	ItemDiscount      = "discount"
+)
+
+var itemLabels = map[string]string{
+	ItemBase:          "Base fare",
+	ItemDistance:      "Distance",
+	ItemTime:          "Time",
+	ItemMinimumFare:   "Minimum fare adjustment",
+	ItemFare:          "Trip fare",
+	ItemZoneSurcharge: "Zone surcharges",
+	ItemWaiting:       "Waiting time",
+	ItemNoShowFee:     "No-show fee",
+	ItemPoolDiscount:  "Shared ride discount",
+	ItemDiscount:      "Discount",
+}
+
+// LineItem is one component of a fare. Discounts are negative.
+type LineItem struct {
+	Type   string      `json:"type"`
+	Label  string      `json:"label"`
+	Amount money.Money `json:"amount"`
+}
+
+// Breakdown is a fare itemized into line items that always add up to Total.
+// It is stored on the ride as jsonb next to the fare it explains.
+type Breakdown struct {
+	Items    []LineItem  `json:"items"`
+	Total    money.Money `json:"total"`
+	Currency string      `json:"currency,omitempty"`
+}
+
+// Add appends a line item and updates the total. Zero amounts are skipped,
+// so the receipt lists only what the passenger actually pays for.
+func (b *Breakdown) Add(itemType string, amount money.Money) {
+	if amount.IsZero() {
+		return
+	}
+	label, ok := itemLabels[itemType]
+	if !ok {
+		label = itemType
+	}
+	b.Items = append(b.Items, LineItem{Type: itemType, Label: label, Amount: amount})
+	b.Total = b.Total.Add(amount)
+}
+
+// Amount returns the sum of the line items of the given type.
+func (b Breakdown) Amount(itemType string) money.Money {
+	total := money.Zero(b.Currency)
+	for _, item := range b.Items {
+		if item.Type == itemType {
+			total = total.Add(item.Amount)
+		}
+	}
+	return total
+}
+
+// WithCurrency returns a copy with every amount in the currency. Tariffs carry
+// no currency, so breakdowns built from them are converted by the caller.
+func (b Breakdown) WithCurrency(currency string) Breakdown {
+	items := make([]LineItem, len(b.Items))
+	for i, item := range b.Items {
+		item.Amount = item.Amount.WithCurrency(currency)
+		items[i] = item
+	}
+	return Breakdown{Items: items, Total: b.Total.WithCurrency(currency), Currency: currency}
+}
+
+// Clone returns a copy that does not share line items with b.
+func (b Breakdown) Clone() Breakdown {
+	b.Items = append([]LineItem(nil), b.Items...)
+	return b
+}
+
+// Value implements [driver.Valuer] for jsonb columns.
+func (b Breakdown) Value() (driver.Value, error) {
+	return json.Marshal(b)
+}
+
+// Scan implements [sql.Scanner] for jsonb columns. Amounts take the currency of the breakdown.
+func (b *Breakdown) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*b = Breakdown{}
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("pricing: cannot scan %T into breakdown", src)
+	}
+
+	var decoded Breakdown
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*b = decoded.WithCurrency(decoded.Currency)
+	return nil
+}
+
+// Breakdown itemizes the trip price into base, distance and time. When they add up
+// to less than the minimum fare the difference is a separate line item.
+func (t Tariff) Breakdown(distanceKm, durationMin float64) Breakdown {
+	var b Breakdown
+	b.Add(ItemBase, t.BaseFare)
+	b.Add(ItemDistance, t.RatePerKm.Mul(distanceKm, money.HalfUp))
+	b.Add(ItemTime, t.RatePerMin.Mul(durationMin, money.HalfUp))
+	if b.Total.LessThan(t.MinimumFare) {
+		b.Add(ItemMinimumFare, t.MinimumFare.Sub(b.Total))
+	}
+	return b
+}
@@ -0,0 +1,93 @@
+package pricing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"ride-hail/internal/shared/money"
+)
+
+func TestTariff_Breakdown(t *testing.T) {
+	tariff := Tariff{
+		BaseFare:    money.New(50000, ""),
+		RatePerKm:   money.New(10000, ""),
+		RatePerMin:  money.New(5000, ""),
+		MinimumFare: money.New(80000, ""),
+	}
+
+	cases := []struct {
+		name        string
+		distanceKm  float64
+		durationMin float64
+		wantItems   []string
+		wantTotal   int64
+	}{
+		{name: "regular trip", distanceKm: 5, durationMin: 10, wantItems: []string{ItemBase, ItemDistance, ItemTime}, wantTotal: 150000},
+		{name: "below minimum", distanceKm: 0.5, durationMin: 1, wantItems: []string{ItemBase, ItemDistance, ItemTime, ItemMinimumFare}, wantTotal: 80000},
+		{name: "no distance", distanceKm: 0, durationMin: 10, wantItems: []string{ItemBase, ItemTime}, wantTotal: 100000},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			b := tariff.Breakdown(tc.distanceKm, tc.durationMin)
+			if len(b.Items) != len(tc.wantItems) {
+				t.Fatalf("expected items %v, got %+v", tc.wantItems, b.Items)
+			}
+			sum := money.Zero("")
+			for i, item := range b.Items {
+				if item.Type != tc.wantItems[i] {
+					t.Fatalf("expected item %d to be %s, got %s", i, tc.wantItems[i], item.Type)
+				}
+				sum = sum.Add(item.Amount)
+			}
+			if b.Total.Amount != tc.wantTotal || sum != b.Total {
+				t.Fatalf("expected total %d matching the items, got %v (items %v)", tc.wantTotal, b.Total, sum)
+			}
+			if got := tariff.Fare(tc.distanceKm, tc.durationMin); got != b.Total {
+				t.Fatalf("Fare %v differs from breakdown total %v", got, b.Total)
+			}
+		})
+	}
+}
+
+func TestBreakdown_Discount(t *testing.T) {
+	var b Breakdown
+	b.Add(ItemFare, money.New(150000, "KZT"))
+	b.Add(ItemZoneSurcharge, money.Zero("KZT"))
+	b.Add(ItemDiscount, money.New(-20000, "KZT"))
+
+	if len(b.Items) != 2 {
+		t.Fatalf("expected zero items to be skipped, got %+v", b.Items)
+	}
+	if b.Total != money.New(130000, "KZT") {
+		t.Fatalf("expected 1300.00 KZT, got %v", b.Total)
+	}
+	if got := b.Amount(ItemDiscount); got.Amount != -20000 {
+		t.Fatalf("expected discount -200.00, got %v", got)
+	}
+}
+
+func TestBreakdown_ScanValue(t *testing.T) {
+	b := Tariff{BaseFare: money.New(50000, ""), RatePerKm: money.New(10000, "")}.
+		Breakdown(2.5, 0).
+		WithCurrency("KZT")
+
+	stored, err := b.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var scanned Breakdown
+	if err := scanned.Scan(stored); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scanned.Total != b.Total || len(scanned.Items) != 2 || scanned.Items[1].Amount != money.New(25000, "KZT") {
+		t.Fatalf("expected %+v, got %+v", b, scanned)
+	}
+
+	body, _ := json.Marshal(scanned.Items[0])
+	if string(body) != `{"type":"base","label":"Base fare","amount":500.00}` {
+		t.Fatalf("unexpected line item JSON %s", body)
+	}
+}
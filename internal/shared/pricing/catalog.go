@@ -0,0 +1,203 @@
+package pricing
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+// VehicleClass is a configurable ride class such as ECONOMY or ELECTRIC.
+// Tier orders classes by comfort: drivers who opt in take rides of lower tiers.
+type VehicleClass struct {
+	Code        string `json:"code"`
+	DisplayName string `json:"display_name"`
+	Capacity    int    `json:"capacity"`
+	Tier        int    `json:"tier"`
+	IsActive    bool   `json:"is_active"`
+}
+
+// CanServe reports whether a car of this class may take a ride of the other,
+// lower class: it is a higher tier and seats at least as many passengers.
+func (vc VehicleClass) CanServe(other VehicleClass) bool {
+	return vc.Tier > other.Tier && vc.Capacity >= other.Capacity
+}
+
+// Tariff is one version of the prices of a vehicle class. A tariff applies
+// from EffectiveFrom until a newer version of the same class takes over.
+// A tariff without CityID applies in every city that has no tariff of its own.
+// WaitingRatePerMin and NoShowFee apply while the driver waits at pickup, see WaitingPolicy.
+type Tariff struct {
+	ID                string      `json:"id"`
+	VehicleType       string      `json:"vehicle_type"`
+	CityID            string      `json:"city_id,omitempty"`
+	BaseFare          money.Money `json:"base_fare"`
+	RatePerKm         money.Money `json:"rate_per_km"`
+	RatePerMin        money.Money `json:"rate_per_min"`
+	MinimumFare       money.Money `json:"minimum_fare"`
+	WaitingRatePerMin money.Money `json:"waiting_rate_per_min"`
+	NoShowFee         money.Money `json:"no_show_fee"`
+	EffectiveFrom     time.Time   `json:"effective_from"`
+	CreatedAt         time.Time   `json:"created_at"`
+}
+
+// Fare calculates the trip price, never going below the minimum fare.
+// Tariffs carry no currency, the result is in the currency of the caller.
+func (t Tariff) Fare(distanceKm, durationMin float64) money.Money {
+	return t.Breakdown(distanceKm, durationMin).Total
+}
+
+// CatalogLoader loads vehicle classes and all tariff versions from storage.
+type CatalogLoader interface {
+	ListVehicleClasses(ctx context.Context) ([]VehicleClass, error)
+	ListTariffs(ctx context.Context) ([]Tariff, error)
+}
+
+// Catalog keeps vehicle classes and tariffs in memory and reloads them periodically,
+// so admin changes are picked up without a restart.
+type Catalog struct {
+	loader CatalogLoader
+
+	mu      sync.RWMutex
+	classes map[string]VehicleClass
+	tariffs map[string][]Tariff // by vehicle type, newest effective_from first
+}
+
+func NewCatalog(loader CatalogLoader) *Catalog {
+	return &Catalog{
+		loader:  loader,
+		classes: make(map[string]VehicleClass),
+		tariffs: make(map[string][]Tariff),
+	}
+}
+
+// Refresh reloads the catalog. On error the previous snapshot is kept.
+func (c *Catalog) Refresh(ctx context.Context) error {
+	classes, err := c.loader.ListVehicleClasses(ctx)
+	if err != nil {
+		return err
+	}
+	tariffs, err := c.loader.ListTariffs(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.Set(classes, tariffs)
+	return nil
+}
+
+// Run refreshes the catalog every interval until ctx is cancelled.
+func (c *Catalog) Run(ctx context.Context, interval time.Duration) {
+	if err := c.Refresh(ctx); err != nil {
+		slog.Error("failed to load vehicle classes", "error", err.Error())
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil {
+				slog.Error("failed to refresh vehicle classes", "error", err.Error())
+			}
+		}
+	}
+}
+
+// Set replaces the cached classes and tariffs.
+func (c *Catalog) Set(classes []VehicleClass, tariffs []Tariff) {
+	byCode := make(map[string]VehicleClass, len(classes))
+	for _, vc := range classes {
+		byCode[vc.Code] = vc
+	}
+
+	byType := make(map[string][]Tariff)
+	for _, t := range tariffs {
+		byType[t.VehicleType] = append(byType[t.VehicleType], t)
+	}
+	for _, versions := range byType {
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i].EffectiveFrom.After(versions[j].EffectiveFrom)
+		})
+	}
+
+	c.mu.Lock()
+	c.classes = byCode
+	c.tariffs = byType
+	c.mu.Unlock()
+}
+
+// IsValid reports whether code is an active vehicle class.
+func (c *Catalog) IsValid(code string) bool {
+	vc, ok := c.Class(code)
+	return ok && vc.IsActive
+}
+
+// Class returns the vehicle class by code.
+func (c *Catalog) Class(code string) (VehicleClass, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	vc, ok := c.classes[code]
+	return vc, ok
+}
+
+// Classes returns all cached vehicle classes ordered by code.
+func (c *Catalog) Classes() []VehicleClass {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]VehicleClass, 0, len(c.classes))
+	for _, vc := range c.classes {
+		result = append(result, vc)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Code < result[j].Code })
+	return result
+}
+
+// UpgradesFor returns the active classes whose drivers may take rides of the class
+// as a lower class, ordered by code.
+func (c *Catalog) UpgradesFor(code string) []string {
+	ride, ok := c.Class(code)
+	if !ok {
+		return nil
+	}
+
+	var result []string
+	for _, vc := range c.Classes() {
+		if vc.IsActive && vc.CanServe(ride) {
+			result = append(result, vc.Code)
+		}
+	}
+	return result
+}
+
+// TariffAt returns the tariff version of the class that was in effect in the city
+// at the given time, falling back to the tariff shared by all cities.
+func (c *Catalog) TariffAt(code, cityID string, at time.Time) (Tariff, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if cityID != "" {
+		if t, ok := latestAt(c.tariffs[code], cityID, at); ok {
+			return t, true
+		}
+	}
+	return latestAt(c.tariffs[code], "", at)
+}
+
+// latestAt picks the newest version of the city that is not in the future.
+// versions must be sorted newest first.
+func latestAt(versions []Tariff, cityID string, at time.Time) (Tariff, bool) {
+	for _, t := range versions {
+		if t.CityID == cityID && !t.EffectiveFrom.After(at) {
+			return t, true
+		}
+	}
+	return Tariff{}, false
+}
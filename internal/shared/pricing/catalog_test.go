@@ -0,0 +1,148 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+func TestTariff_Fare(t *testing.T) {
+	tariff := Tariff{
+		BaseFare:    money.New(50000, ""),
+		RatePerKm:   money.New(10000, ""),
+		RatePerMin:  money.New(5000, ""),
+		MinimumFare: money.New(80000, ""),
+	}
+
+	if got := tariff.Fare(5, 10); got.Amount != 150000 {
+		t.Fatalf("expected 1500.00, got %v", got)
+	}
+	if got := tariff.Fare(5.123, 10); got.Amount != 151230 {
+		t.Fatalf("expected 1512.30, got %v", got)
+	}
+	if got := tariff.Fare(0.5, 1); got.Amount != 80000 {
+		t.Fatalf("expected minimum fare 800.00, got %v", got)
+	}
+}
+
+func TestCatalog_IsValid(t *testing.T) {
+	c := NewCatalog(nil)
+	c.Set([]VehicleClass{
+		{Code: "ECONOMY", IsActive: true},
+		{Code: "ELECTRIC", IsActive: true},
+		{Code: "RETIRED", IsActive: false},
+	}, nil)
+
+	cases := []struct {
+		code string
+		want bool
+	}{
+		{code: "ECONOMY", want: true},
+		{code: "ELECTRIC", want: true},
+		{code: "RETIRED", want: false},
+		{code: "LUX", want: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.code, func(t *testing.T) {
+			if got := c.IsValid(tc.code); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCatalog_UpgradesFor(t *testing.T) {
+	c := NewCatalog(nil)
+	c.Set([]VehicleClass{
+		{Code: "ECONOMY", Tier: 1, Capacity: 4, IsActive: true},
+		{Code: "PREMIUM", Tier: 2, Capacity: 4, IsActive: true},
+		{Code: "XL", Tier: 2, Capacity: 6, IsActive: true},
+		{Code: "LUX", Tier: 3, Capacity: 4, IsActive: false},
+	}, nil)
+
+	cases := []struct {
+		code string
+		want []string
+	}{
+		{code: "ECONOMY", want: []string{"PREMIUM", "XL"}},
+		// Same tier is not a lower class
+		{code: "PREMIUM", want: nil},
+		// A premium car seats fewer passengers than XL rides need
+		{code: "XL", want: nil},
+		{code: "UNKNOWN", want: nil},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.code, func(t *testing.T) {
+			got := c.UpgradesFor(tc.code)
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("expected %v, got %v", tc.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCatalog_TariffAt(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	c := NewCatalog(nil)
+	c.Set(nil, []Tariff{
+		{ID: "v1", VehicleType: "ECONOMY", BaseFare: money.New(50000, ""), EffectiveFrom: jan},
+		{ID: "v2", VehicleType: "ECONOMY", BaseFare: money.New(60000, ""), EffectiveFrom: mar},
+	})
+
+	if _, ok := c.TariffAt("ECONOMY", "", jan.Add(-time.Hour)); ok {
+		t.Fatal("expected no tariff before the first version")
+	}
+	if got, _ := c.TariffAt("ECONOMY", "", mar.Add(-time.Hour)); got.ID != "v1" {
+		t.Fatalf("expected v1, got %s", got.ID)
+	}
+	if got, _ := c.TariffAt("ECONOMY", "", mar); got.ID != "v2" {
+		t.Fatalf("expected v2, got %s", got.ID)
+	}
+	if _, ok := c.TariffAt("XL", "", mar); ok {
+		t.Fatal("expected no tariff for unknown class")
+	}
+}
+
+func TestCatalog_TariffAt_City(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := NewCatalog(nil)
+	c.Set(nil, []Tariff{
+		{ID: "shared", VehicleType: "ECONOMY", EffectiveFrom: jan},
+		{ID: "astana", VehicleType: "ECONOMY", CityID: "nqz", EffectiveFrom: jan.Add(24 * time.Hour)},
+	})
+
+	cases := []struct {
+		name   string
+		cityID string
+		at     time.Time
+		want   string
+	}{
+		{name: "city tariff", cityID: "nqz", at: jan.Add(48 * time.Hour), want: "astana"},
+		{name: "city tariff not yet effective", cityID: "nqz", at: jan, want: "shared"},
+		{name: "city without own tariff", cityID: "ala", at: jan.Add(48 * time.Hour), want: "shared"},
+		{name: "no city", at: jan.Add(48 * time.Hour), want: "shared"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := c.TariffAt("ECONOMY", tc.cityID, tc.at)
+			if !ok || got.ID != tc.want {
+				t.Fatalf("expected %s, got %s (ok=%v)", tc.want, got.ID, ok)
+			}
+		})
+	}
+}
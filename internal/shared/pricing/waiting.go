@@ -0,0 +1,47 @@
+package pricing
+
+import (
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+// Defaults used when the service is not configured otherwise.
+const (
+	DefaultWaitingGrace = 3 * time.Minute
+	DefaultNoShowAfter  = 5 * time.Minute
+)
+
+// WaitingPolicy governs the time a driver waits at pickup: the first Grace is free,
+// every started minute after it is charged at the tariff's waiting rate, and after
+// NoShowAfter the driver may give up and cancel the ride with the no-show fee.
+// Both durations are counted from the driver's arrival.
+type WaitingPolicy struct {
+	Grace       time.Duration
+	NoShowAfter time.Duration
+}
+
+// FreeUntil returns when waiting charges start.
+func (p WaitingPolicy) FreeUntil(arrivedAt time.Time) time.Time {
+	return arrivedAt.Add(p.Grace)
+}
+
+// NoShowAt returns when the driver may mark the passenger as a no-show.
+func (p WaitingPolicy) NoShowAt(arrivedAt time.Time) time.Time {
+	return arrivedAt.Add(p.NoShowAfter)
+}
+
+// ChargeableMinutes counts the started minutes of waiting after the grace period.
+func (p WaitingPolicy) ChargeableMinutes(arrivedAt, pickedUpAt time.Time) int {
+	over := pickedUpAt.Sub(p.FreeUntil(arrivedAt))
+	if over <= 0 {
+		return 0
+	}
+	return int((over + time.Minute - 1) / time.Minute)
+}
+
+// WaitingCharge prices the waiting between arrival and pickup with the tariff.
+// Tariffs carry no currency, the result is in the currency of the caller.
+func (p WaitingPolicy) WaitingCharge(t Tariff, arrivedAt, pickedUpAt time.Time) money.Money {
+	return t.WaitingRatePerMin.MulInt(int64(p.ChargeableMinutes(arrivedAt, pickedUpAt)))
+}
@@ -0,0 +1,51 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+func TestWaitingPolicy_WaitingCharge(t *testing.T) {
+	policy := WaitingPolicy{Grace: 3 * time.Minute, NoShowAfter: 5 * time.Minute}
+	tariff := Tariff{WaitingRatePerMin: money.New(4000, "")}
+	arrived := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name        string
+		waited      time.Duration
+		wantMinutes int
+		wantAmount  int64
+	}{
+		{name: "picked up right away", waited: 30 * time.Second},
+		{name: "end of grace period", waited: 3 * time.Minute},
+		{name: "a second over", waited: 3*time.Minute + time.Second, wantMinutes: 1, wantAmount: 4000},
+		{name: "two and a half minutes over", waited: 5*time.Minute + 30*time.Second, wantMinutes: 3, wantAmount: 12000},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pickedUp := arrived.Add(tc.waited)
+			if got := policy.ChargeableMinutes(arrived, pickedUp); got != tc.wantMinutes {
+				t.Fatalf("expected %d minutes, got %d", tc.wantMinutes, got)
+			}
+			if got := policy.WaitingCharge(tariff, arrived, pickedUp); got.Amount != tc.wantAmount {
+				t.Fatalf("expected %d, got %v", tc.wantAmount, got)
+			}
+		})
+	}
+}
+
+func TestWaitingPolicy_NoShowAt(t *testing.T) {
+	policy := WaitingPolicy{Grace: 3 * time.Minute, NoShowAfter: 5 * time.Minute}
+	arrived := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if got := policy.NoShowAt(arrived); !got.Equal(arrived.Add(5 * time.Minute)) {
+		t.Fatalf("unexpected no-show time %v", got)
+	}
+	if got := policy.FreeUntil(arrived); !got.Equal(arrived.Add(3 * time.Minute)) {
+		t.Fatalf("unexpected end of free waiting %v", got)
+	}
+}
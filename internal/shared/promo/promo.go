@@ -0,0 +1,161 @@
+package promo
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+// Discount types
+const (
+	DiscountPercent = "PERCENT"
+	DiscountFixed   = "FIXED"
+)
+
+// Redemption statuses
+const (
+	RedemptionReserved = "RESERVED"
+	RedemptionConsumed = "CONSUMED"
+	RedemptionReleased = "RELEASED"
+)
+
+var (
+	ErrInvalidCampaign = errors.New("invalid promo campaign")
+	ErrCodeNotFound    = errors.New("promo code not found")
+	ErrCodeInactive    = errors.New("promo code is not active")
+	ErrCodeExpired     = errors.New("promo code is not valid at this time")
+	ErrCodeNotEligible = errors.New("promo code does not apply to this ride")
+	ErrCodeExhausted   = errors.New("promo code has been fully redeemed")
+	ErrCodeUsedUp      = errors.New("promo code redemption limit reached for this passenger")
+)
+
+var codePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// Campaign is a promo code with its discount, validity window, limits and eligibility.
+// Zero limits are unlimited, empty VehicleTypes and CityIDs apply everywhere.
+// AmountOff and MaxDiscount are in the currency of the ride unless Currency restricts
+// the code to one currency.
+type Campaign struct {
+	ID             string      `json:"id"`
+	Code           string      `json:"code"`
+	Name           string      `json:"name"`
+	DiscountType   string      `json:"discount_type"`
+	PercentOff     int         `json:"percent_off,omitempty"`
+	AmountOff      money.Money `json:"amount_off"`
+	MaxDiscount    money.Money `json:"max_discount"`
+	Currency       string      `json:"currency,omitempty"`
+	ValidFrom      *time.Time  `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time  `json:"valid_until,omitempty"`
+	MaxRedemptions int         `json:"max_redemptions,omitempty"`
+	MaxPerUser     int         `json:"max_per_user,omitempty"`
+	VehicleTypes   []string    `json:"vehicle_types"`
+	CityIDs        []string    `json:"city_ids"`
+	IsActive       bool        `json:"is_active"`
+	// Redeemed counts reserved and consumed redemptions
+	Redeemed  int       `json:"redeemed"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NormalizeCode makes codes case-insensitive for passengers.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks the campaign definition.
+func (c Campaign) Validate() error {
+	if !codePattern.MatchString(c.Code) {
+		return fmt.Errorf("%w: code must be 3-32 upper case letters, digits, '-' or '_'", ErrInvalidCampaign)
+	}
+	if c.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCampaign)
+	}
+
+	switch c.DiscountType {
+	case DiscountPercent:
+		if c.PercentOff < 1 || c.PercentOff > 100 {
+			return fmt.Errorf("%w: percent_off must be between 1 and 100", ErrInvalidCampaign)
+		}
+	case DiscountFixed:
+		if c.AmountOff.IsNegative() || c.AmountOff.IsZero() {
+			return fmt.Errorf("%w: amount_off must be positive", ErrInvalidCampaign)
+		}
+	default:
+		return fmt.Errorf("%w: discount_type must be %s or %s", ErrInvalidCampaign, DiscountPercent, DiscountFixed)
+	}
+
+	if c.MaxDiscount.IsNegative() {
+		return fmt.Errorf("%w: max_discount must not be negative", ErrInvalidCampaign)
+	}
+	if c.ValidFrom != nil && c.ValidUntil != nil && !c.ValidUntil.After(*c.ValidFrom) {
+		return fmt.Errorf("%w: valid_until must be after valid_from", ErrInvalidCampaign)
+	}
+	if c.MaxRedemptions < 0 || c.MaxPerUser < 0 {
+		return fmt.Errorf("%w: limits must not be negative", ErrInvalidCampaign)
+	}
+	return nil
+}
+
+// CheckEligible reports whether the code can be applied to a ride of the vehicle
+// type in the city and currency at the given time.
+func (c Campaign) CheckEligible(vehicleType, cityID, currency string, at time.Time) error {
+	if !c.IsActive {
+		return ErrCodeInactive
+	}
+	if (c.ValidFrom != nil && at.Before(*c.ValidFrom)) || (c.ValidUntil != nil && !at.Before(*c.ValidUntil)) {
+		return ErrCodeExpired
+	}
+	if len(c.VehicleTypes) > 0 && !slices.Contains(c.VehicleTypes, vehicleType) {
+		return fmt.Errorf("%w: not valid for %s", ErrCodeNotEligible, vehicleType)
+	}
+	if len(c.CityIDs) > 0 && !slices.Contains(c.CityIDs, cityID) {
+		return fmt.Errorf("%w: not valid in this city", ErrCodeNotEligible)
+	}
+	if c.Currency != "" && c.Currency != currency {
+		return fmt.Errorf("%w: not valid for fares in %s", ErrCodeNotEligible, currency)
+	}
+	return nil
+}
+
+// CheckLimits reports whether another redemption fits the global limit, given the
+// redemptions of the campaign, and the per-user limit, given those of the passenger.
+func (c Campaign) CheckLimits(redeemed, redeemedByUser int) error {
+	if c.MaxRedemptions > 0 && redeemed >= c.MaxRedemptions {
+		return ErrCodeExhausted
+	}
+	if c.MaxPerUser > 0 && redeemedByUser >= c.MaxPerUser {
+		return ErrCodeUsedUp
+	}
+	return nil
+}
+
+// Discount returns the amount taken off the fare: never negative and never more
+// than the fare itself. Percent discounts round down to the minor unit.
+func (c Campaign) Discount(fare money.Money) money.Money {
+	if fare.IsNegative() || fare.IsZero() {
+		return money.Zero(fare.Currency)
+	}
+
+	var discount money.Money
+	switch c.DiscountType {
+	case DiscountPercent:
+		discount = fare.Percent(float64(c.PercentOff), money.Down)
+	case DiscountFixed:
+		discount = c.AmountOff.WithCurrency(fare.Currency)
+	default:
+		return money.Zero(fare.Currency)
+	}
+
+	if !c.MaxDiscount.IsZero() && c.MaxDiscount.Amount < discount.Amount {
+		discount = c.MaxDiscount.WithCurrency(fare.Currency)
+	}
+	if fare.LessThan(discount) {
+		discount = fare
+	}
+	return discount
+}
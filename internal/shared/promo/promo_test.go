@@ -0,0 +1,93 @@
+package promo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ride-hail/internal/shared/money"
+)
+
+func TestCampaign_Discount(t *testing.T) {
+	fare := money.New(150000, "KZT")
+
+	cases := []struct {
+		name     string
+		campaign Campaign
+		fare     money.Money
+		want     int64
+	}{
+		{name: "percent", campaign: Campaign{DiscountType: DiscountPercent, PercentOff: 15}, fare: fare, want: 22500},
+		{name: "percent rounds down", campaign: Campaign{DiscountType: DiscountPercent, PercentOff: 15}, fare: money.New(1999, "KZT"), want: 299},
+		{name: "percent capped", campaign: Campaign{DiscountType: DiscountPercent, PercentOff: 50, MaxDiscount: money.New(30000, "")}, fare: fare, want: 30000},
+		{name: "fixed", campaign: Campaign{DiscountType: DiscountFixed, AmountOff: money.New(50000, "")}, fare: fare, want: 50000},
+		{name: "fixed above fare", campaign: Campaign{DiscountType: DiscountFixed, AmountOff: money.New(200000, "")}, fare: fare, want: 150000},
+		{name: "free ride", campaign: Campaign{DiscountType: DiscountPercent, PercentOff: 100}, fare: fare, want: 150000},
+		{name: "zero fare", campaign: Campaign{DiscountType: DiscountFixed, AmountOff: money.New(50000, "")}, fare: money.Zero("KZT"), want: 0},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.campaign.Discount(tc.fare)
+			if got.Amount != tc.want || got.Currency != tc.fare.Currency {
+				t.Fatalf("expected %d %s, got %v", tc.want, tc.fare.Currency, got)
+			}
+		})
+	}
+}
+
+func TestCampaign_CheckEligible(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	base := Campaign{Code: "SUMMER", IsActive: true, VehicleTypes: []string{"ECONOMY"}, CityIDs: []string{"city-1"}}
+	with := func(change func(c *Campaign)) Campaign {
+		c := base
+		change(&c)
+		return c
+	}
+
+	cases := []struct {
+		name     string
+		campaign Campaign
+		vehicle  string
+		city     string
+		wantErr  error
+	}{
+		{name: "eligible", campaign: base, vehicle: "ECONOMY", city: "city-1"},
+		{name: "inactive", campaign: with(func(c *Campaign) { c.IsActive = false }), vehicle: "ECONOMY", city: "city-1", wantErr: ErrCodeInactive},
+		{name: "not started", campaign: with(func(c *Campaign) { c.ValidFrom = &future }), vehicle: "ECONOMY", city: "city-1", wantErr: ErrCodeExpired},
+		{name: "ended", campaign: with(func(c *Campaign) { c.ValidUntil = &past }), vehicle: "ECONOMY", city: "city-1", wantErr: ErrCodeExpired},
+		{name: "other vehicle type", campaign: base, vehicle: "PREMIUM", city: "city-1", wantErr: ErrCodeNotEligible},
+		{name: "other city", campaign: base, vehicle: "ECONOMY", city: "city-2", wantErr: ErrCodeNotEligible},
+		{name: "other currency", campaign: with(func(c *Campaign) { c.Currency = "USD" }), vehicle: "ECONOMY", city: "city-1", wantErr: ErrCodeNotEligible},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.campaign.CheckEligible(tc.vehicle, tc.city, "KZT", now)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCampaign_CheckLimits(t *testing.T) {
+	c := Campaign{MaxRedemptions: 100, MaxPerUser: 1}
+
+	if err := c.CheckLimits(99, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.CheckLimits(100, 0); !errors.Is(err, ErrCodeExhausted) {
+		t.Fatalf("expected %v, got %v", ErrCodeExhausted, err)
+	}
+	if err := c.CheckLimits(10, 1); !errors.Is(err, ErrCodeUsedUp) {
+		t.Fatalf("expected %v, got %v", ErrCodeUsedUp, err)
+	}
+	if err := (Campaign{}).CheckLimits(1000, 1000); err != nil {
+		t.Fatalf("expected unlimited campaign, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package reliability
+
+import "time"
+
+// Outcomes of a ride offer
+const (
+	OutcomePending  = "PENDING"
+	OutcomeAccepted = "ACCEPTED"
+	OutcomeDeclined = "DECLINED"
+	OutcomeExpired  = "EXPIRED"
+	// OutcomeCancelled is an offer the driver accepted and then cancelled the ride
+	OutcomeCancelled = "CANCELLED"
+)
+
+// Levels a driver can be at
+const (
+	LevelOK       = "OK"
+	LevelWarning  = "WARNING"
+	LevelCooldown = "COOLDOWN"
+)
+
+// Rates a level can be reached on
+const (
+	ReasonAcceptance   = "acceptance_rate"
+	ReasonCancellation = "cancellation_rate"
+)
+
+// Defaults used when the service is not configured otherwise.
+const (
+	DefaultWindow               = 50
+	DefaultMinOffers            = 10
+	DefaultWarnAcceptance       = 0.7
+	DefaultCooldownAcceptance   = 0.5
+	DefaultWarnCancellation     = 0.1
+	DefaultCooldownCancellation = 0.2
+	DefaultCooldown             = 30 * time.Minute
+	DefaultPenaltyKm            = 2.0
+)
+
+// Policy turns a driver's recent offers into rates and acts on them. Rates are
+// rolling over the last Window decided offers and only judged from MinOffers
+// on. A rate past its warn threshold gets the driver a warning; past its
+// cooldown threshold the driver gets no offers for Cooldown. In matching, a
+// driver who ignored or cancelled every offer ranks as if up to 2*PenaltyKm
+// further away.
+type Policy struct {
+	Window               int
+	MinOffers            int
+	WarnAcceptance       float64
+	CooldownAcceptance   float64
+	WarnCancellation     float64
+	CooldownCancellation float64
+	Cooldown             time.Duration
+	PenaltyKm            float64
+}
+
+// DefaultPolicy is used when the service is not configured otherwise.
+var DefaultPolicy = Policy{
+	Window:               DefaultWindow,
+	MinOffers:            DefaultMinOffers,
+	WarnAcceptance:       DefaultWarnAcceptance,
+	CooldownAcceptance:   DefaultCooldownAcceptance,
+	WarnCancellation:     DefaultWarnCancellation,
+	CooldownCancellation: DefaultCooldownCancellation,
+	Cooldown:             DefaultCooldown,
+	PenaltyKm:            DefaultPenaltyKm,
+}
+
+// Counts are the outcomes of a driver's decided offers.
+type Counts struct {
+	Accepted  int `json:"accepted"`
+	Declined  int `json:"declined"`
+	Expired   int `json:"expired"`
+	Cancelled int `json:"cancelled"`
+}
+
+// Add counts one more offer with the outcome.
+func (c *Counts) Add(outcome string) {
+	switch outcome {
+	case OutcomeAccepted:
+		c.Accepted++
+	case OutcomeDeclined:
+		c.Declined++
+	case OutcomeExpired:
+		c.Expired++
+	case OutcomeCancelled:
+		c.Cancelled++
+	}
+}
+
+func (c Counts) Offers() int {
+	return c.Accepted + c.Declined + c.Expired + c.Cancelled
+}
+
+// AcceptanceRate is the share of offers accepted, cancelled ones included. It
+// is nil without offers.
+func (c Counts) AcceptanceRate() *float64 {
+	return rate(c.Accepted+c.Cancelled, c.Offers())
+}
+
+// CancellationRate is the share of accepted offers cancelled afterwards. It is
+// nil without accepted offers.
+func (c Counts) CancellationRate() *float64 {
+	return rate(c.Cancelled, c.Accepted+c.Cancelled)
+}
+
+func rate(part, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	r := float64(part) / float64(total)
+	return &r
+}
+
+// Stats are a driver's rolling rates and where they put the driver.
+type Stats struct {
+	Counts           Counts   `json:"offers"`
+	AcceptanceRate   *float64 `json:"acceptance_rate"`
+	CancellationRate *float64 `json:"cancellation_rate"`
+	Level            string   `json:"level"`
+	// Reason is the rate that put the driver above LevelOK
+	Reason string `json:"reason,omitempty"`
+}
+
+// Evaluate works out the rates and the level of a driver with the counts.
+func (p Policy) Evaluate(c Counts) Stats {
+	s := Stats{
+		Counts:           c,
+		AcceptanceRate:   c.AcceptanceRate(),
+		CancellationRate: c.CancellationRate(),
+		Level:            LevelOK,
+	}
+	if c.Offers() < p.MinOffers {
+		return s
+	}
+
+	acceptance, cancellation := value(s.AcceptanceRate, 1), value(s.CancellationRate, 0)
+	switch {
+	case acceptance < p.CooldownAcceptance:
+		s.Level, s.Reason = LevelCooldown, ReasonAcceptance
+	case cancellation > p.CooldownCancellation:
+		s.Level, s.Reason = LevelCooldown, ReasonCancellation
+	case acceptance < p.WarnAcceptance:
+		s.Level, s.Reason = LevelWarning, ReasonAcceptance
+	case cancellation > p.WarnCancellation:
+		s.Level, s.Reason = LevelWarning, ReasonCancellation
+	}
+	return s
+}
+
+// StartsCooldown reports whether the outcome just recorded sends a driver with
+// the stats on a cooldown. Only a missed or cancelled offer does, so a driver
+// coming back from a cooldown can accept their way out of it.
+func (p Policy) StartsCooldown(s Stats, outcome string) bool {
+	return s.Level == LevelCooldown && outcome != OutcomeAccepted && p.Cooldown > 0
+}
+
+// Score ranks a driver distanceKm away for an offer, lower first. Drivers
+// without rates yet are ranked on distance alone.
+func (p Policy) Score(distanceKm float64, acceptance, cancellation *float64) float64 {
+	return distanceKm + p.PenaltyKm*((1-value(acceptance, 1))+value(cancellation, 0))
+}
+
+func value(r *float64, def float64) float64 {
+	if r == nil {
+		return def
+	}
+	return *r
+}
@@ -0,0 +1,84 @@
+package reliability
+
+import (
+	"math"
+	"testing"
+)
+
+func counts(outcomes map[string]int) Counts {
+	var c Counts
+	for outcome, n := range outcomes {
+		for i := 0; i < n; i++ {
+			c.Add(outcome)
+		}
+	}
+	return c
+}
+
+func TestCounts_Rates(t *testing.T) {
+	c := counts(map[string]int{OutcomeAccepted: 6, OutcomeCancelled: 2, OutcomeDeclined: 1, OutcomeExpired: 1})
+
+	if got := *c.AcceptanceRate(); got != 0.8 {
+		t.Errorf("AcceptanceRate() = %v, want 0.8", got)
+	}
+	if got := *c.CancellationRate(); got != 0.25 {
+		t.Errorf("CancellationRate() = %v, want 0.25", got)
+	}
+
+	var none Counts
+	if none.AcceptanceRate() != nil || none.CancellationRate() != nil {
+		t.Error("rates without offers should be nil")
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	cases := []struct {
+		name       string
+		outcomes   map[string]int
+		wantLevel  string
+		wantReason string
+	}{
+		{"too few offers to judge", map[string]int{OutcomeDeclined: 9}, LevelOK, ""},
+		{"reliable", map[string]int{OutcomeAccepted: 19, OutcomeDeclined: 1}, LevelOK, ""},
+		{"ignores some offers", map[string]int{OutcomeAccepted: 6, OutcomeExpired: 4}, LevelWarning, ReasonAcceptance},
+		{"ignores most offers", map[string]int{OutcomeAccepted: 4, OutcomeExpired: 3, OutcomeDeclined: 3}, LevelCooldown, ReasonAcceptance},
+		{"cancels some rides", map[string]int{OutcomeAccepted: 17, OutcomeCancelled: 3}, LevelWarning, ReasonCancellation},
+		{"cancels many rides", map[string]int{OutcomeAccepted: 7, OutcomeCancelled: 3}, LevelCooldown, ReasonCancellation},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := DefaultPolicy.Evaluate(counts(tc.outcomes))
+			if s.Level != tc.wantLevel || s.Reason != tc.wantReason {
+				t.Errorf("Evaluate() = %s (%s), want %s (%s)", s.Level, s.Reason, tc.wantLevel, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestPolicy_StartsCooldown(t *testing.T) {
+	s := DefaultPolicy.Evaluate(counts(map[string]int{OutcomeAccepted: 4, OutcomeExpired: 6}))
+
+	if !DefaultPolicy.StartsCooldown(s, OutcomeExpired) {
+		t.Error("an expired offer past the threshold should start a cooldown")
+	}
+	if DefaultPolicy.StartsCooldown(s, OutcomeAccepted) {
+		t.Error("an accepted offer should never start a cooldown")
+	}
+}
+
+func TestPolicy_Score(t *testing.T) {
+	half, none := 0.5, 0.0
+
+	if got := DefaultPolicy.Score(3, nil, nil); got != 3 {
+		t.Errorf("Score() without rates = %v, want 3", got)
+	}
+	if got := DefaultPolicy.Score(3, &half, &none); math.Abs(got-4) > 1e-9 {
+		t.Errorf("Score() at half acceptance = %v, want 4", got)
+	}
+	// A reliable driver a little further away ranks before an unreliable one
+	if DefaultPolicy.Score(3.5, nil, nil) >= DefaultPolicy.Score(3, &half, &none) {
+		t.Error("reliable driver should rank first")
+	}
+}
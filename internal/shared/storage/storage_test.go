@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalDisk_SaveOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	disk, err := NewLocalDisk(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := "drivers/d1/license.pdf"
+	if err := disk.Save(ctx, key, strings.NewReader("scan")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	f, err := disk.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	data, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "scan" {
+		t.Errorf("content = %q, want %q", data, "scan")
+	}
+
+	if err := disk.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := disk.Open(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open after Delete: err = %v, want ErrNotFound", err)
+	}
+	if err := disk.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing file: %v", err)
+	}
+}
+
+func TestLocalDisk_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+	disk, err := NewLocalDisk(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := []string{"", "../outside", "/etc/passwd", "drivers/../../outside"}
+	for _, key := range keys {
+		key := key
+		t.Run(key, func(t *testing.T) {
+			if err := disk.Save(ctx, key, strings.NewReader("x")); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("Save: err = %v, want ErrInvalidKey", err)
+			}
+			if _, err := disk.Open(ctx, key); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("Open: err = %v, want ErrInvalidKey", err)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"sync"
 	"time"
 
+	"ride-hail/internal/shared/chat"
+
 	"github.com/gorilla/websocket"
 )
 
@@ -18,8 +20,8 @@ const (
 	// Send pings to peer with this period. Must be less than pongWait.
 	pingPeriod = 30 * time.Second
 
-	// Maximum message size allowed from peer.
-	maxMessageSize = 512
+	// Maximum message size allowed from peer: room for a chat message.
+	maxMessageSize = chat.MaxFrameSize
 
 	// Time allowed to authenticate after connection.
 	authTimeout = 5 * time.Second
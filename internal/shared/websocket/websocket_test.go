@@ -1,8 +1,16 @@
 package websocket
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"ride-hail/internal/shared/chat"
+
+	"github.com/gorilla/websocket"
 )
 
 func TestNewHub(t *testing.T) {
@@ -84,10 +92,59 @@ func TestClientConstants(t *testing.T) {
 	if pingPeriod != 30*time.Second {
 		t.Errorf("expected pingPeriod 30s, got %v", pingPeriod)
 	}
-	if maxMessageSize != 512 {
-		t.Errorf("expected maxMessageSize 512, got %d", maxMessageSize)
+	if maxMessageSize != chat.MaxFrameSize {
+		t.Errorf("expected maxMessageSize %d, got %d", chat.MaxFrameSize, maxMessageSize)
 	}
 	if authTimeout != 5*time.Second {
 		t.Errorf("expected authTimeout 5s, got %v", authTimeout)
 	}
 }
+
+func TestClient_ReadsLongestChatMessage(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	received := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		client := NewClient(hub, conn, "passenger-1", "PASSENGER")
+		go client.ReadPump(func(c *Client, message []byte) {
+			received <- message
+		})
+	}))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	// 4-byte characters are the worst case for the frame size
+	body := strings.Repeat("😀", chat.MaxBodyLength)
+	frame, _ := json.Marshal(map[string]string{
+		"type":       "chat_message",
+		"request_id": "req-1",
+		"ride_id":    "550e8400-e29b-41d4-a716-446655440000",
+		"body":       body,
+	})
+	if err := conn.WriteMessage(websocket.TextMessage, frame); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	select {
+	case message := <-received:
+		var got struct {
+			Body string `json:"body"`
+		}
+		if err := json.Unmarshal(message, &got); err != nil || got.Body != body {
+			t.Fatalf("expected the whole message to arrive, got %d bytes (%v)", len(message), err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("message was not read")
+	}
+}
@@ -0,0 +1,8 @@
+begin;
+
+alter table rides drop column if exists zone_surcharge;
+drop index if exists idx_zones_active;
+drop table if exists zones cascade;
+drop table if exists zone_type cascade;
+
+commit;
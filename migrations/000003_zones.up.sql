@@ -0,0 +1,31 @@
+begin;
+
+-- Zone type enumeration
+create table "zone_type"("value" text not null primary key);
+insert into
+    "zone_type" ("value")
+values
+    ('SERVICE_AREA'), -- Rides can only be requested inside a service area
+    ('NO_PICKUP'),    -- Pickups are moved to the nearest allowed point
+    ('AIRPORT'),      -- Airport zone with its own surcharge
+    ('SURCHARGE')     -- Generic surcharge zone
+;
+
+-- Admin-managed geofenced zones
+create table zones (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    name varchar(100) not null,
+    zone_type text references "zone_type"(value) not null,
+    polygon jsonb not null, -- [{"lat": 43.2, "lng": 76.8}, ...]
+    surcharge_amount decimal(10,2) not null default 0 check (surcharge_amount >= 0),
+    is_active boolean not null default true
+);
+
+create index idx_zones_active on zones(zone_type) where is_active = true;
+
+-- Zone surcharge applied to the ride fare
+alter table rides add column zone_surcharge decimal(10,2) not null default 0 check (zone_surcharge >= 0);
+
+commit;
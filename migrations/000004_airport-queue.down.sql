@@ -0,0 +1,7 @@
+begin;
+
+alter table zones drop column if exists parent_zone_id;
+delete from zones where zone_type = 'AIRPORT_STAGING';
+delete from "zone_type" where value = 'AIRPORT_STAGING';
+
+commit;
@@ -0,0 +1,13 @@
+begin;
+
+-- Staging area where drivers wait for airport pickups
+insert into
+    "zone_type" ("value")
+values
+    ('AIRPORT_STAGING') -- Drivers queue here first-in-first-out for the linked airport
+;
+
+-- Staging zones point at the airport zone they serve
+alter table zones add column parent_zone_id uuid references zones(id);
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+drop index if exists idx_tariffs_effective;
+drop table if exists tariffs cascade;
+alter table "vehicle_type" drop column if exists is_active;
+alter table "vehicle_type" drop column if exists capacity;
+alter table "vehicle_type" drop column if exists display_name;
+
+commit;
@@ -0,0 +1,36 @@
+begin;
+
+-- Vehicle classes are managed by admins instead of being hard-coded
+alter table "vehicle_type" add column display_name text;
+alter table "vehicle_type" add column capacity integer not null default 4 check (capacity > 0);
+alter table "vehicle_type" add column is_active boolean not null default true;
+
+update "vehicle_type" set display_name = 'Economy', capacity = 4 where value = 'ECONOMY';
+update "vehicle_type" set display_name = 'Premium', capacity = 4 where value = 'PREMIUM';
+update "vehicle_type" set display_name = 'XL', capacity = 6 where value = 'XL';
+
+-- Versioned tariffs: the row with the latest effective_from not in the future applies
+create table tariffs (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    vehicle_type text references "vehicle_type"(value) not null,
+    base_fare decimal(10,2) not null check (base_fare >= 0),
+    rate_per_km decimal(10,2) not null check (rate_per_km >= 0),
+    rate_per_min decimal(10,2) not null check (rate_per_min >= 0),
+    minimum_fare decimal(10,2) not null default 0 check (minimum_fare >= 0),
+    effective_from timestamptz not null default now(),
+    unique (vehicle_type, effective_from)
+);
+
+create index idx_tariffs_effective on tariffs(vehicle_type, effective_from desc);
+
+-- Initial tariffs, same as the former hard-coded pricing table
+insert into
+    tariffs (vehicle_type, base_fare, rate_per_km, rate_per_min, minimum_fare, effective_from)
+values
+    ('ECONOMY', 500, 100, 50, 0, 'epoch'),
+    ('PREMIUM', 800, 120, 60, 0, 'epoch'),
+    ('XL', 1000, 150, 75, 0, 'epoch')
+;
+
+commit;
@@ -0,0 +1,14 @@
+begin;
+
+drop index if exists idx_rides_city;
+alter table rides drop column if exists city_id;
+
+drop index if exists idx_tariffs_version;
+delete from tariffs where city_id is not null;
+alter table tariffs drop column if exists city_id;
+alter table tariffs add constraint tariffs_vehicle_type_effective_from_key unique (vehicle_type, effective_from);
+
+drop index if exists idx_cities_default;
+drop table if exists cities cascade;
+
+commit;
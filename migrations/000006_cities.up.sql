@@ -0,0 +1,40 @@
+begin;
+
+-- Cities (markets) with their own currency, timezone and service area
+create table cities (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    code varchar(10) unique not null,
+    name text not null,
+    currency char(3) not null,
+    timezone text not null,
+    service_area jsonb, -- polygon [{"lat": .., "lng": ..}], rides outside every area go to the default city
+    is_default boolean not null default false,
+    is_active boolean not null default true
+);
+
+-- At most one default city
+create unique index idx_cities_default on cities(is_default) where is_default;
+
+insert into
+    cities (code, name, currency, timezone, is_default)
+values
+    ('ALA', 'Almaty', 'KZT', 'Asia/Almaty', true)
+;
+
+-- Tariffs can be set per city; a tariff without city applies everywhere
+alter table tariffs add column city_id uuid references cities(id);
+alter table tariffs drop constraint tariffs_vehicle_type_effective_from_key;
+create unique index idx_tariffs_version on tariffs(
+    vehicle_type,
+    coalesce(city_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    effective_from
+);
+
+-- Every ride belongs to the city of its pickup point
+alter table rides add column city_id uuid references cities(id);
+update rides set city_id = (select id from cities where is_default);
+create index idx_rides_city on rides(city_id, created_at);
+
+commit;
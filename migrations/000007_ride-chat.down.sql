@@ -0,0 +1,6 @@
+begin;
+
+drop index if exists idx_ride_messages_ride;
+drop table if exists ride_messages;
+
+commit;
@@ -0,0 +1,17 @@
+begin;
+
+-- Chat between the passenger and the driver of a ride
+create table ride_messages (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    ride_id uuid not null references rides(id),
+    sender_id uuid not null references users(id),
+    sender_role text not null check (sender_role in ('PASSENGER', 'DRIVER')),
+    body text not null check (length(body) between 1 and 1000),
+    delivered_at timestamptz,
+    read_at timestamptz
+);
+
+create index idx_ride_messages_ride on ride_messages(ride_id, created_at);
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+drop index if exists idx_ride_events_ride;
+delete from ride_events where event_type = 'PIN_FAILED';
+delete from "ride_event_type" where value = 'PIN_FAILED';
+alter table rides drop column if exists pickup_pin;
+
+commit;
@@ -0,0 +1,15 @@
+begin;
+
+-- PIN the passenger tells the driver at pickup, generated when the ride is matched
+alter table rides add column pickup_pin char(4) check (pickup_pin ~ '^[0-9]{4}$');
+
+insert into
+    "ride_event_type" ("value")
+values
+    ('PIN_FAILED') -- Driver entered a wrong pickup PIN
+;
+
+-- Failed PIN attempts are counted per ride
+create index idx_ride_events_ride on ride_events(ride_id, event_type, created_at);
+
+commit;
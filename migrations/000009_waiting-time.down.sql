@@ -0,0 +1,9 @@
+begin;
+
+delete from ride_events where event_type = 'PASSENGER_NO_SHOW';
+delete from "ride_event_type" where value = 'PASSENGER_NO_SHOW';
+alter table rides drop column if exists waiting_charge;
+alter table tariffs drop column if exists no_show_fee;
+alter table tariffs drop column if exists waiting_rate_per_min;
+
+commit;
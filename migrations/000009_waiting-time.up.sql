@@ -0,0 +1,21 @@
+begin;
+
+-- Waiting after the free grace period is charged per minute; a passenger who
+-- never shows up pays the no-show fee
+alter table tariffs add column waiting_rate_per_min decimal(10,2) not null default 0 check (waiting_rate_per_min >= 0);
+alter table tariffs add column no_show_fee decimal(10,2) not null default 0 check (no_show_fee >= 0);
+
+update tariffs set waiting_rate_per_min = 40, no_show_fee = 500 where vehicle_type = 'ECONOMY' and effective_from = 'epoch';
+update tariffs set waiting_rate_per_min = 50, no_show_fee = 800 where vehicle_type = 'PREMIUM' and effective_from = 'epoch';
+update tariffs set waiting_rate_per_min = 60, no_show_fee = 1000 where vehicle_type = 'XL' and effective_from = 'epoch';
+
+-- Charged for waiting at pickup, fixed when the ride starts and added to the final fare
+alter table rides add column waiting_charge decimal(10,2) not null default 0;
+
+insert into
+    "ride_event_type" ("value")
+values
+    ('PASSENGER_NO_SHOW') -- Driver gave up waiting, the ride was cancelled with the no-show fee
+;
+
+commit;
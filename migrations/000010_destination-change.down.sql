@@ -0,0 +1,6 @@
+begin;
+
+delete from ride_events where event_type = 'DESTINATION_CHANGED';
+delete from "ride_event_type" where value = 'DESTINATION_CHANGED';
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+insert into
+    "ride_event_type" ("value")
+values
+    ('DESTINATION_CHANGED') -- Passenger changed the destination, fare and ETA re-estimated
+;
+
+commit;
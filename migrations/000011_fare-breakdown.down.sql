@@ -0,0 +1,6 @@
+begin;
+
+alter table rides drop column if exists final_fare_breakdown;
+alter table rides drop column if exists estimated_fare_breakdown;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+-- Line items of the estimated and final fares: base, distance, time, surcharges,
+-- waiting, discounts and so on, see pricing.Breakdown
+alter table rides add column estimated_fare_breakdown jsonb;
+alter table rides add column final_fare_breakdown jsonb;
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists promo_redemptions;
+drop table if exists promo_campaigns;
+
+commit;
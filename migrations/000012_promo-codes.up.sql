@@ -0,0 +1,47 @@
+begin;
+
+-- Promo campaigns defined by admins. A code gives either percent_off percent of the
+-- fare, capped by max_discount, or a fixed amount_off. Empty vehicle_types and
+-- city_ids mean the code applies everywhere; null limits mean unlimited.
+create table promo_campaigns (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    code text not null unique check (code ~ '^[A-Z0-9_-]{3,32}$'),
+    name text not null,
+    discount_type text not null check (discount_type in ('PERCENT', 'FIXED')),
+    percent_off integer check (percent_off between 1 and 100),
+    amount_off decimal(10,2) check (amount_off > 0),
+    max_discount decimal(10,2) check (max_discount > 0),
+    currency char(3),
+    valid_from timestamptz,
+    valid_until timestamptz,
+    max_redemptions integer check (max_redemptions > 0),
+    max_per_user integer check (max_per_user > 0),
+    vehicle_types text[] not null default '{}',
+    city_ids uuid[] not null default '{}',
+    is_active boolean not null default true,
+    check (
+        (discount_type = 'PERCENT' and percent_off is not null) or
+        (discount_type = 'FIXED' and amount_off is not null)
+    ),
+    check (valid_until is null or valid_from is null or valid_until > valid_from)
+);
+
+-- One redemption per ride: reserved when the ride is requested, consumed when it
+-- is completed and released when it is cancelled. Reserved and consumed
+-- redemptions count against the limits.
+create table promo_redemptions (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    campaign_id uuid not null references promo_campaigns(id),
+    passenger_id uuid not null references users(id),
+    ride_id uuid not null unique references rides(id),
+    status text not null default 'RESERVED' check (status in ('RESERVED', 'CONSUMED', 'RELEASED')),
+    discount decimal(10,2) not null check (discount >= 0)
+);
+
+create index idx_promo_redemptions_campaign on promo_redemptions(campaign_id, passenger_id) where status <> 'RELEASED';
+
+commit;
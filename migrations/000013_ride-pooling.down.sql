@@ -0,0 +1,7 @@
+begin;
+
+alter table rides drop column if exists pool_id;
+alter table rides drop column if exists is_pooled;
+drop table if exists ride_pools;
+
+commit;
@@ -0,0 +1,21 @@
+begin;
+
+-- A pool is the trip of one driver shared by several POOL rides. The plan is the
+-- ordered list of pickups and drop-offs still ahead; the pool is completed when
+-- the last rider is dropped off or cancelled.
+create table ride_pools (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    driver_id uuid not null references drivers(id),
+    status text not null default 'ACTIVE' check (status in ('ACTIVE', 'COMPLETED')),
+    plan jsonb not null default '[]'
+);
+
+create unique index idx_ride_pools_active_driver on ride_pools(driver_id) where status = 'ACTIVE';
+
+-- POOL rides are requested as ECONOMY rides that may share the car
+alter table rides add column is_pooled boolean not null default false;
+alter table rides add column pool_id uuid references ride_pools(id);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists ride_charges;
+drop table if exists ride_fare_splits;
+
+commit;
@@ -0,0 +1,33 @@
+begin;
+
+-- Passengers the ride owner invited to split the fare. With share_percent set the
+-- participants pay their share and the owner the rest; without it the fare is
+-- divided evenly between the owner and the participants who accepted.
+create table ride_fare_splits (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    ride_id uuid not null references rides(id),
+    passenger_id uuid not null references users(id),
+    status text not null default 'INVITED' check (status in ('INVITED', 'ACCEPTED', 'DECLINED')),
+    share_percent integer check (share_percent between 1 and 99),
+    unique (ride_id, passenger_id)
+);
+
+create index idx_ride_fare_splits_passenger on ride_fare_splits(passenger_id);
+
+-- What each passenger is charged for a finished ride. Charges are settled with
+-- the payment provider outside of the ride service.
+create table ride_charges (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    ride_id uuid not null references rides(id),
+    passenger_id uuid not null references users(id),
+    amount decimal(10,2) not null check (amount >= 0),
+    currency char(3),
+    status text not null default 'PENDING' check (status in ('PENDING', 'SETTLED', 'FAILED')),
+    unique (ride_id, passenger_id)
+);
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+drop index if exists idx_location_history_flagged;
+alter table location_history drop column if exists gps_reason;
+alter table location_history drop column if exists gps_verdict;
+alter table location_history drop column if exists raw_longitude;
+alter table location_history drop column if exists raw_latitude;
+
+commit;
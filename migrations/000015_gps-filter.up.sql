@@ -0,0 +1,15 @@
+begin;
+
+-- Driver positions are filtered before they are used: latitude and longitude keep
+-- the filtered position, raw_* what the device reported. Rejected points never
+-- become the current coordinate but stay in the history for disputes.
+alter table location_history add column raw_latitude decimal(10,8) check (raw_latitude between -90 and 90);
+alter table location_history add column raw_longitude decimal(11,8) check (raw_longitude between -180 and 180);
+alter table location_history add column gps_verdict text not null default 'ACCEPTED'
+    check (gps_verdict in ('ACCEPTED', 'SUSPICIOUS', 'REJECTED'));
+alter table location_history add column gps_reason text;
+
+create index idx_location_history_flagged on location_history(driver_id, recorded_at)
+    where gps_verdict <> 'ACCEPTED';
+
+commit;
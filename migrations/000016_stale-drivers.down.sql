@@ -0,0 +1,5 @@
+begin;
+
+alter table drivers drop column if exists is_stale;
+
+commit;
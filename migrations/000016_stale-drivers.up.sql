@@ -0,0 +1,7 @@
+begin;
+
+-- Drivers who stopped reporting their location are stale: they keep their status
+-- but are not offered rides until they report again or are taken offline.
+alter table drivers add column is_stale boolean not null default false;
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+drop table if exists driver_breaks;
+update drivers set status = 'AVAILABLE' where status = 'ON_BREAK';
+delete from "driver_status" where "value" = 'ON_BREAK';
+
+commit;
@@ -0,0 +1,17 @@
+begin;
+
+-- Drivers on a break keep their session open but are not offered rides
+insert into "driver_status" ("value") values ('ON_BREAK');
+
+-- Breaks taken during a session; a break without ended_at is still going on
+create table driver_breaks (
+    id uuid primary key default gen_random_uuid(),
+    session_id uuid not null references driver_sessions(id),
+    driver_id uuid not null references drivers(id),
+    started_at timestamptz not null default now(),
+    ended_at timestamptz
+);
+
+create unique index idx_driver_breaks_open on driver_breaks(session_id) where ended_at is null;
+
+commit;
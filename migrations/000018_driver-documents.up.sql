@@ -0,0 +1,25 @@
+begin;
+
+-- Scans drivers upload during onboarding; admins approve or reject each one.
+-- Every upload is a new row, older ones stay for the record.
+create table driver_documents (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    driver_id uuid not null references drivers(id),
+    doc_type varchar(20) not null check (doc_type in ('LICENSE', 'REGISTRATION', 'INSURANCE')),
+    storage_key text not null,
+    file_name text not null,
+    content_type varchar(100) not null,
+    size_bytes bigint not null,
+    expires_at timestamptz not null,
+    status varchar(20) not null default 'PENDING' check (status in ('PENDING', 'APPROVED', 'REJECTED')),
+    review_note text,
+    reviewed_at timestamptz,
+    expiry_notified_at timestamptz
+);
+
+create index idx_driver_documents_driver on driver_documents(driver_id, doc_type);
+create index idx_driver_documents_pending on driver_documents(created_at) where status = 'PENDING';
+create index idx_driver_documents_expiry on driver_documents(expires_at) where status = 'APPROVED' and expiry_notified_at is null;
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+drop index if exists idx_ride_events_declined;
+drop index if exists idx_rides_driver;
+drop index if exists idx_driver_sessions_driver;
+delete from ride_events where event_type = 'DRIVER_DECLINED';
+delete from "ride_event_type" where value = 'DRIVER_DECLINED';
+
+commit;
@@ -0,0 +1,14 @@
+begin;
+
+insert into
+    "ride_event_type" ("value")
+values
+    ('DRIVER_DECLINED') -- Driver declined the ride offer, event_data holds driver_id
+;
+
+-- Session history and earnings reports are read per driver and time
+create index idx_driver_sessions_driver on driver_sessions(driver_id, started_at);
+create index idx_rides_driver on rides(driver_id, matched_at);
+create index idx_ride_events_declined on ride_events((event_data->>'driver_id'), created_at) where event_type = 'DRIVER_DECLINED';
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+drop table if exists driver_preferences;
+alter table rides drop column if exists payment_method;
+alter table "vehicle_type" drop column if exists tier;
+
+commit;
@@ -0,0 +1,22 @@
+begin;
+
+-- Tiers order vehicle classes by comfort; drivers may opt in to rides of lower
+-- tiers their car seats enough passengers for, e.g. a PREMIUM car taking ECONOMY rides
+alter table "vehicle_type" add column tier integer not null default 1 check (tier >= 0);
+update "vehicle_type" set tier = 2 where value in ('PREMIUM', 'XL');
+
+alter table rides add column payment_method text not null default 'CARD' check (payment_method in ('CASH', 'CARD'));
+
+-- What work a driver wants; drivers without a row take everything they are matched to
+create table driver_preferences (
+    driver_id uuid primary key references drivers(id),
+    updated_at timestamptz not null default now(),
+    max_pickup_km double precision check (max_pickup_km > 0),
+    accept_lower_classes boolean not null default false,
+    min_trip_km double precision not null default 0 check (min_trip_km >= 0),
+    accept_cash boolean not null default true,
+    accept_card boolean not null default true,
+    check (accept_cash or accept_card)
+);
+
+commit;
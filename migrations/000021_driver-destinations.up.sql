@@ -0,0 +1,21 @@
+begin;
+
+-- Destination mode: a driver heading home only gets rides that bring them closer.
+-- Every use is a row, so the daily limit counts rows created since midnight UTC.
+create table driver_destinations (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    driver_id uuid not null references drivers(id),
+    latitude decimal(10,8) not null check (latitude between -90 and 90),
+    longitude decimal(11,8) not null check (longitude between -180 and 180),
+    address text,
+    expires_at timestamptz not null,
+    ended_at timestamptz,
+    end_reason varchar(20) check (end_reason in ('ARRIVED', 'EXPIRED', 'CLEARED'))
+);
+
+-- At most one destination in use per driver
+create unique index idx_driver_destinations_active on driver_destinations(driver_id) where ended_at is null;
+create index idx_driver_destinations_driver on driver_destinations(driver_id, created_at);
+
+commit;
@@ -0,0 +1,11 @@
+begin;
+
+alter table drivers
+    drop column if exists acceptance_rate,
+    drop column if exists cancellation_rate,
+    drop column if exists reliability_level,
+    drop column if exists reliability_reason,
+    drop column if exists cooldown_until;
+drop table if exists driver_offers;
+
+commit;
@@ -0,0 +1,27 @@
+begin;
+
+-- Every ride offered to a driver and what became of it. Accepted offers turn
+-- CANCELLED when the driver cancels the ride before it starts.
+create table driver_offers (
+    id uuid primary key default gen_random_uuid(),
+    driver_id uuid not null references drivers(id),
+    ride_id uuid not null references rides(id),
+    offered_at timestamptz not null default now(),
+    expires_at timestamptz not null,
+    outcome varchar(20) not null default 'PENDING' check (outcome in ('PENDING', 'ACCEPTED', 'DECLINED', 'EXPIRED', 'CANCELLED')),
+    decided_at timestamptz
+);
+
+create index idx_driver_offers_driver on driver_offers(driver_id, offered_at desc) where outcome <> 'PENDING';
+create index idx_driver_offers_ride on driver_offers(ride_id, driver_id);
+create index idx_driver_offers_pending on driver_offers(expires_at) where outcome = 'PENDING';
+
+-- Rolling rates over the driver's last offers, kept up to date as offers are decided
+alter table drivers
+    add column acceptance_rate double precision,
+    add column cancellation_rate double precision,
+    add column reliability_level varchar(20) not null default 'OK' check (reliability_level in ('OK', 'WARNING', 'COOLDOWN')),
+    add column reliability_reason text,
+    add column cooldown_until timestamptz;
+
+commit;